@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"ccpm-demo/internal/calculator"
+)
+
+// runBatch evaluates each line read from in with calc, so variables set on
+// one line can be used on the next, and writes one result per line to out,
+// as JSON objects when jsonOutput is set. Blank lines and lines starting
+// with # are skipped. A line that fails is reported to errOut with its line
+// number and the rest are still evaluated. It returns the number of lines
+// that failed.
+func runBatch(calc *calculator.Calculator, in io.Reader, out, errOut io.Writer, jsonOutput bool) int {
+	failed := 0
+	scanner := bufio.NewScanner(in)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		name, value, err := evalBatchLine(calc, line)
+		switch {
+		case jsonOutput:
+			if writeErr := writeJSONResult(out, calc, line, value, err); writeErr != nil {
+				fmt.Fprintf(errOut, "Line %d: error writing output: %v\n", lineNumber, writeErr)
+				if err == nil {
+					failed++
+				}
+			}
+		case err != nil:
+			// Reported to errOut below
+		case name != "":
+			fmt.Fprintf(out, "%s = %s\n", name, calc.Format(value))
+		default:
+			fmt.Fprintf(out, "= %s\n", calc.Format(value))
+			if warning := baseWarning(calc, value); warning != "" {
+				fmt.Fprintln(errOut, warning)
+			}
+		}
+
+		if err != nil {
+			fmt.Fprintf(errOut, "Line %d: %v\n", lineNumber, err)
+			failed++
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		fmt.Fprintf(errOut, "Error reading input: %v\n", err)
+		failed++
+	}
+	return failed
+}
+
+// evalBatchLine evaluates a single batch line, which is an expression or a
+// "set" or "const" assignment as in the REPL. For an assignment it returns
+// the name assigned to.
+func evalBatchLine(calc *calculator.Calculator, line string) (string, float64, error) {
+	switch {
+	case strings.HasPrefix(line, "set "):
+		name, value, err := splitAssignment(calc, line[4:])
+		if err == nil {
+			err = calc.SetVariable(name, value)
+		}
+		return name, value, err
+	case strings.HasPrefix(line, "const "):
+		name, value, err := splitAssignment(calc, line[6:])
+		if err == nil {
+			err = calc.SetConstant(name, value)
+		}
+		return name, value, err
+	default:
+		value, err := calc.Evaluate(line)
+		return "", value, err
+	}
+}
@@ -24,6 +24,7 @@ func main() {
 		benchmark    = flag.Bool("benchmark", false, "Run benchmark tests")
 		parallel     = flag.Int("parallel", 1, "Number of parallel test runs")
 		theme        = flag.String("theme", "retro-casio", "Theme to test")
+		copyReport   = flag.Bool("copy-report", false, "Copy the text report to the clipboard after the run")
 	)
 	flag.Parse()
 
@@ -46,6 +47,12 @@ func main() {
 		}
 	}
 
+	// The report is copied with OSC 52, which the terminal forwards to the system clipboard
+	var clipboard ui.Clipboard
+	if *copyReport {
+		clipboard = ui.NewOSC52Clipboard(os.Stdout)
+	}
+
 	startTime := time.Now()
 
 	if *demoMode {
@@ -57,7 +64,7 @@ func main() {
 			log.Fatalf("Benchmark mode failed: %v", err)
 		}
 	} else {
-		if err := runTestMode(model, *outputDir, *updateMode, *tolerance, *verbose, *parallel); err != nil {
+		if err := runTestMode(model, *outputDir, *updateMode, *tolerance, *verbose, *parallel, clipboard); err != nil {
 			log.Fatalf("Test mode failed: %v", err)
 		}
 	}
@@ -66,7 +73,10 @@ func main() {
 	fmt.Printf("\nTotal execution time: %s\n", duration)
 }
 
-func runTestMode(model ui.Model, outputDir string, updateMode bool, tolerance float64, verbose bool, parallel int) error {
+// runTestMode runs the visual regression tests and saves the results. When
+// clipboard is not nil the text report is also copied to it, whether or not
+// the tests passed.
+func runTestMode(model ui.Model, outputDir string, updateMode bool, tolerance float64, verbose bool, parallel int, clipboard ui.Clipboard) error {
 	fmt.Printf("Running visual regression tests...\n")
 	fmt.Printf("Output directory: %s\n", outputDir)
 	fmt.Printf("Update mode: %v\n", updateMode)
@@ -118,6 +128,14 @@ func runTestMode(model ui.Model, outputDir string, updateMode bool, tolerance fl
 	fmt.Printf("Results saved to: %s\n", resultsFile)
 	fmt.Printf("Report saved to: %s\n", reportFile)
 
+	// Copy report
+	if clipboard != nil {
+		if err := clipboard.WriteText(report); err != nil {
+			return fmt.Errorf("failed to copy report: %w", err)
+		}
+		fmt.Printf("Report copied to clipboard\n")
+	}
+
 	// Return error if tests failed
 	if !test.Results.Passed {
 		return fmt.Errorf("visual regression tests failed")
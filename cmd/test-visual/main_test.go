@@ -0,0 +1,63 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ccpm-demo/internal/calculator"
+	"ccpm-demo/internal/ui"
+)
+
+// fakeClipboard records the text written to it
+type fakeClipboard struct {
+	writes []string
+}
+
+func (c *fakeClipboard) WriteText(text string) error {
+	c.writes = append(c.writes, text)
+	return nil
+}
+
+func TestRunTestModeCopiesReport(t *testing.T) {
+	outputDir := t.TempDir()
+	clipboard := &fakeClipboard{}
+
+	err := runTestMode(ui.NewModel(calculator.NewEngine()), outputDir, true, 0.01, false, 1, clipboard)
+	require.NoError(t, err)
+
+	report, err := os.ReadFile(filepath.Join(outputDir, "report.txt"))
+	require.NoError(t, err)
+
+	require.Len(t, clipboard.writes, 1)
+	assert.Equal(t, string(report), clipboard.writes[0])
+	assert.Contains(t, clipboard.writes[0], "Calculator Visual Regression")
+}
+
+func TestRunTestModeCopiesReportAfterFailure(t *testing.T) {
+	outputDir := t.TempDir()
+
+	// Record baselines with one theme, then compare another against them
+	require.NoError(t, runTestMode(ui.NewModel(calculator.NewEngine()), outputDir, true, 0.01, false, 1, nil))
+
+	model := ui.NewModel(calculator.NewEngine())
+	require.NoError(t, model.SetButtonGridTheme("modern"))
+	clipboard := &fakeClipboard{}
+
+	err := runTestMode(model, outputDir, false, 0, false, 1, clipboard)
+	require.Error(t, err)
+
+	require.Len(t, clipboard.writes, 1)
+	assert.Contains(t, clipboard.writes[0], "Failed Tests")
+}
+
+func TestRunTestModeWithoutClipboard(t *testing.T) {
+	outputDir := t.TempDir()
+
+	err := runTestMode(ui.NewModel(calculator.NewEngine()), outputDir, true, 0.01, false, 1, nil)
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(outputDir, "report.txt"))
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -11,6 +13,7 @@ import (
 
 	"ccpm-demo/internal/calculator"
 	"ccpm-demo/internal/ui"
+	"ccpm-demo/internal/ui/input"
 )
 
 func main() {
@@ -24,15 +27,98 @@ func main() {
 	// Create the initial model
 	model := ui.NewModel(calcEngine)
 
-	// Create the Bubble Tea program with options
+	// Honor NO_COLOR and --no-color by rendering plain text
+	if ui.NoColorRequested(os.Args[1:]) {
+		model.SetNoColor(true)
+	}
+
+	// Drop the button borders with --borderless to save space on small terminals
+	if ui.BorderlessRequested(os.Args[1:]) {
+		model.SetBorderless(true)
+	}
+
+	// Show results with a fixed number of decimal places with --precision N
+	if value, ok := flagValue(os.Args[1:], "--precision"); ok {
+		digits, err := strconv.Atoi(value)
+		if err != nil || digits < 0 {
+			fmt.Fprintf(os.Stderr, "Error: --precision needs a number of decimal places, got %q\n", value)
+			os.Exit(1)
+		}
+		model.SetPrecision(digits, calculator.PrecisionDecimals)
+	}
+
+	// Use colors that stay distinct with color-vision deficiencies with --colorblind
+	if hasFlag(os.Args[1:], "--colorblind") {
+		if err := model.SetButtonGridTheme("retro-casio-cb"); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	// Start with a custom theme from a JSON or YAML file with --theme-file
+	if path, ok := flagValue(os.Args[1:], "--theme-file"); ok {
+		name, err := model.LoadThemeFile(path)
+		if err == nil {
+			err = model.SetButtonGridTheme(name)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	// Give the 0 key two columns, like a Casio, with --wide-zero
+	if hasFlag(os.Args[1:], "--wide-zero") {
+		model.SetWideZero(true)
+	}
+
+	// Show the scientific rows for functions and exponents with --scientific
+	if hasFlag(os.Args[1:], "--scientific") {
+		model.SetScientificMode(true)
+	}
+
+	// Count key presses in the status bar with --key-counter, for QA
+	if hasFlag(os.Args[1:], "--key-counter") {
+		model.SetKeyCounter(true)
+	}
+
+	// List the keys available in the current mode in a footer with --key-legend
+	if hasFlag(os.Args[1:], "--key-legend") {
+		model.SetKeyLegend(true)
+	}
+
+	// Evaluate in the background behind a spinner with --async-eval
+	if hasFlag(os.Args[1:], "--async-eval") {
+		model.SetAsyncEvaluation(true)
+	}
+
+	// Copy with OSC 52 on the same stream the program renders to
+	model.SetClipboard(ui.NewOSC52Clipboard(os.Stderr))
+
+	// Highlight buttons under the mouse pointer
+	model.SetHoverTracker(input.NewHoverManager())
+
+	// Follow the keymap given with --keymap, or ~/.ccpm_keys.json if there is one
+	if path, explicit := keymapPath(os.Args[1:]); path != "" {
+		if _, err := os.Stat(path); explicit && os.IsNotExist(err) {
+			fmt.Fprintf(os.Stderr, "Warning: keymap %s not found, using the default keys\n", path)
+		}
+		keymap, err := input.LoadKeyBindingsOrDefault(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v, using the default keys\n", err)
+		}
+		model.SetKeyRemapper(input.NewKeyRemapper(keymap))
+	}
+
+	// Create the Bubble Tea program with options; all motion events are needed for hover
 	opts := []tea.ProgramOption{
 		tea.WithAltScreen(),
-		tea.WithMouseCellMotion(),
+		tea.WithMouseAllMotion(),
 		tea.WithOutput(os.Stderr),
 	}
 
 	// Check terminal capabilities
-	if !ui.IsTerminalCompatible() {
+	if compatible, _ := ui.IsTerminalCompatible(); !compatible {
 		fmt.Fprintln(os.Stderr, "Error: Terminal not compatible with TUI")
 		fmt.Fprintln(os.Stderr, "Required: 256-color support, mouse events")
 		os.Exit(1)
@@ -60,6 +146,38 @@ func main() {
 	fmt.Println("\nCCPM Calculator TUI - Gracefully shutdown")
 }
 
+// keymapPath returns the keymap file given with --keymap, or the default
+// keymap file, and whether it was given
+func keymapPath(args []string) (string, bool) {
+	if path, ok := flagValue(args, "--keymap"); ok {
+		return path, true
+	}
+	return input.DefaultKeymapPath(), false
+}
+
+// hasFlag reports whether flag is among args
+func hasFlag(args []string, flag string) bool {
+	for _, arg := range args {
+		if arg == flag {
+			return true
+		}
+	}
+	return false
+}
+
+// flagValue returns the value of flag given as "flag value" or "flag=value"
+func flagValue(args []string, flag string) (string, bool) {
+	for i, arg := range args {
+		if arg == flag && i+1 < len(args) {
+			return args[i+1], true
+		}
+		if strings.HasPrefix(arg, flag+"=") {
+			return strings.TrimPrefix(arg, flag+"="), true
+		}
+	}
+	return "", false
+}
+
 func init() {
 	// Configure lipgloss for better rendering
 	lipgloss.SetHasDarkBackground(true)
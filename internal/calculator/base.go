@@ -0,0 +1,142 @@
+package calculator
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// NumberBase is the base whole-number results are shown in
+type NumberBase int
+
+const (
+	// BaseDecimal shows results in decimal, with the precision settings
+	BaseDecimal NumberBase = iota
+	// BaseHex shows whole numbers as 0xFF
+	BaseHex
+	// BaseOctal shows whole numbers as 0o17
+	BaseOctal
+	// BaseBinary shows whole numbers as 0b1010
+	BaseBinary
+)
+
+// maxBaseInteger is the largest magnitude shown in a non-decimal base; above
+// it float64 cannot hold every whole number exactly
+const maxBaseInteger = 1 << 53
+
+// String returns the string representation of the number base
+func (b NumberBase) String() string {
+	switch b {
+	case BaseHex:
+		return "hex"
+	case BaseOctal:
+		return "oct"
+	case BaseBinary:
+		return "bin"
+	default:
+		return "dec"
+	}
+}
+
+// Radix returns the numeric base, e.g. 16 for BaseHex
+func (b NumberBase) Radix() int {
+	switch b {
+	case BaseHex:
+		return 16
+	case BaseOctal:
+		return 8
+	case BaseBinary:
+		return 2
+	default:
+		return 10
+	}
+}
+
+// prefix returns the literal prefix the parser reads numbers in the base with
+func (b NumberBase) prefix() string {
+	switch b {
+	case BaseHex:
+		return "0x"
+	case BaseOctal:
+		return "0o"
+	case BaseBinary:
+		return "0b"
+	default:
+		return ""
+	}
+}
+
+// ParseNumberBase parses a base name: hex, dec, oct or bin
+func ParseNumberBase(name string) (NumberBase, error) {
+	for _, base := range []NumberBase{BaseDecimal, BaseHex, BaseOctal, BaseBinary} {
+		if strings.EqualFold(name, base.String()) {
+			return base, nil
+		}
+	}
+	return BaseDecimal, fmt.Errorf("unknown base %q, expected hex, dec, oct or bin", name)
+}
+
+// IsWholeNumber reports whether value can be shown in a non-decimal base
+func IsWholeNumber(value float64) bool {
+	return !math.IsInf(value, 0) && !math.IsNaN(value) && value == math.Trunc(value)
+}
+
+// FormatInteger renders a whole number in base with its literal prefix, so
+// FormatInteger(255, BaseHex) is "0xFF" and reads back as 255. Values that
+// are not whole numbers give ErrNotWholeNumber, and ones too large to be
+// exact give ErrOverflow.
+func FormatInteger(value float64, base NumberBase) (string, error) {
+	if !IsWholeNumber(value) {
+		return "", ErrNotWholeNumber
+	}
+	if math.Abs(value) > maxBaseInteger {
+		return "", ErrOverflow
+	}
+
+	integer := int64(value)
+	sign := ""
+	if integer < 0 {
+		sign, integer = "-", -integer
+	}
+	digits := strings.ToUpper(strconv.FormatInt(integer, base.Radix()))
+	return sign + base.prefix() + digits, nil
+}
+
+// parseBasePrefix returns the base of the prefixed integer literal starting
+// at i, such as 0xFF, or BaseDecimal if there is none
+func parseBasePrefix(expr string, i int) NumberBase {
+	if i+2 >= len(expr) || expr[i] != '0' || !isBaseDigit(expr[i+2]) {
+		return BaseDecimal
+	}
+	switch expr[i+1] {
+	case 'x', 'X':
+		return BaseHex
+	case 'o', 'O':
+		return BaseOctal
+	case 'b', 'B':
+		return BaseBinary
+	default:
+		return BaseDecimal
+	}
+}
+
+// isBaseDigit reports whether c can be part of a prefixed integer literal.
+// Digits invalid in the literal's base are rejected when it is parsed.
+func isBaseDigit(c byte) bool {
+	return isDigitByte(c) || isLetter(c)
+}
+
+// SetBase sets the base Format shows whole-number results in
+func (c *Calculator) SetBase(base NumberBase) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.base = base
+}
+
+// GetBase returns the base whole-number results are shown in
+func (c *Calculator) GetBase() NumberBase {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.base
+}
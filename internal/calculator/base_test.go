@@ -0,0 +1,107 @@
+package calculator
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseBaseLiterals(t *testing.T) {
+	tests := []struct {
+		expr string
+		want float64
+	}{
+		{"0xFF", 255},
+		{"0xff + 0b1010", 265},
+		{"0o17", 15},
+		{"0B11 * 0X10", 48},
+		{"-0x10", -16},
+		{"0x1e5", 485}, // e is a hex digit, not an exponent
+		{"2(0b11)", 6},
+		{"0", 0},
+		{"0.5", 0.5},
+	}
+
+	for _, tt := range tests {
+		got, err := NewParser().Parse(tt.expr)
+		if err != nil {
+			t.Errorf("Parse(%q) failed: %v", tt.expr, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Parse(%q) = %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+
+	for _, expr := range []string{"0b102", "0o8", "0xFG"} {
+		if _, err := NewParser().Parse(expr); !errors.Is(err, ErrInvalidNumber) {
+			t.Errorf("Expected Parse(%q) to be an invalid number, got %v", expr, err)
+		}
+	}
+}
+
+func TestFormatInteger(t *testing.T) {
+	tests := []struct {
+		value float64
+		base  NumberBase
+		want  string
+	}{
+		{255, BaseHex, "0xFF"},
+		{15, BaseOctal, "0o17"},
+		{10, BaseBinary, "0b1010"},
+		{-16, BaseHex, "-0x10"},
+		{0, BaseBinary, "0b0"},
+		{42, BaseDecimal, "42"},
+	}
+
+	for _, tt := range tests {
+		got, err := FormatInteger(tt.value, tt.base)
+		if err != nil || got != tt.want {
+			t.Errorf("FormatInteger(%v, %s) = %q, %v, want %q", tt.value, tt.base, got, err, tt.want)
+		}
+		// The output reads back as the same value
+		if parsed, err := NewParser().Parse(got); err != nil || parsed != tt.value {
+			t.Errorf("Expected %q to parse back as %v, got %v, %v", got, tt.value, parsed, err)
+		}
+	}
+
+	if _, err := FormatInteger(2.5, BaseHex); !errors.Is(err, ErrNotWholeNumber) {
+		t.Errorf("Expected 2.5 not to be a whole number, got %v", err)
+	}
+	if _, err := FormatInteger(1e300, BaseHex); !errors.Is(err, ErrOverflow) {
+		t.Errorf("Expected 1e300 to be too large for hex, got %v", err)
+	}
+}
+
+func TestParseNumberBase(t *testing.T) {
+	for name, want := range map[string]NumberBase{"hex": BaseHex, "DEC": BaseDecimal, "oct": BaseOctal, "bin": BaseBinary} {
+		if got, err := ParseNumberBase(name); err != nil || got != want {
+			t.Errorf("ParseNumberBase(%q) = %v, %v, want %v", name, got, err, want)
+		}
+	}
+	if _, err := ParseNumberBase("base64"); err == nil {
+		t.Error("Expected an unknown base to fail")
+	}
+}
+
+func TestCalculatorBase(t *testing.T) {
+	calc := NewCalculator()
+	calc.SetVariable("x", 2)
+	calc.SetVariable("b", 3)
+
+	// Base literals are not mistaken for variables
+	result, err := calc.Evaluate("0xFF + 0b1010 + x")
+	if err != nil || result != 267 {
+		t.Fatalf("Expected 0xFF + 0b1010 + x = 267, got %v, %v", result, err)
+	}
+
+	calc.SetBase(BaseHex)
+	if got := calc.Format(result); got != "0x10B" {
+		t.Errorf("Expected the result in hex, got %q", got)
+	}
+
+	// Fractions stay in decimal, with the precision settings
+	calc.SetPrecision(2)
+	if got := calc.Format(2.5); got != "2.50" {
+		t.Errorf("Expected a fraction to be shown in decimal, got %q", got)
+	}
+}
@@ -0,0 +1,54 @@
+package calculator
+
+import (
+	"fmt"
+	"math"
+)
+
+// applyBitwise applies a bitwise operator to two whole numbers. Operands with
+// a fractional part give ErrNotWholeNumber.
+func applyBitwise(op string, left, right float64) (float64, error) {
+	a, err := toInteger(op, left)
+	if err != nil {
+		return 0, err
+	}
+	b, err := toInteger(op, right)
+	if err != nil {
+		return 0, err
+	}
+
+	switch op {
+	case "&":
+		return float64(a & b), nil
+	case "|":
+		return float64(a | b), nil
+	case "^^":
+		return float64(a ^ b), nil
+	case "<<", ">>":
+		if b < 0 {
+			return 0, fmt.Errorf("%w: negative shift count %d", ErrInvalidExpression, b)
+		}
+		if op == ">>" {
+			return float64(a >> uint64(b)), nil
+		}
+		shifted := a << uint64(b)
+		if shifted>>uint64(b) != a || math.Abs(float64(shifted)) > maxBaseInteger {
+			return 0, ErrOverflow
+		}
+		return float64(shifted), nil
+	default:
+		return 0, fmt.Errorf("%w: %s", ErrInvalidOperator, op)
+	}
+}
+
+// toInteger converts an operand of op to an integer, refusing fractions and
+// magnitudes float64 cannot hold exactly
+func toInteger(op string, value float64) (int64, error) {
+	if !IsWholeNumber(value) {
+		return 0, fmt.Errorf("%w: %s needs whole numbers, got %v", ErrNotWholeNumber, op, value)
+	}
+	if math.Abs(value) > maxBaseInteger {
+		return 0, ErrOverflow
+	}
+	return int64(value), nil
+}
@@ -0,0 +1,55 @@
+package calculator
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseBitwise(t *testing.T) {
+	tests := []struct {
+		expr string
+		want float64
+	}{
+		{"0xF0 | 0x0F", 255},
+		{"0xFF & 0x0F", 15},
+		{"0b1100 ^^ 0b1010", 6},
+		{"1 << 4", 16},
+		{"256 >> 4", 16},
+		{"-16 >> 2", -4},
+		{"2 ^ 3 ^^ 1", 9},                     // ^ is still a power
+		{"1 + 1 << 2", 8},                     // shifts bind looser than arithmetic
+		{"1 | 2 ^^ 3 & 6", 1 | (2 ^ (3 & 6))}, // | < ^^ < & < shifts
+		{"1 | 6 << 1", 13},
+		{"(1 | 2) * 4", 12},
+		{"3.0 & 1", 1},
+	}
+
+	for _, tt := range tests {
+		got, err := NewParser().Parse(tt.expr)
+		if err != nil {
+			t.Errorf("Parse(%q) failed: %v", tt.expr, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Parse(%q) = %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestParseBitwiseErrors(t *testing.T) {
+	for _, expr := range []string{"1.5 & 1", "1 | 0.25", "2.5 << 1", "1 << 0.5"} {
+		if _, err := NewParser().Parse(expr); !errors.Is(err, ErrNotWholeNumber) {
+			t.Errorf("Expected Parse(%q) to refuse a fraction, got %v", expr, err)
+		}
+	}
+
+	if _, err := NewParser().Parse("1 << 63"); !errors.Is(err, ErrOverflow) {
+		t.Errorf("Expected a shift past the exact range to overflow, got %v", err)
+	}
+	if _, err := NewParser().Parse("1 << -1"); !errors.Is(err, ErrInvalidExpression) {
+		t.Errorf("Expected a negative shift to be invalid, got %v", err)
+	}
+	if _, err := NewParser().Parse("1 &"); err == nil {
+		t.Error("Expected a missing operand to fail")
+	}
+}
@@ -1,7 +1,12 @@
 package calculator
 
 import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 )
 
 // Engine represents the calculator engine state
@@ -9,6 +14,7 @@ type Engine struct {
 	currentValue float64
 	entryValue   float64
 	shouldClear  bool
+	roundingMode RoundingMode
 }
 
 // NewEngine creates a new calculator engine
@@ -22,11 +28,19 @@ func NewEngine() *Engine {
 
 // Evaluate evaluates a mathematical expression and returns the result
 func (e *Engine) Evaluate(expression string) (float64, error) {
+	return e.EvaluateWithContext(context.Background(), expression)
+}
+
+// EvaluateWithContext evaluates a mathematical expression like Evaluate, but
+// stops and returns the context's error once ctx is cancelled
+func (e *Engine) EvaluateWithContext(ctx context.Context, expression string) (float64, error) {
 	if expression == "" {
 		return 0, ErrEmptyExpression
 	}
 
 	parser := NewParser()
+	parser.SetRoundingMode(e.roundingMode)
+	parser.ctx = ctx
 	result, err := parser.Parse(expression)
 	if err != nil {
 		return 0, err
@@ -42,6 +56,22 @@ func (e *Engine) Evaluate(expression string) (float64, error) {
 	return result, nil
 }
 
+// SetRoundingMode sets how the round function and display rounding round values
+func (e *Engine) SetRoundingMode(mode RoundingMode) {
+	e.roundingMode = mode
+}
+
+// GetRoundingMode returns the current rounding mode
+func (e *Engine) GetRoundingMode() RoundingMode {
+	return e.roundingMode
+}
+
+// RoundForDisplay rounds a value to the given number of decimal places using
+// the engine's rounding mode
+func (e *Engine) RoundForDisplay(value float64, places int) float64 {
+	return e.roundingMode.RoundTo(value, places)
+}
+
 // Clear clears all values (C functionality)
 func (e *Engine) Clear() {
 	e.currentValue = 0
@@ -199,7 +229,24 @@ func (e *Engine) PerformOperation(op string) (float64, error) {
 type Calculator struct {
 	engine    *Engine
 	variables map[string]float64
+	constants map[string]bool
 	mu        sync.RWMutex
+
+	// maxVariables limits how many variables may be defined, 0 for no limit
+	maxVariables int
+
+	// history keeps the latest evaluations, whose results are ans1..ansN
+	history historyRing
+
+	// memory is the accumulator behind M+, M-, MR and MC
+	memory float64
+
+	// precision and precisionMode control how Format renders results
+	precision     int
+	precisionMode PrecisionMode
+
+	// base is the base Format shows whole-number results in
+	base NumberBase
 }
 
 // NewCalculator creates a new calculator with variable support
@@ -207,26 +254,106 @@ func NewCalculator() *Calculator {
 	return &Calculator{
 		engine:    NewEngine(),
 		variables: make(map[string]float64),
+		constants: make(map[string]bool),
+		history:   historyRing{size: DefaultHistorySize},
+		precision: DefaultPrecision,
 	}
 }
 
-// Evaluate evaluates a mathematical expression with variable support
+// Evaluate evaluates a mathematical expression with variable support and
+// records it in the history. Earlier results can be used as ans1 (the latest,
+// also ans), ans2 and so on, unless a variable of the same name is defined,
+// and M recalls the memory.
 func (c *Calculator) Evaluate(expression string) (float64, error) {
-	// Simple variable substitution for now
+	result, err := c.Preview(expression)
+	if err != nil {
+		return 0, err
+	}
+
+	c.mu.Lock()
+	c.history.add(HistoryEntry{Expression: expression, Result: result, Time: time.Now()})
+	c.mu.Unlock()
+
+	return result, nil
+}
+
+// Preview evaluates an expression like Evaluate without recording it in the
+// history, for values that are shown but not asked for
+func (c *Calculator) Preview(expression string) (float64, error) {
 	c.mu.RLock()
 	for name, value := range c.variables {
 		expression = replaceVariable(expression, name, value)
 	}
+	for name, value := range c.resultVariables() {
+		if _, defined := c.variables[name]; !defined {
+			expression = replaceVariable(expression, name, value)
+		}
+	}
+	expression = replaceVariable(expression, MemoryName, c.memory)
 	c.mu.RUnlock()
 
 	return c.engine.Evaluate(expression)
 }
 
-// SetVariable sets a variable value
-func (c *Calculator) SetVariable(name string, value float64) {
+// SetVariable sets a variable value. Defining a new variable fails with
+// ErrTooManyVariables once the variable limit is reached, and M is reserved
+// for the memory (ErrReservedName).
+func (c *Calculator) SetVariable(name string, value float64) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+
+	if err := c.checkDefinable(name); err != nil {
+		return err
+	}
 	c.variables[name] = value
+	return nil
+}
+
+// SetConstant sets a variable that ClearVariables keeps. Like SetVariable it
+// fails once the variable limit is reached.
+func (c *Calculator) SetConstant(name string, value float64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.checkDefinable(name); err != nil {
+		return err
+	}
+	c.variables[name] = value
+	c.constants[name] = true
+	return nil
+}
+
+// SetMaxVariables limits how many variables may be defined; 0 removes the
+// limit. Variables already defined are kept, and can still be updated.
+func (c *Calculator) SetMaxVariables(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if n < 0 {
+		n = 0
+	}
+	c.maxVariables = n
+}
+
+// checkDefinable returns an error if name is reserved or defining it would
+// exceed the variable limit. Callers must hold the lock.
+func (c *Calculator) checkDefinable(name string) error {
+	if name == MemoryName {
+		return fmt.Errorf("%w: %s recalls the memory", ErrReservedName, name)
+	}
+	if _, exists := c.variables[name]; exists || c.maxVariables == 0 {
+		return nil
+	}
+	if len(c.variables) >= c.maxVariables {
+		return fmt.Errorf("%w: cannot define %q, the limit is %d", ErrTooManyVariables, name, c.maxVariables)
+	}
+	return nil
+}
+
+// IsConstant reports whether a variable was set with SetConstant
+func (c *Calculator) IsConstant(name string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.constants[name]
 }
 
 // GetVariable gets a variable value
@@ -237,7 +364,7 @@ func (c *Calculator) GetVariable(name string) (float64, bool) {
 	return value, exists
 }
 
-// GetVariables returns all variables
+// GetVariables returns all variables, and the memory as M when it is not zero
 func (c *Calculator) GetVariables() map[string]float64 {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
@@ -247,18 +374,101 @@ func (c *Calculator) GetVariables() map[string]float64 {
 	for k, v := range c.variables {
 		vars[k] = v
 	}
+
+	// A stored memory is reported so that front-ends can show an indicator
+	if c.memory != 0 {
+		vars[MemoryName] = c.memory
+	}
 	return vars
 }
 
-// ClearVariables clears all variables
+// ClearVariables clears all variables except constants
 func (c *Calculator) ClearVariables() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	c.variables = make(map[string]float64)
+
+	variables := make(map[string]float64)
+	for name := range c.constants {
+		variables[name] = c.variables[name]
+	}
+	c.variables = variables
 }
 
-// replaceVariable replaces variable names with their values in expressions
+// replaceVariable replaces whole-word uses of name in expr with its bracketed
+// value. A name followed by an opening bracket is a function call and is kept.
 func replaceVariable(expr, name string, value float64) string {
-	// Simple implementation - in production this would need proper parsing
-	return expr // For now, we'll implement this in the parser
+	var result strings.Builder
+	for i := 0; i < len(expr); {
+		// Copy numbers whole, so the name in "2x" is still found
+		if end := numberEnd(expr, i); end > i {
+			result.WriteString(expr[i:end])
+			i = end
+			continue
+		}
+
+		if !isNameChar(expr[i]) {
+			result.WriteByte(expr[i])
+			i++
+			continue
+		}
+
+		start := i
+		for i < len(expr) && isNameChar(expr[i]) {
+			i++
+		}
+		word := expr[start:i]
+
+		if word == name && !strings.HasPrefix(strings.TrimLeft(expr[i:], " "), "(") {
+			result.WriteString("(" + strconv.FormatFloat(value, 'f', -1, 64) + ")")
+		} else {
+			result.WriteString(word)
+		}
+	}
+	return result.String()
+}
+
+// numberEnd returns where the number literal starting at i ends, including a
+// scientific exponent such as e-3 or a base prefix such as 0x, or i if there
+// is no number there
+func numberEnd(expr string, i int) int {
+	if !isDigitByte(expr[i]) {
+		return i
+	}
+
+	if base := parseBasePrefix(expr, i); base != BaseDecimal {
+		end := i + len(base.prefix())
+		for end < len(expr) && isBaseDigit(expr[end]) {
+			end++
+		}
+		return end
+	}
+
+	end := i
+	for end < len(expr) && (isDigitByte(expr[end]) || expr[end] == '.') {
+		end++
+	}
+
+	if end < len(expr) && (expr[end] == 'e' || expr[end] == 'E') {
+		digits := end + 1
+		if digits < len(expr) && (expr[digits] == '-' || expr[digits] == '+') {
+			digits++
+		}
+		if digits < len(expr) && isDigitByte(expr[digits]) {
+			end = digits
+			for end < len(expr) && isDigitByte(expr[end]) {
+				end++
+			}
+		}
+	}
+	return end
+}
+
+// isDigitByte reports whether c is a decimal digit
+func isDigitByte(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// isNameChar reports whether c can be part of a variable name
+func isNameChar(c byte) bool {
+	return isLetter(c) || (c >= '0' && c <= '9') || c == '_'
 }
\ No newline at end of file
@@ -1,8 +1,11 @@
 package calculator
 
 import (
+	"context"
+	"errors"
 	"math"
 	"testing"
+	"time"
 )
 
 func TestNewEngine(t *testing.T) {
@@ -192,6 +195,24 @@ func TestEvaluate(t *testing.T) {
 	}
 }
 
+func TestEvaluateWithContext(t *testing.T) {
+	engine := NewEngine()
+
+	result, err := engine.EvaluateWithContext(context.Background(), "2*(3+4)")
+	if err != nil || result != 14 {
+		t.Errorf("EvaluateWithContext() = %f, %v, want 14", result, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := engine.EvaluateWithContext(ctx, "2*(3+4)"); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected a cancelled evaluation to return context.Canceled, got %v", err)
+	}
+	if engine.GetValue() != 14 {
+		t.Errorf("Expected a cancelled evaluation to leave the value alone, got %f", engine.GetValue())
+	}
+}
+
 func TestOverflowDetection(t *testing.T) {
 	engine := NewEngine()
 
@@ -290,4 +311,377 @@ func BenchmarkExpressionEvaluation(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		engine.Evaluate("2+3*4-1/2")
 	}
-}
\ No newline at end of file
+}
+
+func TestRoundingMode(t *testing.T) {
+	tests := []struct {
+		mode       RoundingMode
+		expression string
+		want       float64
+	}{
+		{RoundHalfUp, "round(2.5)", 3},
+		{RoundHalfUp, "round(-2.5)", -3},
+		{RoundHalfUp, "round(2.4)", 2},
+		{RoundHalfEven, "round(2.5)", 2},
+		{RoundHalfEven, "round(3.5)", 4},
+		{RoundHalfEven, "round(2.6)", 3},
+		{RoundFloor, "round(2.9)", 2},
+		{RoundFloor, "round(-2.1)", -3},
+		{RoundCeil, "round(2.1)", 3},
+		{RoundCeil, "round(-2.9)", -2},
+		{RoundHalfUp, "round(5/2)*2", 6},
+		{RoundCeil, "round(0.1*3*10)", 3},
+		{RoundFloor, "round(2.3*100)", 230},
+	}
+
+	for _, tt := range tests {
+		engine := NewEngine()
+		engine.SetRoundingMode(tt.mode)
+
+		result, err := engine.Evaluate(tt.expression)
+		if err != nil {
+			t.Errorf("Evaluate(%q) with %s returned error: %v", tt.expression, tt.mode, err)
+			continue
+		}
+		if result != tt.want {
+			t.Errorf("Evaluate(%q) with %s = %f, want %f", tt.expression, tt.mode, result, tt.want)
+		}
+	}
+}
+
+func TestRoundingModeDefaults(t *testing.T) {
+	engine := NewEngine()
+	if engine.GetRoundingMode() != RoundHalfUp {
+		t.Errorf("Default rounding mode = %s, want %s", engine.GetRoundingMode(), RoundHalfUp)
+	}
+
+	if _, err := engine.Evaluate("round(1,2)"); err == nil {
+		t.Error("round with two arguments should return an error")
+	}
+}
+
+func TestRoundForDisplay(t *testing.T) {
+	engine := NewEngine()
+
+	engine.SetRoundingMode(RoundFloor)
+	if got := engine.RoundForDisplay(1.239, 2); math.Abs(got-1.23) > 1e-10 {
+		t.Errorf("RoundForDisplay(1.239, 2) with floor = %f, want 1.23", got)
+	}
+
+	engine.SetRoundingMode(RoundCeil)
+	if got := engine.RoundForDisplay(1.231, 2); math.Abs(got-1.24) > 1e-10 {
+		t.Errorf("RoundForDisplay(1.231, 2) with ceil = %f, want 1.24", got)
+	}
+}
+
+func TestRoundForDisplayRepresentationError(t *testing.T) {
+	tests := []struct {
+		mode  RoundingMode
+		value float64
+		want  float64
+	}{
+		{RoundCeil, 0.1 + 0.2, 0.3},
+		{RoundCeil, 1.1 * 3, 3.3},
+		{RoundFloor, 1.005, 1.005},
+		{RoundFloor, 0.1 * 3, 0.3},
+	}
+
+	for _, tt := range tests {
+		engine := NewEngine()
+		engine.SetRoundingMode(tt.mode)
+
+		if got := engine.RoundForDisplay(tt.value, 6); math.Abs(got-tt.want) > 1e-12 {
+			t.Errorf("RoundForDisplay(%v, 6) with %s = %v, want %v", tt.value, tt.mode, got, tt.want)
+		}
+	}
+}
+
+func TestRoundLargeValues(t *testing.T) {
+	tests := []struct {
+		mode   RoundingMode
+		value  float64
+		places int
+		want   float64
+	}{
+		{RoundHalfUp, 123456789012345, 0, 123456789012345},
+		{RoundHalfUp, 1234567890123.4, 0, 1234567890123},
+		{RoundHalfUp, 1234567890123.5, 0, 1234567890124},
+		{RoundHalfEven, 1234567890122.5, 0, 1234567890122},
+		{RoundCeil, 1234567890123.2, 0, 1234567890124},
+		{RoundHalfUp, 123456789012345, 2, 123456789012345},
+		{RoundHalfUp, 1234567890.1234567, 4, 1234567890.1235},
+		{RoundFloor, 1234567890.1234567, 4, 1234567890.1234},
+		{RoundHalfUp, 12345678901.2344, 3, 12345678901.234},
+	}
+
+	for _, tt := range tests {
+		if got := tt.mode.RoundTo(tt.value, tt.places); got != tt.want {
+			t.Errorf("RoundTo(%v, %d) with %s = %v, want %v", tt.value, tt.places, tt.mode, got, tt.want)
+		}
+	}
+
+	engine := NewEngine()
+	if result, err := engine.Evaluate("round(123456789012345)"); err != nil || result != 123456789012345 {
+		t.Errorf("Evaluate(\"round(123456789012345)\") = %v (%v), want 123456789012345", result, err)
+	}
+}
+
+func TestCalculatorVariables(t *testing.T) {
+	calc := NewCalculator()
+	calc.SetVariable("x", 4)
+	calc.SetVariable("rate", -2)
+
+	tests := []struct {
+		expression string
+		want       float64
+	}{
+		{"x * 2", 8},
+		{"x+rate", 2},
+		{"round(x/3)", 1},
+		{"2^x", 16},
+		{"3x", 12},
+		{"2(x+1)", 10},
+		{"1.5e1x", 60},
+	}
+
+	for _, tt := range tests {
+		result, err := calc.Evaluate(tt.expression)
+		if err != nil {
+			t.Errorf("Evaluate(%q) returned error: %v", tt.expression, err)
+			continue
+		}
+		if math.Abs(result-tt.want) > 1e-10 {
+			t.Errorf("Evaluate(%q) = %f, want %f", tt.expression, result, tt.want)
+		}
+	}
+}
+
+func TestCalculatorImplicitMultiplication(t *testing.T) {
+	calc := NewCalculator()
+
+	// pi is built in
+	result, err := calc.Evaluate("2pi")
+	if err != nil {
+		t.Fatalf("Evaluate(\"2pi\") returned error: %v", err)
+	}
+	if math.Abs(result-2*math.Pi) > 1e-10 {
+		t.Errorf("Evaluate(\"2pi\") = %f, want %f", result, 2*math.Pi)
+	}
+
+	// A variable takes the place of a builtin constant
+	if err := calc.SetVariable("e", 10); err != nil {
+		t.Fatalf("SetVariable returned error: %v", err)
+	}
+	result, err = calc.Evaluate("2e")
+	if err != nil {
+		t.Fatalf("Evaluate(\"2e\") returned error: %v", err)
+	}
+	if result != 20 {
+		t.Errorf("Evaluate(\"2e\") = %f, want 20", result)
+	}
+}
+
+func TestCalculatorConstants(t *testing.T) {
+	calc := NewCalculator()
+	calc.SetVariable("x", 2)
+	calc.SetConstant("g", 9.81)
+
+	if calc.IsConstant("x") || !calc.IsConstant("g") {
+		t.Error("Expected only g to be a constant")
+	}
+
+	calc.ClearVariables()
+
+	if _, exists := calc.GetVariable("x"); exists {
+		t.Error("Expected clearing to remove x")
+	}
+	if value, exists := calc.GetVariable("g"); !exists || value != 9.81 {
+		t.Errorf("Expected clearing to keep g = 9.81, got %v (%v)", value, exists)
+	}
+
+	result, err := calc.Evaluate("2*g")
+	if err != nil {
+		t.Fatalf("Evaluate(\"2*g\") returned error: %v", err)
+	}
+	if math.Abs(result-19.62) > 1e-10 {
+		t.Errorf("Evaluate(\"2*g\") = %f, want 19.62", result)
+	}
+}
+
+func TestCalculatorMaxVariables(t *testing.T) {
+	calc := NewCalculator()
+	calc.SetMaxVariables(2)
+
+	if err := calc.SetVariable("a", 1); err != nil {
+		t.Fatalf("SetVariable(a) returned error: %v", err)
+	}
+	if err := calc.SetConstant("b", 2); err != nil {
+		t.Fatalf("SetConstant(b) returned error: %v", err)
+	}
+
+	err := calc.SetVariable("c", 3)
+	if !errors.Is(err, ErrTooManyVariables) {
+		t.Errorf("Expected the third variable to be rejected with ErrTooManyVariables, got %v", err)
+	}
+	if _, exists := calc.GetVariable("c"); exists {
+		t.Error("Expected the rejected variable not to be defined")
+	}
+
+	// Existing variables can still be updated
+	if err := calc.SetVariable("a", 10); err != nil {
+		t.Errorf("Expected updating a to succeed, got %v", err)
+	}
+	if value, _ := calc.GetVariable("a"); value != 10 {
+		t.Errorf("Expected a = 10, got %v", value)
+	}
+
+	// Removing the limit allows new variables again
+	calc.SetMaxVariables(0)
+	if err := calc.SetVariable("c", 3); err != nil {
+		t.Errorf("Expected no limit after SetMaxVariables(0), got %v", err)
+	}
+}
+
+func TestCalculatorResultVariables(t *testing.T) {
+	calc := NewCalculator()
+	calc.SetHistorySize(3)
+
+	for _, expression := range []string{"1+1", "3*3", "10-6"} {
+		if _, err := calc.Evaluate(expression); err != nil {
+			t.Fatalf("Evaluate(%q) returned error: %v", expression, err)
+		}
+	}
+
+	evaluate := func(expression string) float64 {
+		t.Helper()
+		result, err := calc.Evaluate(expression)
+		if err != nil {
+			t.Fatalf("Evaluate(%q) returned error: %v", expression, err)
+		}
+		return result
+	}
+
+	// Results are numbered from the latest: 4, 9, 2
+	results := calc.GetResults()
+	if len(results) != 3 || results[0] != 4 || results[1] != 9 || results[2] != 2 {
+		t.Fatalf("Expected results [4 9 2], got %v", results)
+	}
+	if got := evaluate("ans1*100 + ans2*10 + ans3"); got != 492 {
+		t.Errorf("Expected ans1, ans2 and ans3 to be 4, 9 and 2, got %v", got)
+	}
+
+	// That result rolled the window: 492, 4, 9
+	if got := evaluate("ans3 + ans"); got != 9+492 {
+		t.Errorf("Expected ans3 = 9 and ans = 492 after the window rolled, got %v", got)
+	}
+	if _, err := calc.Evaluate("ans4"); err == nil {
+		t.Error("Expected ans4 to be undefined with a window of 3")
+	}
+
+	// Previews do not roll the window
+	if result, err := calc.Preview("ans + 1"); err != nil || result != 502 {
+		t.Errorf("Expected a preview of ans + 1 to be 502, got %v (%v)", result, err)
+	}
+	if results := calc.GetResults(); results[0] != 501 {
+		t.Errorf("Expected a preview to leave ans at 501, got %v", results)
+	}
+
+	// Defined variables take precedence
+	calc.SetVariable("ans1", 7)
+	if got := evaluate("ans1"); got != 7 {
+		t.Errorf("Expected the ans1 variable to win, got %v", got)
+	}
+}
+
+func TestCalculatorMemory(t *testing.T) {
+	calc := NewCalculator()
+
+	// Empty memory recalls 0 and is not reported
+	if got := calc.MemoryRecall(); got != 0 {
+		t.Errorf("Expected empty memory to recall 0, got %v", got)
+	}
+	if _, shown := calc.GetVariables()[MemoryName]; shown {
+		t.Error("Expected empty memory not to be reported")
+	}
+	if result, err := calc.Evaluate("M + 1"); err != nil || result != 1 {
+		t.Errorf("Expected M + 1 with empty memory to be 1, got %v (%v)", result, err)
+	}
+
+	calc.MemoryAdd(10)
+	calc.MemoryAdd(5)
+	calc.MemorySubtract(3)
+	if got := calc.MemoryRecall(); got != 12 {
+		t.Errorf("Expected memory 12, got %v", got)
+	}
+	if got := calc.GetVariables()[MemoryName]; got != 12 {
+		t.Errorf("Expected the memory to be reported as M = 12, got %v", got)
+	}
+	if result, err := calc.Evaluate("M * 2"); err != nil || result != 24 {
+		t.Errorf("Expected M * 2 to be 24, got %v (%v)", result, err)
+	}
+
+	// M is reserved for the memory
+	if err := calc.SetVariable(MemoryName, 1); !errors.Is(err, ErrReservedName) {
+		t.Errorf("Expected defining M to fail with ErrReservedName, got %v", err)
+	}
+
+	calc.MemoryClear()
+	if got := calc.MemoryRecall(); got != 0 {
+		t.Errorf("Expected cleared memory to recall 0, got %v", got)
+	}
+	if _, shown := calc.GetVariables()[MemoryName]; shown {
+		t.Error("Expected cleared memory not to be reported")
+	}
+}
+
+func TestCalculatorHistory(t *testing.T) {
+	calc := NewCalculatorWithHistory(3)
+
+	before := time.Now()
+	for _, expression := range []string{"1+1", "2*3", "10/4", "ans * 2"} {
+		if _, err := calc.Evaluate(expression); err != nil {
+			t.Fatalf("Evaluate(%q) returned error: %v", expression, err)
+		}
+	}
+
+	// The oldest entry rolled out, and ans resolved to the previous result
+	history := calc.History()
+	if len(history) != 3 {
+		t.Fatalf("Expected 3 entries, got %d", len(history))
+	}
+	expected := []HistoryEntry{{Expression: "2*3", Result: 6}, {Expression: "10/4", Result: 2.5}, {Expression: "ans * 2", Result: 5}}
+	for i, entry := range history {
+		if entry.Expression != expected[i].Expression || entry.Result != expected[i].Result {
+			t.Errorf("Entry %d: expected %s = %v, got %s = %v", i, expected[i].Expression, expected[i].Result, entry.Expression, entry.Result)
+		}
+		if entry.Time.Before(before) {
+			t.Errorf("Entry %d: expected a timestamp, got %v", i, entry.Time)
+		}
+	}
+
+	// Previews and errors are not recorded
+	calc.Preview("ans + 1")
+	calc.Evaluate("1/0")
+	if got := len(calc.History()); got != 3 {
+		t.Errorf("Expected previews and errors to leave 3 entries, got %d", got)
+	}
+
+	// The returned history is a copy
+	history[0].Result = 99
+	if calc.History()[0].Result != 6 {
+		t.Error("Expected the history to be unaffected by changes to the copy")
+	}
+
+	calc.SetHistorySize(1)
+	if history := calc.History(); len(history) != 1 || history[0].Expression != "ans * 2" {
+		t.Errorf("Expected shrinking to keep the latest entry, got %v", history)
+	}
+
+	calc.ClearHistory()
+	if len(calc.History()) != 0 {
+		t.Error("Expected ClearHistory to empty the history")
+	}
+	if _, err := calc.Evaluate("ans"); err == nil {
+		t.Error("Expected ans to be undefined with an empty history")
+	}
+}
@@ -20,6 +20,12 @@ const (
 	ErrInvalidNumber       CalculatorError = "invalid number format"
 	ErrInvalidOperator     CalculatorError = "invalid operator"
 	ErrMismatchedParentheses CalculatorError = "mismatched parentheses"
+	ErrUnknownFunction     CalculatorError = "unknown function"
+	ErrInvalidArgument     CalculatorError = "invalid function argument"
+	ErrTooManyVariables    CalculatorError = "too many variables"
+	ErrReservedName        CalculatorError = "reserved name"
+	ErrNotWholeNumber      CalculatorError = "not a whole number"
+	ErrZeroBase            CalculatorError = "zero base value"
 )
 
 // IsOverflow checks if a calculation would result in overflow
@@ -0,0 +1,163 @@
+package calculator
+
+import (
+	"math"
+	"strconv"
+)
+
+// DefaultPrecision leaves results in the shortest form that reads back exactly
+const DefaultPrecision = -1
+
+// Results at or beyond these magnitudes are shown in scientific notation
+// whatever the precision
+const (
+	scientificAbove = 1e15
+	scientificBelow = 1e-6
+)
+
+// PrecisionMode controls what a precision counts
+type PrecisionMode int
+
+const (
+	// PrecisionDecimals counts digits after the decimal point (33.333 at 3)
+	PrecisionDecimals PrecisionMode = iota
+	// PrecisionSignificant counts significant digits (33.3 at 3)
+	PrecisionSignificant
+)
+
+// String returns the string representation of the precision mode
+func (p PrecisionMode) String() string {
+	switch p {
+	case PrecisionSignificant:
+		return "significant"
+	default:
+		return "decimals"
+	}
+}
+
+// Formatter renders results with a fixed number of decimal places or
+// significant digits, rounded with its rounding mode
+type Formatter struct {
+	// Precision is the number of digits shown, DefaultPrecision for the
+	// shortest exact form
+	Precision int
+	Mode      PrecisionMode
+	Rounding  RoundingMode
+	// Base shows whole numbers in hex, octal or binary; other values are
+	// still shown in decimal
+	Base NumberBase
+}
+
+// Format renders value. Precision 0 in decimals mode gives whole numbers, and
+// magnitudes too large or small to show sensibly use scientific notation. In a
+// non-decimal base whole numbers are shown with their prefix, e.g. 0xFF.
+func (f Formatter) Format(value float64) string {
+	if f.Base != BaseDecimal {
+		if formatted, err := FormatInteger(value, f.Base); err == nil {
+			return formatted
+		}
+	}
+
+	if f.Precision < 0 || math.IsInf(value, 0) || math.IsNaN(value) {
+		return strconv.FormatFloat(value, 'g', -1, 64)
+	}
+
+	digits := f.Precision
+	if f.Mode == PrecisionSignificant && digits < 1 {
+		digits = 1
+	}
+
+	magnitude := math.Abs(value)
+	if magnitude >= scientificAbove || (magnitude != 0 && magnitude < scientificBelow) {
+		if f.Mode == PrecisionSignificant {
+			return strconv.FormatFloat(value, 'e', digits-1, 64)
+		}
+		return strconv.FormatFloat(value, 'e', digits, 64)
+	}
+
+	decimals := digits
+	if f.Mode == PrecisionSignificant {
+		decimals = digits - 1 - decimalExponent(value)
+		if decimals < 0 {
+			return strconv.FormatFloat(value, 'e', digits-1, 64)
+		}
+	}
+
+	rounded := f.Rounding.RoundTo(value, decimals)
+	// Rounding up can carry into another digit (9.99 -> 10.0)
+	if f.Mode == PrecisionSignificant && decimals > 0 && decimalExponent(rounded) > decimalExponent(value) {
+		decimals--
+	}
+	if rounded == 0 {
+		rounded = 0 // no "-0"
+	}
+	return strconv.FormatFloat(rounded, 'f', decimals, 64)
+}
+
+// decimalExponent returns the power of ten of the leading digit of value
+func decimalExponent(value float64) int {
+	if value == 0 {
+		return 0
+	}
+	return int(math.Floor(math.Log10(math.Abs(value))))
+}
+
+// SetPrecision sets how many digits Format shows, DefaultPrecision for the
+// shortest exact form
+func (c *Calculator) SetPrecision(digits int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if digits < 0 {
+		digits = DefaultPrecision
+	}
+	c.precision = digits
+}
+
+// GetPrecision returns how many digits Format shows
+func (c *Calculator) GetPrecision() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.precision
+}
+
+// SetPrecisionMode sets whether the precision counts decimal places or
+// significant digits
+func (c *Calculator) SetPrecisionMode(mode PrecisionMode) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.precisionMode = mode
+}
+
+// GetPrecisionMode returns what the precision counts
+func (c *Calculator) GetPrecisionMode() PrecisionMode {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.precisionMode
+}
+
+// SetRoundingMode sets how results are rounded to the precision, and how the
+// round function rounds
+func (c *Calculator) SetRoundingMode(mode RoundingMode) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.engine.SetRoundingMode(mode)
+}
+
+// GetRoundingMode returns the rounding mode
+func (c *Calculator) GetRoundingMode() RoundingMode {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.engine.GetRoundingMode()
+}
+
+// Formatter returns the formatter for the calculator's precision settings
+func (c *Calculator) Formatter() Formatter {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return Formatter{Precision: c.precision, Mode: c.precisionMode, Rounding: c.engine.GetRoundingMode(), Base: c.base}
+}
+
+// Format renders a result with the calculator's precision settings
+func (c *Calculator) Format(value float64) string {
+	return c.Formatter().Format(value)
+}
@@ -0,0 +1,77 @@
+package calculator
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFormatter(t *testing.T) {
+	tests := []struct {
+		name      string
+		formatter Formatter
+		value     float64
+		want      string
+	}{
+		{"default is shortest exact", Formatter{Precision: DefaultPrecision}, 100.0 / 3, "33.333333333333336"},
+		{"default integer", Formatter{Precision: DefaultPrecision}, 36, "36"},
+		{"decimals", Formatter{Precision: 3}, 100.0 / 3, "33.333"},
+		{"decimals keep trailing zeros", Formatter{Precision: 2}, 2.5, "2.50"},
+		{"precision 0 integer", Formatter{Precision: 0}, 36, "36"},
+		{"precision 0 rounds half up", Formatter{Precision: 0}, 2.5, "3"},
+		{"precision 0 rounds half even", Formatter{Precision: 0, Rounding: RoundHalfEven}, 2.5, "2"},
+		{"floor", Formatter{Precision: 2, Rounding: RoundFloor}, 2.679, "2.67"},
+		{"no negative zero", Formatter{Precision: 0}, -0.4, "0"},
+		{"representation error", Formatter{Precision: 2}, 1.005, "1.01"},
+		{"many digits", Formatter{Precision: 2}, 123456789012345, "123456789012345.00"},
+		{"many digits with decimals", Formatter{Precision: 4}, 1234567890.1234567, "1234567890.1235"},
+		{"many digits floor", Formatter{Precision: 4, Rounding: RoundFloor}, 1234567890.1234567, "1234567890.1234"},
+		{"significant", Formatter{Precision: 3, Mode: PrecisionSignificant}, 100.0 / 3, "33.3"},
+		{"significant small", Formatter{Precision: 3, Mode: PrecisionSignificant}, 0.0123456, "0.0123"},
+		{"significant carry", Formatter{Precision: 3, Mode: PrecisionSignificant}, 9.996, "10.0"},
+		{"significant too large for digits", Formatter{Precision: 3, Mode: PrecisionSignificant}, 123456, "1.23e+05"},
+		{"large magnitude", Formatter{Precision: 2}, 1e20, "1.00e+20"},
+		{"small magnitude", Formatter{Precision: 2}, 1.5e-9, "1.50e-09"},
+		{"small significant", Formatter{Precision: 3, Mode: PrecisionSignificant}, -1.23456e-9, "-1.23e-09"},
+		{"infinity", Formatter{Precision: 2}, math.Inf(1), "+Inf"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.formatter.Format(tt.value); got != tt.want {
+			t.Errorf("%s: Format(%v) = %q, want %q", tt.name, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestCalculatorPrecision(t *testing.T) {
+	calc := NewCalculator()
+	if calc.GetPrecision() != DefaultPrecision {
+		t.Errorf("Expected the default precision, got %d", calc.GetPrecision())
+	}
+
+	result, _ := calc.Evaluate("100/3")
+	if got := calc.Format(result); got != "33.333333333333336" {
+		t.Errorf("Expected the shortest exact form by default, got %q", got)
+	}
+
+	calc.SetPrecision(3)
+	if got := calc.Format(result); got != "33.333" {
+		t.Errorf("Expected 3 decimal places, got %q", got)
+	}
+
+	calc.SetPrecisionMode(PrecisionSignificant)
+	if got := calc.Format(result); got != "33.3" {
+		t.Errorf("Expected 3 significant digits, got %q", got)
+	}
+
+	calc.SetPrecisionMode(PrecisionDecimals)
+	calc.SetPrecision(0)
+	calc.SetRoundingMode(RoundCeil)
+	if got := calc.Format(result); got != "34" {
+		t.Errorf("Expected ceil to 0 places to give 34, got %q", got)
+	}
+
+	calc.SetPrecision(-5)
+	if calc.GetPrecision() != DefaultPrecision {
+		t.Errorf("Expected a negative precision to restore the default, got %d", calc.GetPrecision())
+	}
+}
@@ -0,0 +1,244 @@
+package calculator
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Function represents a named function callable from expressions
+type Function struct {
+	Name  string
+	Arity int
+	Apply func(ctx FunctionContext, args []float64) (float64, error)
+}
+
+// FunctionContext carries the evaluation settings a function may depend on
+type FunctionContext struct {
+	RoundingMode RoundingMode
+}
+
+// builtinFunctions holds the functions available to every parser
+var builtinFunctions = map[string]Function{
+	"addtax":    {Name: "addtax", Arity: 2, Apply: addTax},
+	"delta":     {Name: "delta", Arity: 2, Apply: delta},
+	"margin":    {Name: "margin", Arity: 2, Apply: margin},
+	"ncr":       {Name: "nCr", Arity: 2, Apply: combinations},
+	"npr":       {Name: "nPr", Arity: 2, Apply: permutations},
+	"pctchange": {Name: "pctchange", Arity: 2, Apply: percentChange},
+	"root":      {Name: "root", Arity: 2, Apply: nthRoot},
+	"round":     {Name: "round", Arity: 1, Apply: round},
+}
+
+// builtinConstants holds the named values available to every parser. A
+// variable of the same name takes their place.
+var builtinConstants = map[string]float64{
+	"e":  math.E,
+	"pi": math.Pi,
+}
+
+// lookupConstant returns the value of the builtin constant with the given name
+func lookupConstant(name string) (float64, bool) {
+	value, exists := builtinConstants[name]
+	return value, exists
+}
+
+// lookupFunction returns the builtin function with the given name
+func lookupFunction(name string) (Function, bool) {
+	fn, exists := builtinFunctions[name]
+	return fn, exists
+}
+
+// FunctionNames returns the names of the builtin functions in sorted order
+func FunctionNames() []string {
+	names := make([]string, 0, len(builtinFunctions))
+	for name := range builtinFunctions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// call validates the argument count and applies the function
+func (f Function) call(ctx FunctionContext, args []float64) (float64, error) {
+	if len(args) != f.Arity {
+		return 0, fmt.Errorf("%w: %s expects %d arguments, got %d", ErrInvalidArgument, f.Name, f.Arity, len(args))
+	}
+
+	result, err := f.Apply(ctx, args)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := ValidateNumber(result); err != nil {
+		return 0, err
+	}
+
+	return result, nil
+}
+
+// addTax adds a percentage tax to an amount:
+//
+//	addtax(amount, rate) = amount + amount * rate / 100
+//
+// The amount and rate must not be negative.
+func addTax(_ FunctionContext, args []float64) (float64, error) {
+	amount, rate := args[0], args[1]
+
+	if amount < 0 {
+		return 0, fmt.Errorf("%w: addtax amount must not be negative", ErrInvalidArgument)
+	}
+	if rate < 0 {
+		return 0, fmt.Errorf("%w: addtax rate must not be negative", ErrInvalidArgument)
+	}
+
+	return amount + amount*rate/100, nil
+}
+
+// margin returns the profit margin as a percentage of the selling price:
+//
+//	margin(cost, price) = (price - cost) / price * 100
+//
+// The cost must not be negative and the price must be positive. A cost above
+// the price yields a negative margin.
+func margin(_ FunctionContext, args []float64) (float64, error) {
+	cost, price := args[0], args[1]
+
+	if cost < 0 {
+		return 0, fmt.Errorf("%w: margin cost must not be negative", ErrInvalidArgument)
+	}
+	if price == 0 {
+		return 0, ErrDivisionByZero
+	}
+	if price < 0 {
+		return 0, fmt.Errorf("%w: margin price must be positive", ErrInvalidArgument)
+	}
+
+	return (price - cost) / price * 100, nil
+}
+
+// percentChange returns the change from old to new as a percentage of old:
+//
+//	pctchange(old, new) = (new - old) / |old| * 100
+//
+// Dividing by the magnitude keeps a rise positive when old is negative. An
+// old value of zero has no percentage change and gives ErrZeroBase.
+func percentChange(_ FunctionContext, args []float64) (float64, error) {
+	oldValue, newValue := args[0], args[1]
+
+	if oldValue == 0 {
+		return 0, fmt.Errorf("%w: pctchange old value must not be zero", ErrZeroBase)
+	}
+
+	return (newValue - oldValue) / math.Abs(oldValue) * 100, nil
+}
+
+// delta returns the difference from a to b:
+//
+//	delta(a, b) = b - a
+func delta(_ FunctionContext, args []float64) (float64, error) {
+	return args[1] - args[0], nil
+}
+
+// nthRoot returns the nth root of x:
+//
+//	root(x, n) = x^(1/n)
+//
+// n must be a non-zero whole number. Negative x has a real root only for odd
+// n, so root(-8, 3) is -2 while root(-8, 2) is an error.
+func nthRoot(_ FunctionContext, args []float64) (float64, error) {
+	x, n := args[0], args[1]
+
+	if n == 0 || n != math.Trunc(n) {
+		return 0, fmt.Errorf("%w: root degree must be a non-zero whole number", ErrInvalidArgument)
+	}
+	if x < 0 && math.Mod(n, 2) == 0 {
+		return 0, fmt.Errorf("%w: root of a negative number needs an odd degree", ErrInvalidArgument)
+	}
+
+	// A whole root comes out exact rather than as root(27,3) = 3.0000000000000004,
+	// but only when it really is one, so large roots keep all their digits
+	root := math.Pow(math.Abs(x), 1/n)
+	if whole := math.Round(root); math.Pow(whole, n) == math.Abs(x) {
+		root = whole
+	}
+	if x < 0 {
+		return -root, nil
+	}
+	return root, nil
+}
+
+// round rounds its argument to a whole number using the context's rounding mode
+func round(ctx FunctionContext, args []float64) (float64, error) {
+	return ctx.RoundingMode.Round(args[0]), nil
+}
+
+// factorial returns n! for a non-negative whole number n. Results beyond the
+// float64 range (n > 170) give ErrOverflow rather than +Inf.
+func factorial(n float64) (float64, error) {
+	if n < 0 || !IsWholeNumber(n) {
+		return 0, fmt.Errorf("%w: factorial needs a non-negative whole number, got %v", ErrInvalidArgument, n)
+	}
+	return fallingFactorial(n, n)
+}
+
+// permutations returns the number of ordered selections of r items from n:
+//
+//	nPr(n, r) = n! / (n - r)!
+//
+// n and r must be whole numbers with 0 <= r <= n.
+func permutations(_ FunctionContext, args []float64) (float64, error) {
+	n, r, err := selectionArgs("nPr", args)
+	if err != nil {
+		return 0, err
+	}
+	return fallingFactorial(n, r)
+}
+
+// combinations returns the number of unordered selections of r items from n:
+//
+//	nCr(n, r) = n! / (r! * (n - r)!)
+//
+// n and r must be whole numbers with 0 <= r <= n.
+func combinations(_ FunctionContext, args []float64) (float64, error) {
+	n, r, err := selectionArgs("nCr", args)
+	if err != nil {
+		return 0, err
+	}
+
+	// Multiply and divide in turn so each step is itself a whole number
+	r = math.Min(r, n-r)
+	result := 1.0
+	for i := 1.0; i <= r; i++ {
+		result = result * (n - r + i) / i
+		if math.IsInf(result, 0) {
+			return 0, ErrOverflow
+		}
+	}
+	return math.Round(result), nil
+}
+
+// selectionArgs checks the arguments of nPr and nCr
+func selectionArgs(name string, args []float64) (n, r float64, err error) {
+	n, r = args[0], args[1]
+	if n < 0 || !IsWholeNumber(n) || r < 0 || !IsWholeNumber(r) {
+		return 0, 0, fmt.Errorf("%w: %s needs non-negative whole numbers", ErrInvalidArgument, name)
+	}
+	if r > n {
+		return 0, 0, fmt.Errorf("%w: %s cannot choose %v items from %v", ErrInvalidArgument, name, r, n)
+	}
+	return n, r, nil
+}
+
+// fallingFactorial returns n * (n-1) * ... * (n-k+1), stopping with
+// ErrOverflow as soon as the product leaves the float64 range
+func fallingFactorial(n, k float64) (float64, error) {
+	result := 1.0
+	for i := 0.0; i < k; i++ {
+		result *= n - i
+		if math.IsInf(result, 0) {
+			return 0, ErrOverflow
+		}
+	}
+	return result, nil
+}
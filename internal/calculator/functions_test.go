@@ -0,0 +1,257 @@
+package calculator
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestRetailFunctions(t *testing.T) {
+	parser := NewParser()
+
+	tests := []struct {
+		expression string
+		want       float64
+	}{
+		{"addtax(100,8.5)", 108.5},
+		{"addtax(100, 0)", 100},
+		{"addtax(0,8.5)", 0},
+		{"addtax(50+50,10)*2", 220},
+		{"margin(80,100)", 20},
+		{"margin(100,100)", 0},
+		{"margin(0,100)", 100},
+		{"margin(120,100)", -20},
+		{"MARGIN(80, 100)", 20},
+	}
+
+	for _, tt := range tests {
+		result, err := parser.Parse(tt.expression)
+		if err != nil {
+			t.Errorf("Parse(%q) returned error: %v", tt.expression, err)
+			continue
+		}
+		if math.Abs(result-tt.want) > 1e-10 {
+			t.Errorf("Parse(%q) = %f, want %f", tt.expression, result, tt.want)
+		}
+	}
+}
+
+func TestRetailFunctionErrors(t *testing.T) {
+	parser := NewParser()
+
+	tests := []struct {
+		expression string
+		errType    error
+	}{
+		{"addtax(-100,8.5)", ErrInvalidArgument},
+		{"addtax(100,-8.5)", ErrInvalidArgument},
+		{"addtax(100)", ErrInvalidArgument},
+		{"addtax(100,8.5,1)", ErrInvalidArgument},
+		{"margin(-80,100)", ErrInvalidArgument},
+		{"margin(80,-100)", ErrInvalidArgument},
+		{"margin(80,0)", ErrDivisionByZero},
+		{"margin()", ErrInvalidArgument},
+		{"margin(80,100", ErrMismatchedParentheses},
+		{"discount(10,5)", ErrUnknownFunction},
+		{"addtax", ErrInvalidExpression},
+	}
+
+	for _, tt := range tests {
+		_, err := parser.Parse(tt.expression)
+		if err == nil {
+			t.Errorf("Parse(%q) expected error, got nil", tt.expression)
+			continue
+		}
+		if !errors.Is(err, tt.errType) {
+			t.Errorf("Parse(%q) error = %v, want %v", tt.expression, err, tt.errType)
+		}
+	}
+}
+
+func TestChangeFunctions(t *testing.T) {
+	parser := NewParser()
+
+	tests := []struct {
+		expression string
+		want       float64
+	}{
+		{"pctchange(100,150)", 50},
+		{"pctchange(150,100)", -100.0 / 3},
+		{"pctchange(80, 80)", 0},
+		{"pctchange(-50,-25)", 50},
+		{"pctchange(100,0)", -100},
+		{"delta(5,8)", 3},
+		{"delta(8,5)", -3},
+		{"delta(0,0)", 0},
+		{"delta(1.5,2)*2", 1},
+		{"PCTCHANGE(100, 150)", 50},
+	}
+
+	for _, tt := range tests {
+		result, err := parser.Parse(tt.expression)
+		if err != nil {
+			t.Errorf("Parse(%q) returned error: %v", tt.expression, err)
+			continue
+		}
+		if math.Abs(result-tt.want) > 1e-10 {
+			t.Errorf("Parse(%q) = %f, want %f", tt.expression, result, tt.want)
+		}
+	}
+}
+
+func TestChangeFunctionErrors(t *testing.T) {
+	parser := NewParser()
+
+	tests := []struct {
+		expression string
+		errType    error
+	}{
+		{"pctchange(0,150)", ErrZeroBase},
+		{"pctchange(5-5,1)", ErrZeroBase},
+		{"pctchange(100)", ErrInvalidArgument},
+		{"pctchange(100,150,1)", ErrInvalidArgument},
+		{"delta(5)", ErrInvalidArgument},
+		{"delta(5,8,13)", ErrInvalidArgument},
+		{"delta()", ErrInvalidArgument},
+	}
+
+	for _, tt := range tests {
+		_, err := parser.Parse(tt.expression)
+		if err == nil {
+			t.Errorf("Parse(%q) expected error, got nil", tt.expression)
+			continue
+		}
+		if !errors.Is(err, tt.errType) {
+			t.Errorf("Parse(%q) error = %v, want %v", tt.expression, err, tt.errType)
+		}
+	}
+}
+
+func TestRootFunction(t *testing.T) {
+	parser := NewParser()
+
+	tests := []struct {
+		expression string
+		want       float64
+	}{
+		{"root(27,3)", 3},
+		{"root(16,4)", 2},
+		{"root(-8,3)", -2},
+		{"root(2,2)", math.Sqrt2},
+		{"root(8,-3)", 0.5},
+	}
+
+	for _, tt := range tests {
+		result, err := parser.Parse(tt.expression)
+		if err != nil {
+			t.Errorf("Parse(%q) returned error: %v", tt.expression, err)
+			continue
+		}
+		if math.Abs(result-tt.want) > 1e-10 {
+			t.Errorf("Parse(%q) = %f, want %f", tt.expression, result, tt.want)
+		}
+	}
+
+	// Whole roots come out exact rather than as 3.0000000000000004
+	if result, _ := parser.Parse("root(27,3)"); result != 3 {
+		t.Errorf("Parse(\"root(27,3)\") = %v, want exactly 3", result)
+	}
+
+	// Large roots keep all their digits
+	large := []struct {
+		expression string
+		want       float64
+	}{
+		{"root(123456789012345*123456789012345,2)", 123456789012345},
+		{"root(15241578753238836,2)", math.Sqrt(15241578753238836)},
+		{"root(1e30,3)", 1e10},
+	}
+	for _, tt := range large {
+		if result, err := parser.Parse(tt.expression); err != nil || result != tt.want {
+			t.Errorf("Parse(%q) = %v (%v), want exactly %v", tt.expression, result, err, tt.want)
+		}
+	}
+}
+
+func TestRootFunctionErrors(t *testing.T) {
+	parser := NewParser()
+
+	tests := []string{"root(-16,2)", "root(27,0)", "root(27,1.5)", "root(27)", "root(27,3,1)"}
+
+	for _, expression := range tests {
+		_, err := parser.Parse(expression)
+		if !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("Parse(%q) error = %v, want %v", expression, err, ErrInvalidArgument)
+		}
+	}
+}
+
+func TestCombinatorics(t *testing.T) {
+	parser := NewParser()
+
+	tests := []struct {
+		expression string
+		want       float64
+	}{
+		{"5!", 120},
+		{"0!", 1},
+		{"3!!", 720},
+		{"(1+2)!", 6},
+		{"2^3!", 64},
+		{"-3!", -6},
+		{"2*3!", 12},
+		{"170!", 7.257415615307994e306},
+		{"nPr(5,2)", 20},
+		{"nPr(5,0)", 1},
+		{"nPr(5,5)", 120},
+		{"nCr(5,2)", 10},
+		{"nCr(52,5)", 2598960},
+		{"nCr(5,0)", 1},
+		{"NCR(10, 10)", 1},
+		{"nCr(5,2)!", 3628800},
+	}
+
+	for _, tt := range tests {
+		result, err := parser.Parse(tt.expression)
+		if err != nil {
+			t.Errorf("Parse(%q) returned error: %v", tt.expression, err)
+			continue
+		}
+		if math.Abs(result-tt.want) > 1e-10*math.Max(1, math.Abs(tt.want)) {
+			t.Errorf("Parse(%q) = %v, want %v", tt.expression, result, tt.want)
+		}
+	}
+}
+
+func TestCombinatoricsErrors(t *testing.T) {
+	parser := NewParser()
+
+	tests := []struct {
+		expression string
+		errType    error
+	}{
+		{"2.5!", ErrInvalidArgument},
+		{"(-3)!", ErrInvalidArgument},
+		{"171!", ErrOverflow},
+		{"nPr(5,6)", ErrInvalidArgument},
+		{"nPr(5,-1)", ErrInvalidArgument},
+		{"nPr(2.5,1)", ErrInvalidArgument},
+		{"nPr(1000,1000)", ErrOverflow},
+		{"nCr(5,6)", ErrInvalidArgument},
+		{"nCr(5,1.5)", ErrInvalidArgument},
+		{"nCr(5)", ErrInvalidArgument},
+		{"nCr(2000,1000)", ErrOverflow},
+		{"!", ErrInvalidExpression},
+	}
+
+	for _, tt := range tests {
+		_, err := parser.Parse(tt.expression)
+		if err == nil {
+			t.Errorf("Parse(%q) expected error, got nil", tt.expression)
+			continue
+		}
+		if !errors.Is(err, tt.errType) {
+			t.Errorf("Parse(%q) error = %v, want %v", tt.expression, err, tt.errType)
+		}
+	}
+}
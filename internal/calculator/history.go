@@ -0,0 +1,129 @@
+package calculator
+
+import (
+	"strconv"
+	"time"
+)
+
+// DefaultHistorySize is how many evaluations a new calculator keeps
+const DefaultHistorySize = 10
+
+// HistoryEntry is one evaluation kept in the calculator history
+type HistoryEntry struct {
+	// Expression is the expression as given, before variables are substituted
+	Expression string
+	Result     float64
+	Time       time.Time
+}
+
+// historyRing is a bounded ring buffer of the latest evaluations
+type historyRing struct {
+	entries []HistoryEntry
+	start   int // index of the oldest entry once the buffer is full
+	size    int
+}
+
+// add records an entry, overwriting the oldest once the buffer is full
+func (h *historyRing) add(entry HistoryEntry) {
+	if h.size == 0 {
+		return
+	}
+	if len(h.entries) < h.size {
+		h.entries = append(h.entries, entry)
+		return
+	}
+	h.entries[h.start] = entry
+	h.start = (h.start + 1) % h.size
+}
+
+// list returns a copy of the entries, oldest first
+func (h *historyRing) list() []HistoryEntry {
+	entries := make([]HistoryEntry, 0, len(h.entries))
+	entries = append(entries, h.entries[h.start:]...)
+	return append(entries, h.entries[:h.start]...)
+}
+
+// latest returns the entry n evaluations back, 0 being the most recent
+func (h *historyRing) latest(n int) (HistoryEntry, bool) {
+	if n < 0 || n >= len(h.entries) {
+		return HistoryEntry{}, false
+	}
+	index := (h.start + len(h.entries) - 1 - n) % len(h.entries)
+	return h.entries[index], true
+}
+
+// resize changes the buffer size, dropping the oldest entries that no longer fit
+func (h *historyRing) resize(size int) {
+	entries := h.list()
+	if len(entries) > size {
+		entries = entries[len(entries)-size:]
+	}
+	h.entries = entries
+	h.start = 0
+	h.size = size
+}
+
+// clear empties the buffer
+func (h *historyRing) clear() {
+	h.entries = nil
+	h.start = 0
+}
+
+// NewCalculatorWithHistory creates a calculator keeping the last size evaluations
+func NewCalculatorWithHistory(size int) *Calculator {
+	c := NewCalculator()
+	c.SetHistorySize(size)
+	return c
+}
+
+// History returns the kept evaluations, oldest first
+func (c *Calculator) History() []HistoryEntry {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.history.list()
+}
+
+// ClearHistory empties the history, and with it ans and ans1..ansN
+func (c *Calculator) ClearHistory() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.history.clear()
+}
+
+// SetHistorySize sets how many of the latest evaluations are kept, and so
+// how many results are available as ans1..ansN; 0 keeps none. Older entries
+// beyond the new size are dropped.
+func (c *Calculator) SetHistorySize(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if n < 0 {
+		n = 0
+	}
+	c.history.resize(n)
+}
+
+// GetResults returns the kept results, newest (ans1) first
+func (c *Calculator) GetResults() []float64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	results := make([]float64, len(c.history.entries))
+	for i := range results {
+		entry, _ := c.history.latest(i)
+		results[i] = entry.Result
+	}
+	return results
+}
+
+// resultVariables returns the kept results by their ans names. Callers must
+// hold the lock.
+func (c *Calculator) resultVariables() map[string]float64 {
+	variables := make(map[string]float64, len(c.history.entries)+1)
+	for i := range c.history.entries {
+		entry, _ := c.history.latest(i)
+		variables["ans"+strconv.Itoa(i+1)] = entry.Result
+	}
+	if latest, ok := c.history.latest(0); ok {
+		variables["ans"] = latest.Result
+	}
+	return variables
+}
@@ -0,0 +1,32 @@
+package calculator
+
+// MemoryName is the reserved name that recalls the memory in expressions
+const MemoryName = "M"
+
+// MemoryAdd adds value to the memory, like the M+ key
+func (c *Calculator) MemoryAdd(value float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.memory += value
+}
+
+// MemorySubtract subtracts value from the memory, like the M- key
+func (c *Calculator) MemorySubtract(value float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.memory -= value
+}
+
+// MemoryRecall returns the memory, 0 when nothing has been stored
+func (c *Calculator) MemoryRecall() float64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.memory
+}
+
+// MemoryClear empties the memory
+func (c *Calculator) MemoryClear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.memory = 0
+}
@@ -1,7 +1,9 @@
 package calculator
 
 import (
+	"context"
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 	"unicode"
@@ -9,8 +11,10 @@ import (
 
 // Parser handles expression parsing and evaluation
 type Parser struct {
-	expression string
-	position   int
+	expression   string
+	position     int
+	roundingMode RoundingMode
+	ctx          context.Context
 }
 
 // NewParser creates a new parser instance
@@ -18,6 +22,11 @@ func NewParser() *Parser {
 	return &Parser{}
 }
 
+// SetRoundingMode sets the rounding mode used by the round function
+func (p *Parser) SetRoundingMode(mode RoundingMode) {
+	p.roundingMode = mode
+}
+
 // Parse parses and evaluates a mathematical expression
 func (p *Parser) Parse(expression string) (float64, error) {
 	p.expression = strings.ReplaceAll(expression, " ", "")
@@ -30,8 +39,67 @@ func (p *Parser) Parse(expression string) (float64, error) {
 	return p.parseExpression()
 }
 
-// parseExpression handles addition and subtraction (lowest precedence)
+// parseExpression parses a whole expression. Bitwise operators bind looser
+// than arithmetic, from | (lowest) through ^^ and & to the shifts, so
+// 1 + 1 << 2 is 2 << 2.
 func (p *Parser) parseExpression() (float64, error) {
+	return p.parseBitwise(0)
+}
+
+// bitwiseLevels lists the bitwise operators from lowest to highest precedence
+var bitwiseLevels = [][]string{
+	{"|"},
+	{"^^"},
+	{"&"},
+	{"<<", ">>"},
+}
+
+// parseBitwise handles the bitwise operators of bitwiseLevels[level] and
+// above, which work on whole numbers only
+func (p *Parser) parseBitwise(level int) (float64, error) {
+	if level == len(bitwiseLevels) {
+		return p.parseSum()
+	}
+
+	left, err := p.parseBitwise(level + 1)
+	if err != nil {
+		return 0, err
+	}
+
+	for {
+		op := p.matchOperator(bitwiseLevels[level])
+		if op == "" {
+			break
+		}
+		p.position += len(op) // consume the operator
+
+		right, err := p.parseBitwise(level + 1)
+		if err != nil {
+			return 0, err
+		}
+
+		if left, err = applyBitwise(op, left, right); err != nil {
+			return 0, err
+		}
+	}
+
+	return left, nil
+}
+
+// matchOperator returns the operator of ops at the current position, or an
+// empty string if there is none. A single | or & is not mistaken for a
+// longer operator, since none of ops starts another.
+func (p *Parser) matchOperator(ops []string) string {
+	for _, op := range ops {
+		if strings.HasPrefix(p.expression[p.position:], op) {
+			return op
+		}
+	}
+	return ""
+}
+
+// parseSum handles addition and subtraction
+func (p *Parser) parseSum() (float64, error) {
 	left, err := p.parseTerm()
 	if err != nil {
 		return 0, err
@@ -66,22 +134,26 @@ func (p *Parser) parseExpression() (float64, error) {
 	return left, nil
 }
 
-// parseTerm handles multiplication and division (higher precedence)
+// parseTerm handles multiplication and division (higher precedence). A
+// factor directly followed by a bracket or a name is multiplied by it, so
+// 2(3+4) is 2*(3+4) and 2(3)^2 is 2*(3^2).
 func (p *Parser) parseTerm() (float64, error) {
-	left, err := p.parseFactor()
+	left, err := p.parsePower()
 	if err != nil {
 		return 0, err
 	}
 
 	for {
 		op := p.peek()
-		if op != '*' && op != '/' {
+		if op == '(' || isLetter(op) {
+			op = '*' // implicit multiplication, nothing to consume
+		} else if op == '*' || op == '/' {
+			p.consume() // consume the operator
+		} else {
 			break
 		}
 
-		p.consume() // consume the operator
-
-		right, err := p.parseFactor()
+		right, err := p.parsePower()
 		if err != nil {
 			return 0, err
 		}
@@ -105,14 +177,58 @@ func (p *Parser) parseTerm() (float64, error) {
 	return left, nil
 }
 
+// parsePower handles exponentiation, which binds tighter than multiplication
+// and groups right to left, so 2^3^2 is 2^9
+func (p *Parser) parsePower() (float64, error) {
+	base, err := p.parseFactor()
+	if err != nil {
+		return 0, err
+	}
+
+	// A postfix "!" binds tightest of all, so 2^3! is 2^6 and -3! is -6
+	for p.peek() == '!' {
+		p.consume() // consume '!'
+		if base, err = factorial(base); err != nil {
+			return 0, err
+		}
+	}
+
+	// "^^" is exclusive or, not a power
+	if p.peek() != '^' || strings.HasPrefix(p.expression[p.position:], "^^") {
+		return base, nil
+	}
+
+	p.consume() // consume '^'
+
+	exponent, err := p.parsePower()
+	if err != nil {
+		return 0, err
+	}
+
+	result := math.Pow(base, exponent)
+	if err := ValidateNumber(result); err != nil {
+		return 0, err
+	}
+
+	return result, nil
+}
+
 // parseFactor handles numbers and parentheses
 func (p *Parser) parseFactor() (float64, error) {
-	// Handle unary plus and minus
+	// Stop early if the evaluation has been cancelled
+	if p.ctx != nil {
+		if err := p.ctx.Err(); err != nil {
+			return 0, err
+		}
+	}
+
+	// Handle unary plus and minus, which bind looser than exponentiation
+	// so that -2^2 is -(2^2)
 	if p.peek() == '+' || p.peek() == '-' {
 		op := p.peek()
 		p.consume()
 
-		value, err := p.parseFactor()
+		value, err := p.parsePower()
 		if err != nil {
 			return 0, err
 		}
@@ -140,14 +256,77 @@ func (p *Parser) parseFactor() (float64, error) {
 		return value, nil
 	}
 
+	// Handle function calls and constants
+	if isLetter(p.peek()) {
+		return p.parseFunctionCall()
+	}
+
 	// Handle numbers
 	return p.parseNumber()
 }
 
+// parseFunctionCall parses a call such as name(arg, arg) and applies the
+// function. A builtin constant such as pi is a name without a call; a bracket
+// after it multiplies, as in pi(2).
+func (p *Parser) parseFunctionCall() (float64, error) {
+	start := p.position
+	for isLetter(p.peek()) {
+		p.consume()
+	}
+	name := strings.ToLower(p.expression[start:p.position])
+
+	if value, exists := lookupConstant(name); exists {
+		return value, nil
+	}
+
+	if p.peek() != '(' {
+		return 0, fmt.Errorf("%w: unexpected identifier %q at position %d", ErrInvalidExpression, name, start)
+	}
+
+	fn, exists := lookupFunction(name)
+	if !exists {
+		return 0, fmt.Errorf("%w: %s", ErrUnknownFunction, name)
+	}
+
+	p.consume() // consume '('
+
+	var args []float64
+	if p.peek() != ')' {
+		for {
+			arg, err := p.parseExpression()
+			if err != nil {
+				return 0, err
+			}
+			args = append(args, arg)
+
+			if p.peek() != ',' {
+				break
+			}
+			p.consume() // consume ','
+		}
+	}
+
+	if p.peek() != ')' {
+		return 0, ErrMismatchedParentheses
+	}
+	p.consume() // consume ')'
+
+	return fn.call(FunctionContext{RoundingMode: p.roundingMode}, args)
+}
+
+// isLetter reports whether c can be part of a function name
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
 // parseNumber parses a numeric literal
 func (p *Parser) parseNumber() (float64, error) {
 	start := p.position
 
+	if base := parseBasePrefix(p.expression, start); base != BaseDecimal {
+		return p.parsePrefixedInteger(base)
+	}
+
 	// Parse integer part
 	for p.position < len(p.expression) && unicode.IsDigit(rune(p.expression[p.position])) {
 		p.position++
@@ -171,6 +350,20 @@ func (p *Parser) parseNumber() (float64, error) {
 		return 0, fmt.Errorf("%w: expected number at position %d", ErrInvalidExpression, p.position)
 	}
 
+	// Parse exponent, e.g. 1.2e3 or 5e-2; an "e" without digits is not one
+	if p.position < len(p.expression) && (p.expression[p.position] == 'e' || p.expression[p.position] == 'E') {
+		digits := p.position + 1
+		if digits < len(p.expression) && (p.expression[digits] == '-' || p.expression[digits] == '+') {
+			digits++
+		}
+		if digits < len(p.expression) && unicode.IsDigit(rune(p.expression[digits])) {
+			p.position = digits
+			for p.position < len(p.expression) && unicode.IsDigit(rune(p.expression[p.position])) {
+				p.position++
+			}
+		}
+	}
+
 	numberStr := p.expression[start:p.position]
 	value, err := strconv.ParseFloat(numberStr, 64)
 	if err != nil {
@@ -180,6 +373,24 @@ func (p *Parser) parseNumber() (float64, error) {
 	return value, nil
 }
 
+// parsePrefixedInteger parses an integer literal written in base with its
+// prefix, such as 0xFF, 0o17 or 0b1010
+func (p *Parser) parsePrefixedInteger(base NumberBase) (float64, error) {
+	p.position += len(base.prefix())
+	start := p.position
+	for p.position < len(p.expression) && isBaseDigit(p.expression[p.position]) {
+		p.position++
+	}
+
+	digits := p.expression[start:p.position]
+	value, err := strconv.ParseUint(digits, base.Radix(), 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q is not a %s number", ErrInvalidNumber, base.prefix()+digits, base)
+	}
+
+	return float64(value), nil
+}
+
 // peek returns the current character without consuming it
 func (p *Parser) peek() byte {
 	if p.position >= len(p.expression) {
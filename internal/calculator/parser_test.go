@@ -64,6 +64,21 @@ func TestParseBasicExpressions(t *testing.T) {
 		{"-3*4", -12, false},
 		{"3*(-4)", -12, false},
 
+		// Exponentiation
+		{"5^2", 25, false},
+		{"2^3", 8, false},
+		{"2*3^2", 18, false},     // 2 * (3^2) = 18
+		{"2^3^2", 512, false},    // 2^(3^2) = 512
+		{"-2^2", -4, false},      // -(2^2) = -4
+		{"2^-1", 0.5, false},
+		{"(1+2)^2", 9, false},
+
+		// Scientific notation
+		{"1.2e3", 1200, false},
+		{"5e-2", 0.05, false},
+		{"2E+2", 200, false},
+		{"1.5e2*2", 300, false},
+
 		// Complex expressions
 		{"2.5*(3+4.5)/2", 9.375, false},
 		{"(10-3.5)*2+1", 14, false},
@@ -97,6 +112,45 @@ func TestParseBasicExpressions(t *testing.T) {
 	}
 }
 
+func TestParseImplicitMultiplication(t *testing.T) {
+	parser := NewParser()
+
+	tests := []struct {
+		expression string
+		want       float64
+	}{
+		{"2(3+4)", 14},
+		{"(1+2)(3+4)", 21},
+		{"2(3)^2", 18},  // 2 * (3^2), not (2*3)^2
+		{"1+2(3)", 7},   // binds like *, tighter than +
+		{"12/2(3)", 18}, // and left to right with /
+		{"-2(3)", -6},
+		{"2(3(4+1))", 30}, // nested
+		{"(2)(3)(4)", 24},
+		{"2round(2.4)", 4}, // before a function call
+		{"round(2.4)(3)", 6},
+		{"round(2.6)", 3}, // a function name is not multiplied into its call
+		{"2pi", 2 * math.Pi},
+		{"pi(2)", 2 * math.Pi},
+		{"3e", 3 * math.E},
+		{"2e3", 2000}, // an exponent, not 2*e*3
+		{"2e-1", 0.2},
+		{"PI", math.Pi},
+		{"e^2", math.E * math.E},
+	}
+
+	for _, tt := range tests {
+		result, err := parser.Parse(tt.expression)
+		if err != nil {
+			t.Errorf("Parse(%q) returned error: %v", tt.expression, err)
+			continue
+		}
+		if math.Abs(result-tt.want) > 1e-10 {
+			t.Errorf("Parse(%q) = %f, want %f", tt.expression, result, tt.want)
+		}
+	}
+}
+
 func TestParseErrorCases(t *testing.T) {
 	parser := NewParser()
 
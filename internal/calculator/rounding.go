@@ -0,0 +1,88 @@
+package calculator
+
+import "math"
+
+// roundingEpsilon is the relative distance within which a value is taken to
+// lie on a rounding boundary, so binary representation error (2.3*100 =
+// 229.99999999999997) cannot push it across one. It allows a few units in the
+// last place, so large values keep their digits.
+const roundingEpsilon = 1e-15
+
+// RoundingMode controls how values are rounded by the round function and
+// when results are rounded for display
+type RoundingMode int
+
+const (
+	// RoundHalfUp rounds halves away from zero (2.5 -> 3, -2.5 -> -3)
+	RoundHalfUp RoundingMode = iota
+	// RoundHalfEven rounds halves to the nearest even digit (2.5 -> 2, 3.5 -> 4)
+	RoundHalfEven
+	// RoundFloor rounds towards negative infinity
+	RoundFloor
+	// RoundCeil rounds towards positive infinity
+	RoundCeil
+)
+
+// String returns the string representation of the rounding mode
+func (r RoundingMode) String() string {
+	switch r {
+	case RoundHalfUp:
+		return "half-up"
+	case RoundHalfEven:
+		return "half-even"
+	case RoundFloor:
+		return "floor"
+	case RoundCeil:
+		return "ceil"
+	default:
+		return "unknown"
+	}
+}
+
+// Round rounds a value to a whole number using the rounding mode
+func (r RoundingMode) Round(value float64) float64 {
+	value = snapBoundary(value)
+
+	switch r {
+	case RoundHalfEven:
+		return math.RoundToEven(value)
+	case RoundFloor:
+		return math.Floor(value)
+	case RoundCeil:
+		return math.Ceil(value)
+	default:
+		return math.Round(value)
+	}
+}
+
+// RoundTo rounds a value to the given number of decimal places using the rounding mode
+func (r RoundingMode) RoundTo(value float64, places int) float64 {
+	if places <= 0 {
+		return r.Round(value)
+	}
+
+	scale := math.Pow(10, float64(places))
+	scaled := value * scale
+	if IsOverflow(scaled) {
+		return value
+	}
+	return r.Round(scaled) / scale
+}
+
+// snapBoundary moves a value onto the nearest whole number or half when it
+// is within roundingEpsilon of it, and otherwise returns it unchanged
+func snapBoundary(value float64) float64 {
+	return snapTo(value, math.Round(value*2)/2)
+}
+
+// snapTo returns boundary if value is within roundingEpsilon of it, else value
+func snapTo(value, boundary float64) float64 {
+	if math.IsInf(value, 0) || math.IsNaN(value) {
+		return value
+	}
+	if math.Abs(value-boundary) <= roundingEpsilon*math.Abs(value) {
+		return boundary
+	}
+	return value
+}
+
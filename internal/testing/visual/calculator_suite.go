@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/stretchr/testify/require"
 
 	"ccpm-demo/internal/calculator"
@@ -13,12 +14,23 @@ import (
 	visualpkg "ccpm-demo/internal/visual"
 )
 
+// DefaultVisualWidths are the terminal widths captured by the responsive width matrix
+var DefaultVisualWidths = []int{40, 60, 80, 100, 120}
+
 // CalculatorVisualSuite provides visual testing specifically for calculator functionality
 type CalculatorVisualSuite struct {
 	Engine     *calculator.Engine
 	Model      ui.Model
 	Config     visualpkg.TerminalConfig
 	TestConfig TestConfig
+	Widths     []int
+}
+
+// WidthCase is a single entry in the responsive width matrix
+type WidthCase struct {
+	Name   string
+	Width  int
+	Config visualpkg.TerminalConfig
 }
 
 // NewCalculatorVisualSuite creates a new calculator visual test suite
@@ -41,7 +53,53 @@ func NewCalculatorVisualSuite() *CalculatorVisualSuite {
 			MaxTestTime:   30 * time.Second,
 			SaveScreenshots: true,
 		},
+		// The suite gets its own widths so changing them leaves the defaults alone
+		Widths: append([]int(nil), DefaultVisualWidths...),
+	}
+}
+
+// WidthCases returns the width matrix cases, named by width (e.g. "width_80")
+func (cvs *CalculatorVisualSuite) WidthCases() []WidthCase {
+	cases := make([]WidthCase, 0, len(cvs.Widths))
+	for _, width := range cvs.Widths {
+		config := cvs.Config
+		config.Width = width
+
+		cases = append(cases, WidthCase{
+			Name:   fmt.Sprintf("width_%d", width),
+			Width:  width,
+			Config: config,
+		})
+	}
+	return cases
+}
+
+// CaptureAtWidth resets the calculator, sizes it to the case's width and captures a screenshot
+func (cvs *CalculatorVisualSuite) CaptureAtWidth(widthCase WidthCase) (*visualpkg.Screenshot, error) {
+	cvs.resetCalculator()
+
+	updatedModel, _ := cvs.Model.Update(tea.WindowSizeMsg{
+		Width:  widthCase.Width,
+		Height: widthCase.Config.Height,
+	})
+	if model, ok := updatedModel.(ui.Model); ok {
+		cvs.Model = model
+	}
+
+	return visualpkg.NewScreenshotFromModel(cvs.Model, widthCase.Config)
+}
+
+// CaptureWidthBaselines captures one baseline per configured width, keyed by case name
+func (cvs *CalculatorVisualSuite) CaptureWidthBaselines() (map[string]*visualpkg.Screenshot, error) {
+	baselines := make(map[string]*visualpkg.Screenshot)
+	for _, widthCase := range cvs.WidthCases() {
+		screenshot, err := cvs.CaptureAtWidth(widthCase)
+		if err != nil {
+			return nil, fmt.Errorf("failed to capture %s: %w", widthCase.Name, err)
+		}
+		baselines[widthCase.Name] = screenshot
 	}
+	return baselines, nil
 }
 
 // TestCalculatorOperations tests visual aspects of calculator operations
@@ -359,6 +417,37 @@ func (cvs *CalculatorVisualSuite) TestThemeSwitchingVisual(t *testing.T) {
 	})
 }
 
+// TestResponsiveWidthsVisual captures the calculator at each configured width so
+// responsive layout regressions are caught
+func (cvs *CalculatorVisualSuite) TestResponsiveWidthsVisual(t *testing.T) {
+	t.Run("Responsive Widths Visual", func(t *testing.T) {
+		for _, widthCase := range cvs.WidthCases() {
+			t.Run(widthCase.Name, func(t *testing.T) {
+				baseline, err := cvs.CaptureAtWidth(widthCase)
+				require.NoError(t, err, "Should capture baseline screenshot")
+				require.Equal(t, widthCase.Width, baseline.Metadata.Width, "Baseline should match the case width")
+
+				current, err := cvs.CaptureAtWidth(widthCase)
+				require.NoError(t, err, "Should capture current screenshot")
+
+				compareConfig := visualpkg.NewDefaultCompareConfig()
+				result, err := visualpkg.CompareScreenshots(baseline, current, compareConfig)
+				require.NoError(t, err, "Should compare screenshots successfully")
+				require.LessOrEqual(t, result.DiffRatio, cvs.TestConfig.MaxDiffRatio, "Layout should be stable at this width")
+
+				if cvs.TestConfig.SaveScreenshots && cvs.TestConfig.UpdateMode {
+					err = os.MkdirAll(cvs.TestConfig.BaselineDir, 0755)
+					require.NoError(t, err, "Should create baseline directory")
+					err = baseline.Save(fmt.Sprintf("%s/%s.png", cvs.TestConfig.BaselineDir, widthCase.Name))
+					require.NoError(t, err, "Should save baseline screenshot")
+				}
+
+				t.Logf("Width %d verified. Diff ratio: %.4f", widthCase.Width, result.DiffRatio)
+			})
+		}
+	})
+}
+
 // TestCalculatorRegressionFull runs a full regression test for calculator functionality
 func (cvs *CalculatorVisualSuite) TestCalculatorRegressionFull(t *testing.T) {
 	t.Run("Calculator Regression Full", func(t *testing.T) {
@@ -472,6 +561,7 @@ func RunCalculatorVisualTests(t *testing.T) {
 	suite.TestButtonVisualFeedback(t)
 	suite.TestKeyboardNavigationVisual(t)
 	suite.TestThemeSwitchingVisual(t)
+	suite.TestResponsiveWidthsVisual(t)
 	suite.TestCalculatorRegressionFull(t)
 	suite.TestCalculatorDemoGeneration(t)
 }
\ No newline at end of file
@@ -0,0 +1,56 @@
+package visual
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ccpm-demo/internal/ui"
+	visualpkg "ccpm-demo/internal/visual"
+)
+
+// FailureSnapshotDir is where failure snapshots are written by default
+const FailureSnapshotDir = "test/visual/failures"
+
+// SnapshotOnFailure registers a cleanup that captures a screenshot of model
+// if the test has failed by the time it finishes. The model is read when the
+// test ends, so it reflects the state at the point of failure. The screenshot
+// is written to dir (FailureSnapshotDir when empty) and its path is returned.
+func SnapshotOnFailure(t testing.TB, model *ui.Model, dir string) string {
+	t.Helper()
+
+	if dir == "" {
+		dir = FailureSnapshotDir
+	}
+	path := filepath.Join(dir, failureSnapshotName(t.Name()))
+
+	t.Cleanup(func() {
+		if !t.Failed() {
+			return
+		}
+
+		screenshot, err := visualpkg.NewScreenshotFromModel(*model, visualpkg.NewDefaultConfig())
+		if err != nil {
+			t.Logf("Failed to capture failure snapshot: %v", err)
+			return
+		}
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Logf("Failed to create failure snapshot directory: %v", err)
+			return
+		}
+		if err := screenshot.Save(path); err != nil {
+			t.Logf("Failed to save failure snapshot: %v", err)
+			return
+		}
+		t.Logf("Saved failure snapshot to %s", path)
+	})
+
+	return path
+}
+
+// failureSnapshotName turns a test name into a PNG file name
+func failureSnapshotName(testName string) string {
+	name := strings.NewReplacer("/", "_", " ", "_", string(filepath.Separator), "_").Replace(testName)
+	return name + ".png"
+}
@@ -1,11 +1,19 @@
 package visual
 
 import (
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	tea "github.com/charmbracelet/bubbletea"
 
@@ -414,4 +422,469 @@ func TestVisualTestingReliability(t *testing.T) {
 
 		t.Logf("All visual testing reliability tests passed")
 	})
-}
\ No newline at end of file
+}
+
+// TestCalculatorWidthMatrix tests that each configured width yields its own comparable baseline
+func TestCalculatorWidthMatrix(t *testing.T) {
+	t.Run("Default Widths", func(t *testing.T) {
+		suite := NewCalculatorVisualSuite()
+		require.Equal(t, []int{40, 60, 80, 100, 120}, suite.Widths, "Default widths should cover narrow to wide terminals")
+
+		baselines, err := suite.CaptureWidthBaselines()
+		require.NoError(t, err, "Should capture a baseline per width")
+		require.Len(t, baselines, len(suite.Widths), "Each width should have its own baseline")
+
+		seenWidths := make(map[int]bool)
+		for _, widthCase := range suite.WidthCases() {
+			baseline, exists := baselines[widthCase.Name]
+			require.True(t, exists, "Baseline %s should exist", widthCase.Name)
+
+			// Distinct: every width produces a differently sized image
+			imageWidth := baseline.Image.Bounds().Dx()
+			require.False(t, seenWidths[imageWidth], "Baseline %s should be distinct", widthCase.Name)
+			seenWidths[imageWidth] = true
+
+			// Comparable: a fresh capture at the same width matches the baseline
+			current, err := suite.CaptureAtWidth(widthCase)
+			require.NoError(t, err, "Should recapture %s", widthCase.Name)
+
+			result, err := visualpkg.CompareScreenshots(baseline, current, visualpkg.NewDefaultCompareConfig())
+			require.NoError(t, err, "Baseline %s should be comparable", widthCase.Name)
+			require.True(t, result.Identical, "Recapture at %s should match its baseline", widthCase.Name)
+		}
+	})
+
+	t.Run("Custom Widths", func(t *testing.T) {
+		suite := NewCalculatorVisualSuite()
+		suite.Widths = []int{50, 90}
+
+		cases := suite.WidthCases()
+		require.Len(t, cases, 2, "Should use the configured widths")
+		require.Equal(t, "width_50", cases[0].Name, "Cases should be named by width")
+		require.Equal(t, 90, cases[1].Config.Width, "Case config should use the case width")
+	})
+
+	t.Run("Widths Are Copied", func(t *testing.T) {
+		suite := NewCalculatorVisualSuite()
+		suite.Widths[0] = 30
+
+		require.Equal(t, 40, DefaultVisualWidths[0], "Changing a suite's widths should leave the defaults alone")
+	})
+}
+
+// TestReportDiffSummary tests the aggregate diff ratios in the text report
+func TestReportDiffSummary(t *testing.T) {
+	results := &TestResults{
+		Name: "Diff Summary",
+		TestCases: map[string]*TestCaseResult{
+			"identical": {Name: "identical", Passed: true, DiffRatio: 0},
+			"minor":     {Name: "minor", Passed: true, DiffRatio: 0.02},
+			"major":     {Name: "major", Passed: false, DiffRatio: 0.10},
+			"severe":    {Name: "severe", Passed: false, DiffRatio: 0.20},
+			"skipped":   {Name: "skipped", Skipped: true, DiffRatio: 0.90},
+		},
+	}
+
+	generator := NewReportGenerator(results, ReportConfig{DiffThreshold: 0.05})
+	summary := generator.GetDiffSummary()
+
+	require.Equal(t, 4, summary.Cases, "Skipped cases should not count")
+	require.InDelta(t, 0.20, summary.MaxRatio, 1e-9, "Max should be the largest diff ratio")
+	require.InDelta(t, 0.08, summary.MeanRatio, 1e-9, "Mean should average the cases that ran")
+	require.Equal(t, 2, summary.OverThreshold, "Two cases exceed the threshold")
+
+	report := generator.generateTextReportContent()
+	require.Contains(t, report, "--- Diff Summary ---")
+	require.Contains(t, report, "Max Diff Ratio: 20.00%")
+	require.Contains(t, report, "Mean Diff Ratio: 8.00%")
+	require.Contains(t, report, "Over Threshold (5.00%): 2 of 4")
+
+	// The default threshold applies when none is configured
+	require.Equal(t, defaultDiffThreshold, NewReportGenerator(results, ReportConfig{}).GetDiffSummary().Threshold)
+}
+
+// TestDemoCaptionOverlay tests that demo frames can carry a burned-in caption
+func TestDemoCaptionOverlay(t *testing.T) {
+	model := ui.NewModel(calculator.NewEngine())
+	config := visualpkg.NewDefaultConfig()
+
+	captureFrame := func(dir string, captions visualpkg.CaptionConfig) image.Image {
+		demoGen := visualpkg.NewDemoGenerator(model, config, dir)
+		demoGen.Captions = captions
+		require.NoError(t, demoGen.StartRecording("captions", "Caption overlay"))
+		require.NoError(t, demoGen.CaptureFrame("Press '1'"))
+		require.NoError(t, demoGen.StopRecording())
+
+		file, err := os.Open(filepath.Join(dir, "frame_0000.png"))
+		require.NoError(t, err, "Frame should be saved")
+		defer file.Close()
+
+		img, err := png.Decode(file)
+		require.NoError(t, err, "Frame should decode as PNG")
+		return img
+	}
+
+	tempDir := t.TempDir()
+	plain := captureFrame(filepath.Join(tempDir, "plain"), visualpkg.CaptionConfig{})
+	annotated := captureFrame(filepath.Join(tempDir, "annotated"), visualpkg.NewDefaultCaptionConfig())
+
+	require.Equal(t, plain.Bounds(), annotated.Bounds(), "Captions should not resize the frame")
+
+	// The caption band sits on the last row by default
+	bottom := annotated.Bounds().Max.Y - 1
+	require.NotEqual(t, plain.At(0, bottom), annotated.At(0, bottom), "Annotated frame should differ from the plain one")
+	require.Equal(t, plain.At(0, 0), annotated.At(0, 0), "Rows outside the caption should be untouched")
+
+	top := visualpkg.NewDefaultCaptionConfig()
+	top.Position = visualpkg.CaptionTop
+	topAnnotated := captureFrame(filepath.Join(tempDir, "top"), top)
+	require.NotEqual(t, plain.At(0, 0), topAnnotated.At(0, 0), "Top captions should cover the first row")
+	require.Equal(t, plain.At(0, bottom), topAnnotated.At(0, bottom), "Top captions should leave the last row alone")
+}
+
+// TestDemoFrameDelay tests that frame timing is persisted and honored by the exporters
+func TestDemoFrameDelay(t *testing.T) {
+	demoDir := t.TempDir()
+	demoGen := visualpkg.NewDemoGenerator(ui.NewModel(calculator.NewEngine()), visualpkg.NewDefaultConfig(), demoDir)
+	demoGen.FrameDelay = 500 * time.Millisecond
+
+	require.NoError(t, demoGen.StartRecording("pacing", "Frame timing"))
+	require.NoError(t, demoGen.CaptureFrame("First"))
+	require.NoError(t, demoGen.CaptureFrame("Second"))
+	require.NoError(t, demoGen.CaptureFrameWithDelay("Third", 2*time.Second))
+	require.NoError(t, demoGen.CaptureFrame("Fourth"))
+	require.NoError(t, demoGen.StopRecording())
+
+	// The delays are persisted in the metadata
+	data, err := os.ReadFile(filepath.Join(demoDir, "demo.json"))
+	require.NoError(t, err, "Demo metadata should be saved")
+
+	var metadata struct {
+		FrameDelay time.Duration `json:"frameDelay"`
+		Actions    []struct {
+			Delay time.Duration `json:"delay"`
+		} `json:"actions"`
+	}
+	require.NoError(t, json.Unmarshal(data, &metadata))
+	require.Equal(t, 500*time.Millisecond, metadata.FrameDelay, "Global delay should be persisted")
+	require.Len(t, metadata.Actions, 4)
+	require.Equal(t, 500*time.Millisecond, metadata.Actions[0].Delay, "Frames should default to the global delay")
+	require.Equal(t, 2*time.Second, metadata.Actions[2].Delay, "Per-frame delay should be persisted")
+
+	// Cast events start once the previous frame has been shown for its delay
+	castPath := filepath.Join(demoDir, "demo.cast")
+	require.NoError(t, demoGen.ExportCast(castPath))
+
+	castData, err := os.ReadFile(castPath)
+	require.NoError(t, err, "Cast should be written")
+	lines := strings.Split(strings.TrimSpace(string(castData)), "\n")
+	require.Len(t, lines, 5, "Cast should have a header and one event per frame")
+
+	var header map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &header))
+	require.Equal(t, float64(2), header["version"], "Cast should use asciicast v2")
+
+	expected := []float64{0, 0.5, 1.0, 3.0}
+	for i, line := range lines[1:] {
+		var event []interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &event))
+		require.InDelta(t, expected[i], event[0], 1e-9, "Frame %d timestamp", i)
+		require.Equal(t, "o", event[1], "Frames should be output events")
+	}
+
+	// The GIF carries the same delays in hundredths of a second
+	gifPath := filepath.Join(demoDir, "demo.gif")
+	require.NoError(t, demoGen.ExportGIF(gifPath))
+
+	gifFile, err := os.Open(gifPath)
+	require.NoError(t, err, "GIF should be written")
+	defer gifFile.Close()
+
+	animation, err := gif.DecodeAll(gifFile)
+	require.NoError(t, err, "GIF should decode")
+	require.Equal(t, []int{50, 50, 200, 50}, animation.Delay, "GIF should honor the frame delays")
+}
+
+// TestDemoReplay tests that a recorded demo replays against a fresh model
+func TestDemoReplay(t *testing.T) {
+	newModel := func() tea.Model {
+		model, _ := ui.NewModel(calculator.NewEngine()).Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+		return model
+	}
+
+	demoGen := visualpkg.NewDemoGenerator(newModel(), visualpkg.NewDefaultConfig(), t.TempDir())
+	require.NoError(t, demoGen.StartRecording("replay", "Replay check"))
+	require.NoError(t, demoGen.CaptureFrame("Initial state"))
+	for _, key := range "12+3=" {
+		require.NoError(t, demoGen.AddKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{key}}, "Press key"))
+		require.NoError(t, demoGen.CaptureFrame(fmt.Sprintf("After '%c'", key)))
+	}
+	require.NoError(t, demoGen.StopRecording())
+
+	require.NoError(t, demoGen.Replay(newModel()), "Fresh model should reproduce every frame")
+
+	// A model that has drifted from the recording is reported
+	drifted, _ := newModel().Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'9'}})
+	err := demoGen.Replay(drifted)
+	require.Error(t, err, "Drifted model should fail the replay")
+	require.Contains(t, err.Error(), "frame 0", "Error should name the first mismatched frame")
+}
+
+// TestDemoAutoCapture tests that auto-capture records a frame after every key press
+func TestDemoAutoCapture(t *testing.T) {
+	model, _ := ui.NewModel(calculator.NewEngine()).Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	demoGen := visualpkg.NewDemoGenerator(model, visualpkg.NewDefaultConfig(), t.TempDir())
+	demoGen.AutoCapture = true
+
+	require.NoError(t, demoGen.StartRecording("auto", "Auto-capture"))
+	require.NoError(t, demoGen.AddKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'7'}}, "Enter a digit"))
+	require.NoError(t, demoGen.AddKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'+'}}, ""))
+	require.NoError(t, demoGen.AddKeyPress(tea.KeyMsg{Type: tea.KeyEnter}, ""))
+	require.NoError(t, demoGen.StopRecording())
+
+	var frames []visualpkg.DemoAction
+	for _, action := range demoGen.Sequence.Actions {
+		if action.Type == "screenshot" {
+			frames = append(frames, action)
+		}
+	}
+	require.Len(t, frames, 3, "Each key press should capture a frame")
+	assert.Equal(t, "After '7': Enter a digit", frames[0].Description)
+	assert.Equal(t, "After '+'", frames[1].Description)
+	assert.Equal(t, "After 'enter'", frames[2].Description)
+	assert.Contains(t, frames[0].Content, "7", "Frames should show the key's effect")
+	assert.Equal(t, 3, demoGen.CurrentFrame)
+}
+
+// expectedFailureTB records failures and cleanups instead of failing the real test
+type expectedFailureTB struct {
+	testing.TB
+	name     string
+	failed   bool
+	cleanups []func()
+}
+
+func (f *expectedFailureTB) Helper()                                   {}
+func (f *expectedFailureTB) Name() string                              { return f.name }
+func (f *expectedFailureTB) Errorf(format string, args ...interface{}) { f.failed = true }
+func (f *expectedFailureTB) Failed() bool                              { return f.failed }
+func (f *expectedFailureTB) Logf(format string, args ...interface{})   {}
+func (f *expectedFailureTB) Cleanup(fn func())                         { f.cleanups = append(f.cleanups, fn) }
+
+// finish runs the registered cleanups in reverse order, as the testing package does
+func (f *expectedFailureTB) finish() {
+	for i := len(f.cleanups) - 1; i >= 0; i-- {
+		f.cleanups[i]()
+	}
+}
+
+// TestSnapshotOnFailure tests that a failing UI test leaves a screenshot behind
+func TestSnapshotOnFailure(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("Failure Writes Snapshot", func(t *testing.T) {
+		fake := &expectedFailureTB{TB: t, name: "TestCalculator/adds numbers"}
+		model := ui.NewModel(calculator.NewEngine())
+		path := SnapshotOnFailure(fake, &model, dir)
+
+		// Deliberately fail against the model's state
+		updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'7'}})
+		model = updated.(ui.Model)
+		assert.Equal(fake, "8", model.GetInput())
+		fake.finish()
+
+		require.True(t, fake.Failed(), "The deliberate failure should have been recorded")
+		require.Equal(t, filepath.Join(dir, "TestCalculator_adds_numbers.png"), path)
+
+		file, err := os.Open(path)
+		require.NoError(t, err, "Failure snapshot should be written")
+		defer file.Close()
+		_, err = png.Decode(file)
+		require.NoError(t, err, "Failure snapshot should be a PNG")
+	})
+
+	t.Run("Passing Test Writes Nothing", func(t *testing.T) {
+		fake := &expectedFailureTB{TB: t, name: "TestCalculator/passes"}
+		model := ui.NewModel(calculator.NewEngine())
+		path := SnapshotOnFailure(fake, &model, dir)
+		fake.finish()
+
+		_, err := os.Stat(path)
+		require.True(t, os.IsNotExist(err), "Passing tests should not leave a snapshot")
+	})
+}
+
+// TestPerThemeBaselineDirs tests that runs under different themes keep separate baselines
+func TestPerThemeBaselineDirs(t *testing.T) {
+	tempDir := t.TempDir()
+	config := TestConfig{
+		BaselineDir:  filepath.Join(tempDir, "baseline"),
+		CurrentDir:   filepath.Join(tempDir, "current"),
+		DiffDir:      filepath.Join(tempDir, "diff"),
+		Tolerance:    0.01,
+		UpdateMode:   true,
+		PerThemeDirs: true,
+	}
+
+	for _, theme := range []string{"retro-casio", "modern"} {
+		model := ui.NewModel(calculator.NewEngine())
+		require.NoError(t, model.SetButtonGridTheme(theme))
+
+		test := NewVisualRegressionTest("Theme Baselines", "Per-theme baseline test", model, config)
+		require.Equal(t, theme, test.ActiveTheme())
+		require.NoError(t, test.Run())
+		require.True(t, test.Results.Passed, "Creating baselines for %s should pass", theme)
+	}
+
+	for _, theme := range []string{"retro-casio", "modern"} {
+		assert.FileExists(t, filepath.Join(tempDir, "baseline", theme, "initial_state.png"))
+		assert.FileExists(t, filepath.Join(tempDir, "current", theme, "initial_state.png"))
+		assert.DirExists(t, filepath.Join(tempDir, "diff", theme))
+	}
+
+	// Nothing is written to the shared directories themselves
+	_, err := os.Stat(filepath.Join(tempDir, "baseline", "initial_state.png"))
+	assert.True(t, os.IsNotExist(err), "Baselines should only be written per theme")
+}
+
+// TestScreenshotThemeBackground tests that screenshots are filled with the theme's background
+func TestScreenshotThemeBackground(t *testing.T) {
+	expected := map[string]color.RGBA{
+		"retro-casio": {0x26, 0x26, 0x26, 0xff}, // ANSI 235
+		"minimal":     {0x00, 0x00, 0x00, 0xff}, // ANSI 0
+	}
+
+	for theme, background := range expected {
+		t.Run(theme, func(t *testing.T) {
+			model := ui.NewModel(calculator.NewEngine())
+			require.NoError(t, model.SetButtonGridTheme(theme))
+
+			themeColor, err := visualpkg.TerminalColor(model.GetThemeBackground())
+			require.NoError(t, err)
+			require.Equal(t, background, themeColor)
+
+			// A foreground-colored default makes a missed fill easy to spot
+			config := visualpkg.NewDefaultConfig()
+			config.Background = color.White
+			screenshot, err := visualpkg.NewScreenshotFromModel(model, config)
+			require.NoError(t, err)
+
+			bounds := screenshot.Image.Bounds()
+			corners := []image.Point{
+				{bounds.Min.X, bounds.Min.Y},
+				{bounds.Max.X - 1, bounds.Min.Y},
+				{bounds.Min.X, bounds.Max.Y - 1},
+				{bounds.Max.X - 1, bounds.Max.Y - 1},
+			}
+			for _, corner := range corners {
+				assert.Equal(t, background, screenshot.Image.RGBAAt(corner.X, corner.Y), "corner %v", corner)
+			}
+		})
+	}
+}
+
+// TestScreenshotCellSize tests that the cell size sets the screenshot resolution
+func TestScreenshotCellSize(t *testing.T) {
+	model := ui.NewModel(calculator.NewEngine())
+
+	config := visualpkg.NewDefaultConfig()
+	small, err := visualpkg.NewScreenshotFromModel(model, config)
+	require.NoError(t, err)
+	assert.Equal(t, config.Width*config.CellWidth, small.Image.Bounds().Dx())
+	assert.Equal(t, config.Height*config.CellHeight, small.Image.Bounds().Dy())
+
+	config.CellWidth *= 2
+	config.CellHeight *= 2
+	large, err := visualpkg.NewScreenshotFromModel(model, config)
+	require.NoError(t, err)
+	assert.Equal(t, 2*small.Image.Bounds().Dx(), large.Image.Bounds().Dx())
+	assert.Equal(t, 2*small.Image.Bounds().Dy(), large.Image.Bounds().Dy())
+
+	// Unset cell sizes fall back to the default cell
+	config.CellWidth = 0
+	config.CellHeight = 0
+	fallback, err := visualpkg.NewScreenshotFromModel(model, config)
+	require.NoError(t, err)
+	assert.Equal(t, small.Image.Bounds(), fallback.Image.Bounds())
+}
+
+// TestBeforeAfterComposite tests stacking two model states into one image
+func TestBeforeAfterComposite(t *testing.T) {
+	before := ui.NewModel(calculator.NewEngine())
+	updated, _ := before.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'7'}})
+	after := updated.(ui.Model)
+
+	config := visualpkg.NewDefaultConfig()
+	beforeShot, err := visualpkg.NewScreenshotFromModel(before, config)
+	require.NoError(t, err)
+	afterShot, err := visualpkg.NewScreenshotFromModel(after, config)
+	require.NoError(t, err)
+
+	composite, err := visualpkg.CaptureBeforeAfter(before, after, "after pressing 7", config)
+	require.NoError(t, err)
+
+	bounds := composite.Image.Bounds()
+	beforeHeight := beforeShot.Image.Bounds().Dy()
+	afterHeight := afterShot.Image.Bounds().Dy()
+	require.Equal(t, beforeHeight+config.CellHeight+afterHeight, bounds.Dy(), "Composite should stack both images and the divider")
+	require.Equal(t, beforeShot.Image.Bounds().Dx(), bounds.Dx())
+
+	// Both states appear unchanged in their halves
+	for y := 0; y < beforeHeight; y++ {
+		for x := 0; x < bounds.Dx(); x++ {
+			require.Equal(t, beforeShot.Image.RGBAAt(x, y), composite.Image.RGBAAt(x, y), "before pixel (%d,%d)", x, y)
+		}
+	}
+	offset := beforeHeight + config.CellHeight
+	for y := 0; y < afterHeight; y++ {
+		for x := 0; x < bounds.Dx(); x++ {
+			require.Equal(t, afterShot.Image.RGBAAt(x, y), composite.Image.RGBAAt(x, offset+y), "after pixel (%d,%d)", x, y)
+		}
+	}
+
+	// The divider is drawn in the caption colors
+	caption := visualpkg.NewDefaultCaptionConfig()
+	assert.Equal(t, caption.Background, composite.Image.At(bounds.Dx()-1, beforeHeight))
+}
+
+// TestWarnTolerance tests that diffs between the pass and warn tolerances pass with a warning
+func TestWarnTolerance(t *testing.T) {
+	config := TestConfig{Tolerance: 0.01, WarnTolerance: 0.05}
+	test := NewVisualRegressionTest("Warn Band", "Warn tolerance test", ui.NewModel(calculator.NewEngine()), config)
+
+	cases := map[string]float64{"exact": 0, "flaky": 0.03, "broken": 0.10}
+	for _, name := range []string{"exact", "flaky", "broken"} {
+		result := &TestCaseResult{Name: name, DiffRatio: cases[name]}
+		test.applyTolerance(result, cases[name])
+		test.recordResult(name, result)
+	}
+
+	flaky := test.Results.TestCases["flaky"]
+	assert.True(t, flaky.Passed, "A diff in the warn band should pass")
+	assert.Contains(t, flaky.Warning, "within warn tolerance 5.00%")
+	assert.Empty(t, flaky.Error)
+
+	assert.True(t, test.Results.TestCases["exact"].Passed)
+	assert.Empty(t, test.Results.TestCases["exact"].Warning)
+	assert.False(t, test.Results.TestCases["broken"].Passed, "A diff above the warn band should fail")
+
+	assert.Equal(t, 2, test.Results.PassedTests)
+	assert.Equal(t, 1, test.Results.WarnedTests)
+	assert.Equal(t, 1, test.Results.FailedTests)
+
+	report := test.GenerateReport()
+	assert.Contains(t, report, "Warnings: 1")
+	assert.Contains(t, report, "--- Warnings ---")
+	assert.Contains(t, report, "⚠️ flaky: diff ratio 3.00%")
+	assert.NotContains(t, report, "⚠️ exact")
+
+	textReport := NewReportGenerator(test.Results, ReportConfig{}).generateTextReportContent()
+	assert.Contains(t, textReport, "⚠️ flaky")
+
+	// Without a warn tolerance the same diff fails
+	strict := NewVisualRegressionTest("Strict", "No warn band", nil, TestConfig{Tolerance: 0.01})
+	result := &TestCaseResult{Name: "flaky"}
+	strict.applyTolerance(result, 0.03)
+	assert.False(t, result.Passed)
+	assert.Empty(t, result.Warning)
+}
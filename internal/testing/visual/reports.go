@@ -19,6 +19,19 @@ type ReportGenerator struct {
 	GenerateHTML    bool
 	GenerateJSON    bool
 	GenerateText    bool
+	DiffThreshold   float64
+}
+
+// defaultDiffThreshold is the diff ratio above which a case counts as a high diff
+const defaultDiffThreshold = 0.05
+
+// DiffSummary aggregates diff ratios across all test cases
+type DiffSummary struct {
+	Cases         int
+	MaxRatio      float64
+	MeanRatio     float64
+	Threshold     float64
+	OverThreshold int
 }
 
 // ReportConfig contains configuration for report generation
@@ -32,6 +45,7 @@ type ReportConfig struct {
 	Theme          string
 	ShowDiffImages  bool
 	ShowThumbnails  bool
+	DiffThreshold   float64
 }
 
 // NewReportGenerator creates a new report generator
@@ -44,6 +58,7 @@ func NewReportGenerator(results *TestResults, config ReportConfig) *ReportGenera
 		GenerateHTML:    config.GenerateHTML,
 		GenerateJSON:    config.GenerateJSON,
 		GenerateText:    config.GenerateText,
+		DiffThreshold:   config.DiffThreshold,
 	}
 }
 
@@ -254,6 +269,7 @@ func (rg *ReportGenerator) generateTextReportContent() string {
 	report.WriteString(fmt.Sprintf("Total Tests: %d\n", rg.TestResults.TotalTests))
 	report.WriteString(fmt.Sprintf("Passed: %d\n", rg.TestResults.PassedTests))
 	report.WriteString(fmt.Sprintf("Failed: %d\n", rg.TestResults.FailedTests))
+	report.WriteString(fmt.Sprintf("Warnings: %d\n", rg.TestResults.WarnedTests))
 	report.WriteString(fmt.Sprintf("Skipped: %d\n", rg.TestResults.SkippedTests))
 	report.WriteString(fmt.Sprintf("Pass Rate: %.1f%%\n\n", rg.getPassRate()))
 
@@ -279,6 +295,17 @@ func (rg *ReportGenerator) generateTextReportContent() string {
 		report.WriteString("\n")
 	}
 
+	// Warned Tests
+	if rg.TestResults.WarnedTests > 0 {
+		report.WriteString("--- Warnings ---\n")
+		for name, result := range rg.TestResults.TestCases {
+			if result.Passed && result.Warning != "" {
+				report.WriteString(fmt.Sprintf("⚠️ %s: %s\n", name, result.Warning))
+			}
+		}
+		report.WriteString("\n")
+	}
+
 	// Passed Tests
 	if rg.TestResults.PassedTests > 0 {
 		report.WriteString("--- Passed Tests ---\n")
@@ -310,6 +337,13 @@ func (rg *ReportGenerator) generateTextReportContent() string {
 	}
 	report.WriteString("\n")
 
+	// Diff Summary
+	diffs := rg.GetDiffSummary()
+	report.WriteString("--- Diff Summary ---\n")
+	report.WriteString(fmt.Sprintf("Max Diff Ratio: %.2f%%\n", diffs.MaxRatio*100))
+	report.WriteString(fmt.Sprintf("Mean Diff Ratio: %.2f%%\n", diffs.MeanRatio*100))
+	report.WriteString(fmt.Sprintf("Over Threshold (%.2f%%): %d of %d\n\n", diffs.Threshold*100, diffs.OverThreshold, diffs.Cases))
+
 	// Recommendations
 	report.WriteString("--- Recommendations ---\n")
 	recommendations := rg.getRecommendations()
@@ -361,6 +395,40 @@ func (rg *ReportGenerator) getMaxDuration() time.Duration {
 	return max
 }
 
+// getDiffThreshold returns the configured diff threshold or the default
+func (rg *ReportGenerator) getDiffThreshold() float64 {
+	if rg.DiffThreshold > 0 {
+		return rg.DiffThreshold
+	}
+	return defaultDiffThreshold
+}
+
+// GetDiffSummary aggregates the diff ratios of every test case that ran
+func (rg *ReportGenerator) GetDiffSummary() DiffSummary {
+	summary := DiffSummary{Threshold: rg.getDiffThreshold()}
+
+	var total float64
+	for _, result := range rg.TestResults.TestCases {
+		if result.Skipped {
+			continue
+		}
+		summary.Cases++
+		total += result.DiffRatio
+		if result.DiffRatio > summary.MaxRatio {
+			summary.MaxRatio = result.DiffRatio
+		}
+		if result.DiffRatio > summary.Threshold {
+			summary.OverThreshold++
+		}
+	}
+
+	if summary.Cases > 0 {
+		summary.MeanRatio = total / float64(summary.Cases)
+	}
+
+	return summary
+}
+
 // getRecommendations generates recommendations based on test results
 func (rg *ReportGenerator) getRecommendations() []string {
 	var recommendations []string
@@ -379,7 +447,7 @@ func (rg *ReportGenerator) getRecommendations() []string {
 	// Visual diff recommendations
 	hasHighDiffs := false
 	for _, result := range rg.TestResults.TestCases {
-		if !result.Passed && result.DiffRatio > 0.05 {
+		if !result.Passed && result.DiffRatio > rg.getDiffThreshold() {
 			hasHighDiffs = true
 			break
 		}
@@ -0,0 +1,100 @@
+package ui
+
+// maxAnnouncements is how many of the latest announcements are kept
+const maxAnnouncements = 100
+
+// DefaultSpokenLabels returns the words announced for each button value.
+// Values without an entry are announced as they are.
+func DefaultSpokenLabels() map[string]string {
+	return map[string]string{
+		"0":           "zero",
+		"1":           "one",
+		"2":           "two",
+		"3":           "three",
+		"4":           "four",
+		"5":           "five",
+		"6":           "six",
+		"7":           "seven",
+		"8":           "eight",
+		"9":           "nine",
+		".":           "point",
+		"+":           "plus",
+		"-":           "minus",
+		"*":           "multiply",
+		"/":           "divide",
+		"=":           "equals",
+		"clear":       "clear",
+		"clear_entry": "clear entry",
+		"backspace":   "backspace",
+		"negate":      "negate",
+		"reciprocal":  "reciprocal",
+		"square":      "square",
+		"cube":        "cube",
+		"ans":         "answer",
+		"exp":         "exponent",
+		"factorial":   "factorial",
+		"power":       "power",
+	}
+}
+
+// EnableAnnouncements turns screen-reader announcements of activated buttons
+// on or off
+func (m *Model) EnableAnnouncements(enabled bool) {
+	m.announcementsEnabled = enabled
+}
+
+// AnnouncementsEnabled returns whether button activations are announced
+func (m Model) AnnouncementsEnabled() bool {
+	return m.announcementsEnabled
+}
+
+// Announcements returns a copy of the latest announcements, up to
+// maxAnnouncements of them, oldest first
+func (m Model) Announcements() []string {
+	announcements := make([]string, len(m.announcements))
+	copy(announcements, m.announcements)
+	return announcements
+}
+
+// ClearAnnouncements discards all announcements made so far
+func (m *Model) ClearAnnouncements() {
+	m.announcements = nil
+}
+
+// SetSpokenLabels replaces the map from button value to spoken label
+func (m *Model) SetSpokenLabels(labels map[string]string) {
+	m.spokenLabels = make(map[string]string, len(labels))
+	for value, label := range labels {
+		m.spokenLabels[value] = label
+	}
+}
+
+// SetSpokenLabel sets the spoken label of a single button value
+func (m *Model) SetSpokenLabel(value, label string) {
+	labels := make(map[string]string, len(m.spokenLabels)+1)
+	for v, l := range m.spokenLabels {
+		labels[v] = l
+	}
+	labels[value] = label
+	m.spokenLabels = labels
+}
+
+// SpokenLabel returns the label announced for a button value
+func (m Model) SpokenLabel(value string) string {
+	if label, ok := m.spokenLabels[value]; ok {
+		return label
+	}
+	return value
+}
+
+// announceButton adds the spoken label of an activated button to the
+// announcement stream
+func (m *Model) announceButton(value string) {
+	if !m.announcementsEnabled {
+		return
+	}
+	m.announcements = append(m.announcements, m.SpokenLabel(value))
+	if len(m.announcements) > maxAnnouncements { // Keep the latest only
+		m.announcements = m.announcements[len(m.announcements)-maxAnnouncements:]
+	}
+}
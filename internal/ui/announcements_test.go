@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"reflect"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"ccpm-demo/internal/calculator"
+)
+
+func TestButtonAnnouncements(t *testing.T) {
+	updated, _ := NewModel(calculator.NewEngine()).Update(tea.WindowSizeMsg{Width: 80, Height: 40})
+	model := updated.(Model)
+
+	// Announcements are opt-in
+	if quiet, _ := pressButton(t, model, "7"); len(quiet.Announcements()) != 0 {
+		t.Errorf("Expected no announcements by default, got %v", quiet.Announcements())
+	}
+
+	model.EnableAnnouncements(true)
+	model, _ = pressButton(t, model, "7")
+	model = releaseMouse(model)
+	model, _ = pressButton(t, model, "/")
+	model = releaseMouse(model)
+
+	expected := []string{"seven", "divide"}
+	if !reflect.DeepEqual(model.Announcements(), expected) {
+		t.Errorf("Expected announcements %v, got %v", expected, model.Announcements())
+	}
+
+	model.ClearAnnouncements()
+	if len(model.Announcements()) != 0 {
+		t.Errorf("Expected clearing to empty the announcements, got %v", model.Announcements())
+	}
+}
+
+func TestAnnouncementsAreBounded(t *testing.T) {
+	model := NewModel(calculator.NewEngine())
+	model.EnableAnnouncements(true)
+
+	for i := 0; i < maxAnnouncements; i++ {
+		model.announceButton("1")
+	}
+	model.announceButton("2")
+
+	announcements := model.Announcements()
+	if len(announcements) != maxAnnouncements {
+		t.Fatalf("Expected %d announcements to be kept, got %d", maxAnnouncements, len(announcements))
+	}
+	if announcements[len(announcements)-1] != "two" {
+		t.Errorf("Expected the latest announcement to be kept, got '%s'", announcements[len(announcements)-1])
+	}
+}
+
+func TestSpokenLabels(t *testing.T) {
+	model := NewModel(calculator.NewEngine())
+
+	if label := model.SpokenLabel("/"); label != "divide" {
+		t.Errorf("Expected '/' to be spoken as 'divide', got '%s'", label)
+	}
+	if label := model.SpokenLabel("unknown"); label != "unknown" {
+		t.Errorf("Expected values without a label to be spoken as is, got '%s'", label)
+	}
+
+	// Overriding one label leaves copies of the model untouched
+	copied := model
+	model.SetSpokenLabel("/", "divided by")
+	if label := model.SpokenLabel("/"); label != "divided by" {
+		t.Errorf("Expected the custom label 'divided by', got '%s'", label)
+	}
+	if label := copied.SpokenLabel("/"); label != "divide" {
+		t.Errorf("Expected the copy to keep 'divide', got '%s'", label)
+	}
+
+	model.SetSpokenLabels(map[string]string{"+": "and"})
+	if model.SpokenLabel("+") != "and" || model.SpokenLabel("/") != "/" {
+		t.Errorf("Expected the map to be replaced, got '%s' and '%s'", model.SpokenLabel("+"), model.SpokenLabel("/"))
+	}
+}
@@ -0,0 +1,54 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"ccpm-demo/internal/calculator"
+)
+
+func TestAutoClearErrorOnDigit(t *testing.T) {
+	updated, _ := NewModel(calculator.NewEngine()).Update(tea.WindowSizeMsg{Width: 80, Height: 40})
+	model := updated.(Model)
+	if model.GetAutoClearError() {
+		t.Error("Expected auto-clear to be off by default")
+	}
+	model.SetAutoClearError(true)
+
+	model = typeKeys(model, "1/0=")
+	if model.GetError() == "" {
+		t.Fatal("Expected 1/0 to set an error")
+	}
+
+	fresh := typeKeys(model, "5")
+	if fresh.GetError() != "" {
+		t.Errorf("Expected typing a digit to clear the error, got '%s'", fresh.GetError())
+	}
+	if fresh.GetInput() != "5" {
+		t.Errorf("Expected typing a digit to start a fresh input '5', got '%s'", fresh.GetInput())
+	}
+
+	// The number buttons start fresh too
+	pressed, _ := pressButton(t, model, "5")
+	if pressed.GetError() != "" || pressed.GetInput() != "5" {
+		t.Errorf("Expected the 5 button to start fresh, got '%s' with error '%s'", pressed.GetInput(), pressed.GetError())
+	}
+}
+
+func TestAutoClearErrorOff(t *testing.T) {
+	updated, _ := NewModel(calculator.NewEngine()).Update(tea.WindowSizeMsg{Width: 80, Height: 40})
+	model := typeKeys(updated.(Model), "1/0=")
+	errorText := model.GetError()
+	if errorText == "" {
+		t.Fatal("Expected 1/0 to set an error")
+	}
+
+	model = typeKeys(model, "5")
+	if model.GetError() != errorText {
+		t.Errorf("Expected the error '%s' to persist, got '%s'", errorText, model.GetError())
+	}
+	if model.GetInput() == "5" {
+		t.Error("Expected the failed input to be kept")
+	}
+}
@@ -0,0 +1,138 @@
+package ui
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"ccpm-demo/internal/calculator"
+)
+
+// spinnerFrames are drawn in turn while an evaluation is in flight
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// spinnerInterval is how often the spinner advances
+const spinnerInterval = 100 * time.Millisecond
+
+// busyLabel follows the spinner in the output area
+const busyLabel = "Calculating..."
+
+// evaluationDoneMsg carries the outcome of an asynchronous evaluation
+type evaluationDoneMsg struct {
+	generation int
+	expression string
+	result     float64
+	err        error
+}
+
+// spinnerTickMsg advances the spinner of the evaluation it was scheduled for
+type spinnerTickMsg struct {
+	generation int
+}
+
+// busyState tracks an asynchronous evaluation. generation tells the
+// messages of the current evaluation from those of an earlier one, and
+// cancel stops the evaluation running under ctx.
+type busyState struct {
+	async      bool
+	active     bool
+	generation int
+	frame      int
+	ctx        context.Context
+	cancel     context.CancelFunc
+}
+
+// AsyncEvaluation returns whether "=" evaluates off the update loop
+func (m Model) AsyncEvaluation() bool {
+	return m.busy.async
+}
+
+// SetAsyncEvaluation sets whether "=" evaluates off the update loop, showing
+// a spinner until the result arrives, so long evaluations keep the UI responsive
+func (m *Model) SetAsyncEvaluation(enabled bool) {
+	m.busy.async = enabled
+}
+
+// IsBusy returns whether an evaluation is in flight
+func (m Model) IsBusy() bool {
+	return m.busy.active
+}
+
+// SpinnerFrame returns the spinner frame shown while busy
+func (m Model) SpinnerFrame() string {
+	return spinnerFrames[m.busy.frame%len(spinnerFrames)]
+}
+
+// startEvaluation marks an evaluation of expression as in flight and returns
+// the commands that evaluate it and animate the spinner
+func (m *Model) startEvaluation(expression string) tea.Cmd {
+	m.busy.active = true
+	m.busy.generation++
+	m.busy.frame = 0
+	m.busy.ctx, m.busy.cancel = context.WithCancel(context.Background())
+	return tea.Batch(m.evaluateCmd(expression), m.scheduleSpinnerTick())
+}
+
+// cancelEvaluation stops the evaluation in flight, if any. Its result is
+// discarded when it arrives.
+func (m *Model) cancelEvaluation() {
+	if !m.busy.active {
+		return
+	}
+	m.busy.cancel()
+	m.busy.active = false
+}
+
+// evaluateCmd evaluates expression in the background until it completes or
+// the evaluation is cancelled. It uses an engine of its own, so the model's
+// engine is never shared with the update loop.
+func (m Model) evaluateCmd(expression string) tea.Cmd {
+	generation := m.busy.generation
+	ctx := m.busy.ctx
+	engine := calculator.NewEngine()
+	engine.SetRoundingMode(m.engine.GetRoundingMode())
+	return func() tea.Msg {
+		result, err := engine.EvaluateWithContext(ctx, expression)
+		return evaluationDoneMsg{generation: generation, expression: expression, result: result, err: err}
+	}
+}
+
+// scheduleSpinnerTick returns a tick for the current evaluation
+func (m Model) scheduleSpinnerTick() tea.Cmd {
+	generation := m.busy.generation
+	return tea.Tick(spinnerInterval, func(time.Time) tea.Msg {
+		return spinnerTickMsg{generation: generation}
+	})
+}
+
+// handleSpinnerTick advances the spinner while its evaluation is in flight
+func handleSpinnerTick(m Model, msg spinnerTickMsg) (tea.Model, tea.Cmd) {
+	if !m.busy.active || msg.generation != m.busy.generation {
+		return m, nil
+	}
+	m.busy.frame = (m.busy.frame + 1) % len(spinnerFrames)
+	return m, m.scheduleSpinnerTick()
+}
+
+// handleEvaluationDone shows the result of the evaluation in flight and
+// clears the busy state
+func handleEvaluationDone(m Model, msg evaluationDoneMsg) (tea.Model, tea.Cmd) {
+	if !m.busy.active || msg.generation != m.busy.generation {
+		return m, nil
+	}
+	m.busy.active = false
+	m.busy.cancel()
+	return finishEvaluation(m, msg.expression, msg.result, msg.err)
+}
+
+// renderSpinner returns the spinner and busy label, styled with the theme's
+// loader frames
+func (m Model) renderSpinner() string {
+	text := m.SpinnerFrame() + " " + busyLabel
+	loader := m.buttonGrid.GetLoaderStyles()
+	if m.noColor || len(loader) == 0 {
+		return text
+	}
+	return loader[m.busy.frame%len(loader)].Render(text)
+}
@@ -0,0 +1,162 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"ccpm-demo/internal/calculator"
+)
+
+func TestAsyncEvaluationBusyState(t *testing.T) {
+	updated, _ := NewModel(calculator.NewEngine()).Update(tea.WindowSizeMsg{Width: 80, Height: 40})
+	model := updated.(Model)
+	model.SetAsyncEvaluation(true)
+
+	model = typeKeys(model, "12*3=")
+	if !model.IsBusy() {
+		t.Fatal("Expected an in-flight evaluation to set the busy state")
+	}
+	if model.GetOutput() != "" {
+		t.Errorf("Expected no result before the evaluation completes, got '%s'", model.GetOutput())
+	}
+	if !strings.Contains(model.View(), busyLabel) {
+		t.Error("Expected the spinner to be drawn while busy")
+	}
+
+	model = runCmd(model, model.evaluateCmd("12 * 3"))
+	if model.IsBusy() {
+		t.Error("Expected completion to clear the busy state")
+	}
+	if model.GetOutput() != "36" {
+		t.Errorf("Expected the result once the evaluation completes, got '%s'", model.GetOutput())
+	}
+	if strings.Contains(model.View(), busyLabel) {
+		t.Error("Expected the spinner to go once the evaluation completes")
+	}
+
+	// Errors clear the busy state too
+	model = typeKeys(model, "1/0=")
+	model = runCmd(model, model.evaluateCmd("1 / 0"))
+	if model.IsBusy() || model.GetError() == "" {
+		t.Errorf("Expected a failed evaluation to clear the busy state and show the error, got busy %v error '%s'",
+			model.IsBusy(), model.GetError())
+	}
+}
+
+func TestAsyncEvaluationSpinnerFrames(t *testing.T) {
+	model := NewModel(calculator.NewEngine())
+	model.SetAsyncEvaluation(true)
+	model = typeKeys(model, "2+2=")
+
+	first := model.SpinnerFrame()
+	seen := map[string]bool{first: true}
+	for i := 0; i < len(spinnerFrames); i++ {
+		updated, cmd := model.Update(spinnerTickMsg{generation: model.busy.generation})
+		model = updated.(Model)
+		if cmd == nil {
+			t.Fatal("Expected each tick to schedule the next while busy")
+		}
+		seen[model.SpinnerFrame()] = true
+	}
+	if len(seen) != len(spinnerFrames) {
+		t.Errorf("Expected the spinner to show all %d frames, saw %d", len(spinnerFrames), len(seen))
+	}
+	if model.SpinnerFrame() != first {
+		t.Errorf("Expected the spinner to cycle back to '%s', got '%s'", first, model.SpinnerFrame())
+	}
+
+	// A stale tick, or one after completion, stops the animation
+	if _, cmd := model.Update(spinnerTickMsg{generation: model.busy.generation - 1}); cmd != nil {
+		t.Error("Expected a tick from an earlier evaluation to be ignored")
+	}
+	model = runCmd(model, model.evaluateCmd("2 + 2"))
+	if _, cmd := model.Update(spinnerTickMsg{generation: model.busy.generation}); cmd != nil {
+		t.Error("Expected ticks to stop once the evaluation completes")
+	}
+}
+
+func TestNewInputCancelsEvaluation(t *testing.T) {
+	model := NewModel(calculator.NewEngine())
+	model.SetAsyncEvaluation(true)
+	model = typeKeys(model, "12*3=")
+	ctx := model.busy.ctx
+	stale := model.evaluateCmd("12 * 3")
+
+	// Typing while the evaluation runs cancels it and edits the expression
+	model = typeKeys(model, "5")
+	if ctx.Err() != context.Canceled {
+		t.Errorf("Expected a new keystroke to cancel the evaluation's context, got %v", ctx.Err())
+	}
+	if model.IsBusy() {
+		t.Error("Expected a new keystroke to clear the busy state")
+	}
+	if model.GetInput() != "12 * 35" {
+		t.Errorf("Expected the keystroke to be applied, got '%s'", model.GetInput())
+	}
+
+	// The cancelled evaluation's result is discarded
+	model = runCmd(model, stale)
+	if model.GetOutput() != "" || model.GetError() != "" {
+		t.Errorf("Expected the stale result to be discarded, got output '%s' error '%s'",
+			model.GetOutput(), model.GetError())
+	}
+	if len(model.GetHistory()) != 0 {
+		t.Errorf("Expected no history from the stale result, got %v", model.GetHistory())
+	}
+
+	// A result that arrives after a newer evaluation started is discarded too
+	model = typeKeys(model, "=")
+	model = runCmd(model, evaluationDoneCmd(model.busy.generation-1, "12 * 3", 36))
+	if !model.IsBusy() || model.GetOutput() != "" {
+		t.Errorf("Expected an earlier evaluation's result to be ignored, got busy %v output '%s'",
+			model.IsBusy(), model.GetOutput())
+	}
+	model = runCmd(model, model.evaluateCmd("12 * 35"))
+	if model.GetOutput() != "420" {
+		t.Errorf("Expected the current evaluation's result, got '%s'", model.GetOutput())
+	}
+}
+
+func TestButtonPressCancelsEvaluation(t *testing.T) {
+	updated, _ := NewModel(calculator.NewEngine()).Update(tea.WindowSizeMsg{Width: 80, Height: 40})
+	model := updated.(Model)
+	model.SetAsyncEvaluation(true)
+	model = typeKeys(model, "12*3=")
+	ctx := model.busy.ctx
+	stale := model.evaluateCmd("12 * 3")
+
+	// Clicking a button while the evaluation runs cancels it and edits the expression
+	model, _ = pressButton(t, model, "5")
+	model = releaseMouse(model)
+	if ctx.Err() != context.Canceled || model.IsBusy() {
+		t.Errorf("Expected a click to cancel the evaluation, got context error %v busy %v", ctx.Err(), model.IsBusy())
+	}
+	if model.GetInput() != "12 * 35" {
+		t.Errorf("Expected the click to be applied, got '%s'", model.GetInput())
+	}
+
+	// The cancelled evaluation's result does not wipe the edited input
+	model = runCmd(model, stale)
+	if model.GetInput() != "12 * 35" || model.GetOutput() != "" {
+		t.Errorf("Expected the stale result to be discarded, got input '%s' output '%s'", model.GetInput(), model.GetOutput())
+	}
+
+	// So does scrolling through the history with the wheel
+	model = typeKeys(model, "=")
+	ctx = model.busy.ctx
+	updated, _ = model.Update(tea.MouseMsg{Type: tea.MouseWheelUp})
+	model = updated.(Model)
+	if ctx.Err() != context.Canceled || model.IsBusy() {
+		t.Errorf("Expected the wheel to cancel the evaluation, got context error %v busy %v", ctx.Err(), model.IsBusy())
+	}
+}
+
+// evaluationDoneCmd returns a command delivering a finished evaluation
+func evaluationDoneCmd(generation int, expression string, result float64) tea.Cmd {
+	return func() tea.Msg {
+		return evaluationDoneMsg{generation: generation, expression: expression, result: result}
+	}
+}
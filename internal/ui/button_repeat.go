@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// RepeatConfig controls how held input repeats: the first repeat fires after
+// InitialDelay and the following ones every Interval
+type RepeatConfig struct {
+	InitialDelay time.Duration
+	Interval     time.Duration
+}
+
+// DefaultRepeatConfig returns the repeat timing used for held input
+func DefaultRepeatConfig() RepeatConfig {
+	return RepeatConfig{
+		InitialDelay: 500 * time.Millisecond,
+		Interval:     100 * time.Millisecond,
+	}
+}
+
+// digitRepeat holds the state of hold-to-repeat for number buttons
+type digitRepeat struct {
+	enabled bool
+	config  RepeatConfig
+
+	// held is the digit whose button is pressed, empty when none is
+	held string
+
+	// generation invalidates ticks scheduled for an earlier press
+	generation int
+}
+
+// digitRepeatMsg fires while a number button is held
+type digitRepeatMsg struct {
+	generation int
+}
+
+// SetDigitRepeat enables or disables hold-to-repeat for number buttons
+func (m *Model) SetDigitRepeat(enabled bool, config RepeatConfig) {
+	m.stopDigitRepeat()
+	m.repeat.enabled = enabled
+	m.repeat.config = config
+}
+
+// DigitRepeatEnabled returns whether holding a number button repeats it
+func (m Model) DigitRepeatEnabled() bool {
+	return m.repeat.enabled
+}
+
+// startDigitRepeat begins repeating value while its button stays pressed
+func (m *Model) startDigitRepeat(value string) tea.Cmd {
+	if !m.repeat.enabled || !isDigit(value) {
+		return nil
+	}
+
+	m.repeat.generation++
+	m.repeat.held = value
+	return m.scheduleDigitRepeat(m.repeat.config.InitialDelay)
+}
+
+// stopDigitRepeat ends any repeat in progress
+func (m *Model) stopDigitRepeat() {
+	if m.repeat.held == "" {
+		return
+	}
+	m.repeat.generation++
+	m.repeat.held = ""
+}
+
+// scheduleDigitRepeat returns a tick for the current press after delay
+func (m Model) scheduleDigitRepeat(delay time.Duration) tea.Cmd {
+	generation := m.repeat.generation
+	return tea.Tick(delay, func(time.Time) tea.Msg {
+		return digitRepeatMsg{generation: generation}
+	})
+}
+
+// handleDigitRepeatMsg inserts the held digit again and schedules the next repeat
+func handleDigitRepeatMsg(m Model, msg digitRepeatMsg) (tea.Model, tea.Cmd) {
+	if m.repeat.held == "" || msg.generation != m.repeat.generation {
+		return m, nil
+	}
+
+	// Each repeat is new input, like the press that started it
+	m.cancelEvaluation()
+
+	if !m.appendDigit(m.repeat.held) {
+		m.stopDigitRepeat()
+		return m, nil
+	}
+	m.calculatorState.displayValue = m.input
+
+	return m, m.scheduleDigitRepeat(m.repeat.config.Interval)
+}
+
+// isDigit reports whether value is a single number button value
+func isDigit(value string) bool {
+	return len(value) == 1 && value >= "0" && value <= "9"
+}
@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"ccpm-demo/internal/calculator"
+)
+
+// pressButton presses the mouse on the button with the given value
+func pressButton(t *testing.T, m Model, value string) (Model, tea.Cmd) {
+	t.Helper()
+	for buttonID, button := range m.buttonGrid.GetButtons() {
+		if button.GetValue() != value {
+			continue
+		}
+		rect, exists := m.buttonGrid.GetButtonBounds()[buttonID]
+		if !exists {
+			t.Fatalf("Expected bounds to be registered for %s", buttonID)
+		}
+		updated, cmd := m.Update(tea.MouseMsg{X: rect.X, Y: rect.Y, Type: tea.MouseLeft})
+		return updated.(Model), cmd
+	}
+	t.Fatalf("No button with value '%s'", value)
+	return m, nil
+}
+
+// releaseMouse releases the mouse button
+func releaseMouse(m Model) Model {
+	updated, _ := m.Update(tea.MouseMsg{Type: tea.MouseRelease})
+	return updated.(Model)
+}
+
+func TestDigitRepeatWhileHeld(t *testing.T) {
+	config := RepeatConfig{InitialDelay: 5 * time.Millisecond, Interval: time.Millisecond}
+	updated, _ := NewModel(calculator.NewEngine()).Update(tea.WindowSizeMsg{Width: 80, Height: 40})
+	model := updated.(Model)
+	model.SetDigitRepeat(true, config)
+
+	model, cmd := pressButton(t, model, "0")
+	if cmd == nil {
+		t.Fatal("Expected holding '0' to schedule a repeat")
+	}
+
+	// Hold for the initial delay plus two intervals
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		updated, cmd = model.Update(cmd())
+		model = updated.(Model)
+	}
+	if elapsed := time.Since(start); elapsed < config.InitialDelay+2*config.Interval {
+		t.Errorf("Expected repeats to wait for the configured timing, took %v", elapsed)
+	}
+	model = releaseMouse(model)
+
+	if model.GetInput() != "0000" {
+		t.Errorf("Expected holding '0' to insert '0000', got '%s'", model.GetInput())
+	}
+
+	// A tick still in flight after the release does nothing
+	model = runCmd(model, cmd)
+	if model.GetInput() != "0000" {
+		t.Errorf("Expected the release to stop repeating, got '%s'", model.GetInput())
+	}
+}
+
+func TestDigitRepeatShortPress(t *testing.T) {
+	updated, _ := NewModel(calculator.NewEngine()).Update(tea.WindowSizeMsg{Width: 80, Height: 40})
+	model := updated.(Model)
+	model.SetDigitRepeat(true, RepeatConfig{InitialDelay: 5 * time.Millisecond, Interval: time.Millisecond})
+
+	model, cmd := pressButton(t, model, "0")
+	model = releaseMouse(model)
+	model = runCmd(model, cmd)
+
+	if model.GetInput() != "0" {
+		t.Errorf("Expected a short press to insert one '0', got '%s'", model.GetInput())
+	}
+}
+
+func TestDigitRepeatOptIn(t *testing.T) {
+	updated, _ := NewModel(calculator.NewEngine()).Update(tea.WindowSizeMsg{Width: 80, Height: 40})
+	model := updated.(Model)
+	if model.DigitRepeatEnabled() {
+		t.Error("Expected hold-to-repeat to be off by default")
+	}
+
+	if _, cmd := pressButton(t, model, "0"); cmd != nil {
+		t.Error("Expected no repeat to be scheduled when disabled")
+	}
+
+	// Only number buttons repeat
+	model.SetDigitRepeat(true, DefaultRepeatConfig())
+	if _, cmd := pressButton(t, model, "+"); cmd != nil {
+		t.Error("Expected operator buttons not to repeat")
+	}
+}
@@ -0,0 +1,42 @@
+package ui
+
+// ClearMode controls what the C button and key clear
+type ClearMode int
+
+const (
+	// ClearSoft clears the input line only
+	ClearSoft ClearMode = iota
+	// ClearAll also clears the error, the calculation history and the key
+	// counter
+	ClearAll
+)
+
+// String returns the string representation of the clear mode
+func (c ClearMode) String() string {
+	switch c {
+	case ClearAll:
+		return "all"
+	default:
+		return "soft"
+	}
+}
+
+// GetClearMode returns what the C button and key clear
+func (m Model) GetClearMode() ClearMode {
+	return m.clearMode
+}
+
+// SetClearMode sets what the C button and key clear
+func (m *Model) SetClearMode(mode ClearMode) {
+	m.clearMode = mode
+}
+
+// clearAll clears what goes beyond the input line in ClearAll mode
+func (m *Model) clearAll() {
+	if m.clearMode != ClearAll {
+		return
+	}
+	m.error = ""
+	m.ClearHistory()
+	m.keyPresses = 0
+}
@@ -0,0 +1,42 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"ccpm-demo/internal/calculator"
+)
+
+func TestClearMode(t *testing.T) {
+	updated, _ := NewModel(calculator.NewEngine()).Update(tea.WindowSizeMsg{Width: 80, Height: 40})
+	model := typeKeys(updated.(Model), "2+3=4")
+
+	if model.GetClearMode() != ClearSoft {
+		t.Errorf("Expected soft clear by default, got %s", model.GetClearMode())
+	}
+
+	// Soft clear leaves the history intact
+	soft := typeKeys(model, "c")
+	if soft.GetInput() != "" {
+		t.Errorf("Expected C to clear the input, got '%s'", soft.GetInput())
+	}
+	if len(soft.GetHistory()) != 1 {
+		t.Errorf("Expected soft clear to keep the history, got %v", soft.GetHistory())
+	}
+	soft, _ = pressButton(t, model, "clear")
+	if soft.GetInput() != "" || len(soft.GetHistory()) != 1 {
+		t.Errorf("Expected the C button to clear only the input, got '%s' and %v", soft.GetInput(), soft.GetHistory())
+	}
+
+	// Hard clear empties the history too
+	model.SetClearMode(ClearAll)
+	hard := typeKeys(model, "c")
+	if hard.GetInput() != "" || len(hard.GetHistory()) != 0 {
+		t.Errorf("Expected C to clear the input and history, got '%s' and %v", hard.GetInput(), hard.GetHistory())
+	}
+	hard, _ = pressButton(t, model, "clear")
+	if hard.GetInput() != "" || len(hard.GetHistory()) != 0 {
+		t.Errorf("Expected the C button to clear the input and history, got '%s' and %v", hard.GetInput(), hard.GetHistory())
+	}
+}
@@ -0,0 +1,37 @@
+package ui
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+)
+
+// Clipboard defines the interface for copying text out of the calculator
+type Clipboard interface {
+	// WriteText places the given text on the clipboard
+	WriteText(text string) error
+}
+
+// OSC52Clipboard copies text using the OSC 52 terminal escape sequence,
+// which most modern terminals forward to the system clipboard
+type OSC52Clipboard struct {
+	out io.Writer
+}
+
+// NewOSC52Clipboard creates a clipboard that writes OSC 52 sequences to out
+func NewOSC52Clipboard(out io.Writer) *OSC52Clipboard {
+	return &OSC52Clipboard{
+		out: out,
+	}
+}
+
+// WriteText implements Clipboard
+func (c *OSC52Clipboard) WriteText(text string) error {
+	if c.out == nil {
+		return fmt.Errorf("clipboard output is not configured")
+	}
+
+	encoded := base64.StdEncoding.EncodeToString([]byte(text))
+	_, err := fmt.Fprintf(c.out, "\x1b]52;c;%s\a", encoded)
+	return err
+}
@@ -0,0 +1,28 @@
+package ui
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestOSC52ClipboardWriteText(t *testing.T) {
+	var out bytes.Buffer
+	clipboard := NewOSC52Clipboard(&out)
+
+	if err := clipboard.WriteText("1 + 1 = 2\n2 * 3 = 6"); err != nil {
+		t.Fatalf("WriteText returned error: %v", err)
+	}
+
+	expected := "\x1b]52;c;MSArIDEgPSAyCjIgKiAzID0gNg==\a"
+	if out.String() != expected {
+		t.Errorf("Expected OSC 52 sequence %q, got %q", expected, out.String())
+	}
+}
+
+func TestOSC52ClipboardWithoutOutput(t *testing.T) {
+	clipboard := NewOSC52Clipboard(nil)
+
+	if err := clipboard.WriteText("42"); err == nil {
+		t.Error("Expected an error when no output is configured")
+	}
+}
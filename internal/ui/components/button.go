@@ -9,9 +9,10 @@ import (
 
 // Button represents an interactive button component with state management
 type Button struct {
-	stateManager *ButtonStateManager
-	styles       *lipgloss.Style
-	theme        ButtonTheme
+	stateManager   *ButtonStateManager
+	styles         *lipgloss.Style
+	theme          ButtonTheme
+	disabledReason string
 }
 
 // ButtonTheme defines the styling theme for different button types
@@ -95,6 +96,14 @@ func (b *Button) GetPosition() Position {
 	return b.GetConfig().Position
 }
 
+// GetSpan returns the number of grid columns the button covers
+func (b *Button) GetSpan() int {
+	if span := b.GetConfig().Span; span > 1 {
+		return span
+	}
+	return 1
+}
+
 // Focus sets the button to focused state
 func (b *Button) Focus() error {
 	return b.stateManager.Focus()
@@ -117,11 +126,31 @@ func (b *Button) Blur() error {
 
 // Disable sets the button to disabled state
 func (b *Button) Disable() error {
-	return b.stateManager.Disable()
+	return b.DisableWithReason("")
+}
+
+// DisableWithReason sets the button to disabled state, recording why
+func (b *Button) DisableWithReason(reason string) error {
+	if b.stateManager.State() != StateDisabled {
+		if err := b.stateManager.Disable(); err != nil {
+			return err
+		}
+	}
+	b.disabledReason = reason
+	return nil
+}
+
+// DisabledReason returns why the button is disabled, if a reason was given
+func (b *Button) DisabledReason() string {
+	if b.stateManager.State() != StateDisabled {
+		return ""
+	}
+	return b.disabledReason
 }
 
 // Enable returns the button to normal state from disabled
 func (b *Button) Enable() error {
+	b.disabledReason = ""
 	return b.stateManager.Enable()
 }
 
@@ -82,6 +82,10 @@ type ButtonConfig struct {
 
 	// Position represents the button's location in a grid
 	Position Position
+
+	// Span is the number of grid columns the button covers, starting at
+	// Position; 0 counts as 1
+	Span int
 }
 
 // Position represents the grid position of a button
@@ -16,6 +16,7 @@ type FeedbackManager struct {
 	pressDuration   time.Duration
 	transitionSpeed time.Duration
 	focusAnimation  bool
+	maxAnimations   int // 0 means unlimited
 
 	// Visual effects
 	flashEnabled    bool
@@ -26,6 +27,7 @@ type FeedbackManager struct {
 	activeAnimations map[string]*ButtonAnimation
 	flashQueue      []FlashEffect
 	rippleEffects   []RippleEffect
+	animationCount  uint64 // orders animations by when they started
 
 	// Event handlers
 	feedbackHandlers map[string][]func(FeedbackEvent)
@@ -40,8 +42,15 @@ type ButtonAnimation struct {
 	Progress   float64
 	Completed  bool
 	Properties map[string]interface{}
+
+	// sequence orders animations that started at the same instant
+	sequence uint64
 }
 
+// DefaultMaxAnimations is how many button animations may run at once before
+// the oldest is cancelled to make room
+const DefaultMaxAnimations = 8
+
 // AnimationType defines types of button animations
 type AnimationType int
 
@@ -88,6 +97,7 @@ func NewFeedbackManager() *FeedbackManager {
 		pressDuration:     150 * time.Millisecond,
 		transitionSpeed:   100 * time.Millisecond,
 		focusAnimation:    true,
+		maxAnimations:     DefaultMaxAnimations,
 		flashEnabled:      true,
 		flashDuration:     200 * time.Millisecond,
 		rippleEnabled:     false, // Disabled by default for terminal UI
@@ -138,6 +148,25 @@ func (fm *FeedbackManager) WithRipple(enabled bool) *FeedbackManager {
 	return fm
 }
 
+// WithMaxAnimations caps how many button animations run at once. Starting
+// one beyond the cap cancels the oldest; a cap of 0 removes the limit.
+func (fm *FeedbackManager) WithMaxAnimations(limit int) *FeedbackManager {
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+	if limit < 0 {
+		limit = 0
+	}
+	fm.maxAnimations = limit
+	return fm
+}
+
+// GetMaxAnimations returns how many button animations may run at once
+func (fm *FeedbackManager) GetMaxAnimations() int {
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+	return fm.maxAnimations
+}
+
 // TriggerPressAnimation triggers a button press animation
 func (fm *FeedbackManager) TriggerPressAnimation(button *Button) error {
 	if button == nil {
@@ -160,7 +189,7 @@ func (fm *FeedbackManager) TriggerPressAnimation(button *Button) error {
 	}
 
 	key := fm.getAnimationKey(button, AnimPress)
-	fm.activeAnimations[key] = animation
+	fm.startAnimation(key, animation)
 
 	// Trigger feedback event
 	fm.triggerFeedbackEvent(FeedbackEvent{
@@ -200,7 +229,7 @@ func (fm *FeedbackManager) TriggerFocusAnimation(button *Button, focused bool) e
 	}
 
 	key := fm.getAnimationKey(button, animType)
-	fm.activeAnimations[key] = animation
+	fm.startAnimation(key, animation)
 
 	// Trigger feedback event
 	fm.triggerFeedbackEvent(FeedbackEvent{
@@ -406,6 +435,43 @@ func (fm *FeedbackManager) updateRippleEffects(effects []RippleEffect, now time.
 	return activeEffects
 }
 
+// startAnimation adds an animation under key, replacing any already running
+// there. Beyond the cap the oldest animations are cancelled to make room.
+func (fm *FeedbackManager) startAnimation(key string, animation *ButtonAnimation) {
+	fm.animationCount++
+	animation.sequence = fm.animationCount
+
+	if _, replacing := fm.activeAnimations[key]; !replacing && fm.maxAnimations > 0 {
+		for len(fm.activeAnimations) >= fm.maxAnimations {
+			fm.cancelOldestAnimation()
+		}
+	}
+	fm.activeAnimations[key] = animation
+}
+
+// cancelOldestAnimation cancels the animation that started first
+func (fm *FeedbackManager) cancelOldestAnimation() {
+	var oldestKey string
+	var oldest *ButtonAnimation
+	for key, anim := range fm.activeAnimations {
+		if oldest == nil || anim.sequence < oldest.sequence {
+			oldestKey, oldest = key, anim
+		}
+	}
+	if oldest == nil {
+		return
+	}
+
+	oldest.Completed = true
+	fm.triggerFeedbackEvent(FeedbackEvent{
+		Type:      "animation_cancelled",
+		Button:    oldest.Button,
+		Animation: oldest,
+		Timestamp: time.Now(),
+	})
+	delete(fm.activeAnimations, oldestKey)
+}
+
 // getAnimationKey generates a unique key for an animation
 func (fm *FeedbackManager) getAnimationKey(button *Button, animType AnimationType) string {
 	if button == nil {
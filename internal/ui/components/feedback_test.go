@@ -0,0 +1,47 @@
+package components
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeedbackManager_MaxAnimations(t *testing.T) {
+	fm := NewFeedbackManager().WithMaxAnimations(3)
+	assert.Equal(t, 3, fm.GetMaxAnimations())
+
+	var cancelled []*Button
+	fm.RegisterFeedbackHandler("animation_cancelled", func(event FeedbackEvent) {
+		cancelled = append(cancelled, event.Button)
+	})
+
+	buttons := make([]*Button, 4)
+	for i, label := range []string{"1", "2", "3", "4"} {
+		buttons[i] = NewButton(ButtonConfig{Label: label, Type: TypeNumber, Value: label})
+		assert.NoError(t, fm.TriggerPressAnimation(buttons[i]))
+	}
+
+	// The fourth press cancels the first
+	assert.Len(t, fm.GetActiveAnimations(), 3)
+	assert.Equal(t, []*Button{buttons[0]}, cancelled)
+	for _, anim := range fm.GetActiveAnimations() {
+		assert.NotEqual(t, buttons[0], anim.Button)
+	}
+
+	// Pressing a running button again replaces its animation without evicting another
+	assert.NoError(t, fm.TriggerPressAnimation(buttons[3]))
+	assert.Len(t, fm.GetActiveAnimations(), 3)
+	assert.Len(t, cancelled, 1)
+}
+
+func TestFeedbackManager_UnlimitedAnimations(t *testing.T) {
+	fm := NewFeedbackManager()
+	assert.Equal(t, DefaultMaxAnimations, fm.GetMaxAnimations())
+
+	fm.WithMaxAnimations(0)
+	for i := 0; i < DefaultMaxAnimations+2; i++ {
+		button := NewButton(ButtonConfig{Label: "1", Type: TypeNumber, Value: "1"})
+		assert.NoError(t, fm.TriggerPressAnimation(button))
+	}
+	assert.Len(t, fm.GetActiveAnimations(), DefaultMaxAnimations+2)
+}
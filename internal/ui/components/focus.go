@@ -8,6 +8,7 @@ import (
 var (
 	ErrNoFocusableButtons = errors.New("no focusable buttons available")
 	ErrInvalidFocusMove   = errors.New("invalid focus movement")
+	ErrNoButtonOfType     = errors.New("no focusable button of the requested type")
 )
 
 // FocusManager manages focus state and navigation for a collection of buttons
@@ -177,14 +178,15 @@ func (fm *FocusManager) findNextPosition(currentPos Position, direction Directio
 	case DirectionLeft:
 		newPos = Position{Row: currentPos.Row, Column: currentPos.Column - 1}
 	case DirectionRight:
-		newPos = Position{Row: currentPos.Row, Column: currentPos.Column + 1}
+		// Step past every column a spanned button covers
+		newPos = Position{Row: currentPos.Row, Column: currentPos.Column + fm.focusedButton.GetSpan()}
 	default:
 		return Position{}, ErrInvalidFocusMove
 	}
 
-	// Check if new position has a button
-	if _, exists := fm.buttons[newPos]; exists {
-		return newPos, nil
+	// Check if new position has a button, or is covered by a spanned one
+	if pos, exists := fm.findCoveringPosition(newPos); exists {
+		return pos, nil
 	}
 
 	// Handle wrapping based on cycle mode
@@ -196,6 +198,18 @@ func (fm *FocusManager) findNextPosition(currentPos Position, direction Directio
 	return fm.findNearestAvailable(currentPos, direction)
 }
 
+// findCoveringPosition returns the position of the button covering pos: the
+// button there, or a spanned button starting to its left in the same row
+func (fm *FocusManager) findCoveringPosition(pos Position) (Position, bool) {
+	for column := pos.Column; column >= 0; column-- {
+		start := Position{Row: pos.Row, Column: column}
+		if button, exists := fm.buttons[start]; exists {
+			return start, column+button.GetSpan() > pos.Column
+		}
+	}
+	return Position{}, false
+}
+
 // handleWrapping handles focus wrapping based on cycle mode
 func (fm *FocusManager) handleWrapping(currentPos Position, direction Direction) (Position, error) {
 	switch fm.cycleMode {
@@ -511,6 +525,28 @@ func (fm *FocusManager) findLeftmostOverall() (Position, error) {
 	return leftmost, nil
 }
 
+// FocusFirstOfType focuses the first interactive button of the given type in
+// reading order (top row first, then left to right)
+func (fm *FocusManager) FocusFirstOfType(t ButtonType) error {
+	var first Position
+	found := false
+	for pos, button := range fm.buttons {
+		if button.GetType() != t || !button.IsInteractive() {
+			continue
+		}
+		if !found || pos.Row < first.Row || (pos.Row == first.Row && pos.Column < first.Column) {
+			first = pos
+			found = true
+		}
+	}
+
+	if !found {
+		return fmt.Errorf("%w: %s", ErrNoButtonOfType, t)
+	}
+
+	return fm.SetFocus(first.Row, first.Column)
+}
+
 // focusFirstAvailable focuses the first available button in the grid
 func (fm *FocusManager) focusFirstAvailable() error {
 	if len(fm.buttons) == 0 {
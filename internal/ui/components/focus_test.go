@@ -0,0 +1,83 @@
+package components
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFocusManager_FocusFirstOfType(t *testing.T) {
+	newManager := func(t *testing.T, configs []ButtonConfig) *FocusManager {
+		fm := NewFocusManager()
+		for _, config := range configs {
+			require.NoError(t, fm.AddButton(NewButton(config)))
+		}
+		return fm
+	}
+
+	layout := []ButtonConfig{
+		{Label: "C", Type: TypeSpecial, Value: "clear", Position: Position{Row: 0, Column: 0}},
+		{Label: "÷", Type: TypeOperator, Value: "/", Position: Position{Row: 0, Column: 3}},
+		{Label: "7", Type: TypeNumber, Value: "7", Position: Position{Row: 1, Column: 0}},
+		{Label: "×", Type: TypeOperator, Value: "*", Position: Position{Row: 1, Column: 3}},
+		{Label: "0", Type: TypeNumber, Value: "0", Position: Position{Row: 2, Column: 0}},
+	}
+
+	t.Run("focuses the first button of each type in reading order", func(t *testing.T) {
+		fm := newManager(t, layout)
+
+		require.NoError(t, fm.FocusFirstOfType(TypeOperator))
+		assert.Equal(t, "÷", fm.GetFocusedButton().GetLabel())
+
+		require.NoError(t, fm.FocusFirstOfType(TypeNumber))
+		assert.Equal(t, "7", fm.GetFocusedButton().GetLabel())
+
+		require.NoError(t, fm.FocusFirstOfType(TypeSpecial))
+		assert.Equal(t, Position{Row: 0, Column: 0}, fm.GetFocusPosition())
+	})
+
+	t.Run("skips disabled buttons", func(t *testing.T) {
+		fm := newManager(t, layout)
+		require.NoError(t, fm.GetButtonAtPosition(0, 3).Disable())
+
+		require.NoError(t, fm.FocusFirstOfType(TypeOperator))
+		assert.Equal(t, "×", fm.GetFocusedButton().GetLabel())
+	})
+
+	t.Run("errors when no button has the type", func(t *testing.T) {
+		fm := newManager(t, layout[2:3])
+
+		err := fm.FocusFirstOfType(TypeOperator)
+		require.ErrorIs(t, err, ErrNoButtonOfType)
+		assert.Equal(t, "7", fm.GetFocusedButton().GetLabel(), "Focus should stay where it was")
+	})
+}
+
+func TestFocusManager_SpannedButton(t *testing.T) {
+	fm := NewFocusManager().WithWrapping(false)
+	for _, config := range []ButtonConfig{
+		{Label: "1", Type: TypeNumber, Value: "1", Position: Position{Row: 0, Column: 0}},
+		{Label: "2", Type: TypeNumber, Value: "2", Position: Position{Row: 0, Column: 1}},
+		{Label: "3", Type: TypeNumber, Value: "3", Position: Position{Row: 0, Column: 2}},
+		{Label: "0", Type: TypeNumber, Value: "0", Position: Position{Row: 1, Column: 0}, Span: 2},
+		{Label: ".", Type: TypeNumber, Value: ".", Position: Position{Row: 1, Column: 2}},
+	} {
+		require.NoError(t, fm.AddButton(NewButton(config)))
+	}
+	require.NoError(t, fm.SetFocus(1, 0))
+
+	// Right from the zero skips the column it covers
+	require.NoError(t, fm.MoveFocus(DirectionRight))
+	assert.Equal(t, ".", fm.GetFocusedButton().GetLabel())
+
+	// Left lands on the zero as a single target
+	require.NoError(t, fm.MoveFocus(DirectionLeft))
+	assert.Equal(t, "0", fm.GetFocusedButton().GetLabel())
+	assert.Equal(t, Position{Row: 1, Column: 0}, fm.GetFocusPosition())
+
+	// Down from the second column reaches the zero too
+	require.NoError(t, fm.SetFocus(0, 1))
+	require.NoError(t, fm.MoveFocus(DirectionDown))
+	assert.Equal(t, "0", fm.GetFocusedButton().GetLabel())
+}
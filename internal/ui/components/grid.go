@@ -2,6 +2,9 @@ package components
 
 import (
 	"errors"
+	"io"
+	"math"
+	"strings"
 
 	"github.com/charmbracelet/lipgloss"
 )
@@ -12,6 +15,10 @@ var (
 	ErrCellNotFound  = errors.New("cell not found")
 )
 
+// DefaultCellAspectRatio is how many times taller than wide a terminal
+// character cell typically is
+const DefaultCellAspectRatio = 2.0
+
 // GridDimensions defines the size of the grid
 type GridDimensions struct {
 	Columns int
@@ -29,6 +36,18 @@ type GridCell struct {
 	Position GridPosition
 	Content  string
 	Style    lipgloss.Style
+
+	// Span is the number of columns the cell covers, starting at Position;
+	// 0 counts as 1
+	Span int
+}
+
+// columns returns the number of columns the cell covers
+func (c *GridCell) columns() int {
+	if c.Span > 1 {
+		return c.Span
+	}
+	return 1
 }
 
 // GridLayout manages the layout and positioning of grid elements
@@ -42,6 +61,10 @@ type GridLayout struct {
 	minWidth       int
 	maxWidth       int
 	centered       bool
+	offsetX        int
+	offsetY        int
+	borderless     bool
+	cellAspect     float64
 	cells          map[GridPosition]*GridCell
 	renderer       lipgloss.Style
 	borderStyle    lipgloss.Style
@@ -79,13 +102,51 @@ func (g *GridLayout) WithDimensions(cols, rows int) *GridLayout {
 	return g
 }
 
-// WithCellSize sets the cell dimensions
+// WithCellSize sets the cell dimensions. In square-cell mode the height is
+// derived from the width instead.
 func (g *GridLayout) WithCellSize(width, height int) *GridLayout {
 	g.cellWidth = width
 	g.cellHeight = height
+	g.applyCellAspect()
 	return g
 }
 
+// WithSquareCells derives the cell height from the cell width so that
+// bordered cells look roughly square. ratio is how many times taller than wide
+// a terminal character cell is, DefaultCellAspectRatio for most fonts; 0
+// turns the mode off, keeping the current height.
+func (g *GridLayout) WithSquareCells(ratio float64) *GridLayout {
+	if ratio < 0 {
+		ratio = 0
+	}
+	g.cellAspect = ratio
+	g.applyCellAspect()
+	return g
+}
+
+// GetCellAspectRatio returns the character cell ratio square cells are derived
+// with, or 0 when square-cell mode is off
+func (g *GridLayout) GetCellAspectRatio() float64 {
+	return g.cellAspect
+}
+
+// applyCellAspect sets the cell height from the cell width in square-cell
+// mode. A border adds a row above and below and a column either side, so it
+// counts towards both sides of the square; borderless cells have none.
+func (g *GridLayout) applyCellAspect() {
+	if g.cellAspect == 0 {
+		return
+	}
+	height := int(math.Round(float64(g.cellWidth) / g.cellAspect))
+	if !g.borderless {
+		height = int(math.Round(float64(g.cellWidth+2)/g.cellAspect)) - 2
+	}
+	if height < 1 {
+		height = 1
+	}
+	g.cellHeight = height
+}
+
 // WithPadding sets the padding around cells
 func (g *GridLayout) WithPadding(padding int) *GridLayout {
 	g.padding = padding
@@ -117,6 +178,27 @@ func (g *GridLayout) WithCentered(centered bool) *GridLayout {
 	return g
 }
 
+// WithOffset nudges the grid by x columns and y rows on top of any centering.
+// The grid is kept within the maximum width and below the top edge.
+func (g *GridLayout) WithOffset(x, y int) *GridLayout {
+	g.offsetX = x
+	g.offsetY = y
+	return g
+}
+
+// WithBorderless enables/disables borderless rendering, where cells are a single
+// row high and separated by single spaces instead of box characters
+func (g *GridLayout) WithBorderless(borderless bool) *GridLayout {
+	g.borderless = borderless
+	g.applyCellAspect()
+	return g
+}
+
+// IsBorderless returns whether cells are rendered without borders
+func (g *GridLayout) IsBorderless() bool {
+	return g.borderless
+}
+
 // WithBorderStyle sets the border style for cells
 func (g *GridLayout) WithBorderStyle(style lipgloss.Style) *GridLayout {
 	g.borderStyle = style
@@ -137,7 +219,16 @@ func (g *GridLayout) WithPressedStyle(style lipgloss.Style) *GridLayout {
 
 // AddCell adds a cell to the grid
 func (g *GridLayout) AddCell(col, row int, content string, style lipgloss.Style) error {
-	if col < 0 || col >= g.dimensions.Columns {
+	return g.AddSpannedCell(col, row, 1, content, style)
+}
+
+// AddSpannedCell adds a cell covering span columns from col, such as a
+// double-width zero key. The columns it covers must not hold cells of their own.
+func (g *GridLayout) AddSpannedCell(col, row, span int, content string, style lipgloss.Style) error {
+	if span < 1 {
+		span = 1
+	}
+	if col < 0 || col+span > g.dimensions.Columns {
 		return ErrInvalidColumn
 	}
 	if row < 0 || row >= g.dimensions.Rows {
@@ -149,6 +240,7 @@ func (g *GridLayout) AddCell(col, row int, content string, style lipgloss.Style)
 		Position: pos,
 		Content:  content,
 		Style:    style,
+		Span:     span,
 	}
 
 	return nil
@@ -164,6 +256,22 @@ func (g *GridLayout) GetCell(col, row int) (*GridCell, error) {
 	return cell, nil
 }
 
+// GetCoveringCell returns the cell covering a grid position: the cell there,
+// or a spanned cell starting to its left
+func (g *GridLayout) GetCoveringCell(col, row int) (*GridCell, error) {
+	for start := col; start >= 0; start-- {
+		cell, exists := g.cells[GridPosition{Column: start, Row: row}]
+		if !exists {
+			continue
+		}
+		if start+cell.columns() > col {
+			return cell, nil
+		}
+		break
+	}
+	return nil, ErrCellNotFound
+}
+
 // RemoveCell removes a cell from the grid
 func (g *GridLayout) RemoveCell(col, row int) error {
 	pos := GridPosition{Column: col, Row: row}
@@ -219,31 +327,147 @@ func (g *GridLayout) calculateTotalWidth(cellWidth int) int {
 		   (2 * g.padding)
 }
 
-// GetCellPosition returns the screen position for a grid cell
+// GetCellPosition returns the screen position for a grid cell. A column
+// covered by a spanned cell gives the position of that cell.
 func (g *GridLayout) GetCellPosition(col, row int, cellWidth int) (x, y int) {
+	if cell, err := g.GetCoveringCell(col, row); err == nil {
+		col = cell.Position.Column
+	}
+
 	// Calculate horizontal position
 	x = g.padding + (col * (cellWidth + g.spacing))
 
 	// Calculate vertical position
 	y = g.padding + (row * (g.cellHeight + g.spacing))
 
-	// Center horizontally if requested
-	if g.centered {
-		totalWidth := g.calculateTotalWidth(cellWidth)
-		centerX := (g.maxWidth - totalWidth) / 2
-		if centerX > 0 {
-			x += centerX
-		}
+	// Borderless cells sit one space apart with rows stacked directly
+	if g.borderless {
+		x = g.padding + (col * (cellWidth + 1))
+		y = g.padding + row
 	}
 
+	// Center horizontally if requested, then apply the offset
+	x += g.centerShift(cellWidth) + g.clampedOffsetX(cellWidth)
+	y += g.clampedOffsetY()
+
 	return x, y
 }
 
+// SpannedWidth returns the screen width of a cell covering span columns,
+// matching the spacing GetCellPosition places cells with
+func (g *GridLayout) SpannedWidth(cellWidth, span int) int {
+	gap := g.spacing
+	if g.borderless {
+		gap = 1
+	}
+	if span < 1 {
+		span = 1
+	}
+	return span*cellWidth + (span-1)*gap
+}
+
+// renderedSpanWidth returns the content width a cell covering span columns
+// is rendered at, so that it lines up with span single cells: it also takes
+// their inner borders, or the spaces between borderless cells
+func (g *GridLayout) renderedSpanWidth(cellWidth, span int) int {
+	if g.borderless {
+		return span*cellWidth + span - 1
+	}
+	return span*(cellWidth+2) - 2
+}
+
+// centerShift returns how far centering moves the grid right
+func (g *GridLayout) centerShift(cellWidth int) int {
+	if !g.centered {
+		return 0
+	}
+
+	centerX := (g.maxWidth - g.calculateTotalWidth(cellWidth)) / 2
+	if centerX < 0 {
+		return 0
+	}
+	return centerX
+}
+
+// clampedOffsetX returns the horizontal offset, limited so the grid stays
+// between the left edge and the maximum width
+func (g *GridLayout) clampedOffsetX(cellWidth int) int {
+	center := g.centerShift(cellWidth)
+	shift := center + g.offsetX
+
+	if maxShift := g.maxWidth - g.calculateTotalWidth(cellWidth); shift > maxShift {
+		shift = maxShift
+	}
+	if shift < 0 {
+		shift = 0
+	}
+	return shift - center
+}
+
+// clampedOffsetY returns the vertical offset, limited so the grid stays below the top edge
+func (g *GridLayout) clampedOffsetY() int {
+	if g.offsetY < 0 {
+		return 0
+	}
+	return g.offsetY
+}
+
 // Render renders the grid to a string
 func (g *GridLayout) Render(termWidth int) string {
+	var b strings.Builder
+	g.RenderTo(&b, termWidth)
+	return b.String()
+}
+
+// RenderTo renders the grid to w a row at a time, without building the whole
+// grid in memory first. The output is the same as Render's.
+func (g *GridLayout) RenderTo(w io.Writer, termWidth int) error {
 	cellWidth, totalWidth := g.CalculateDimensions(termWidth)
+	rows := g.renderRows(cellWidth)
+	if len(rows) == 0 {
+		rows = []string{""}
+	}
 
-	// Build grid rows
+	// Apply container styling
+	containerStyle := lipgloss.NewStyle().
+		Width(totalWidth).
+		PaddingLeft(g.padding).
+		PaddingRight(g.padding)
+
+	// Nudge the grid by the offset; only rightward and downward offsets can be
+	// shown as margins
+	if offsetX := g.clampedOffsetX(cellWidth); offsetX > 0 {
+		containerStyle = containerStyle.MarginLeft(offsetX)
+	}
+
+	// Each row is styled on its own; the first and last also carry the
+	// padding above and below the grid
+	for i, row := range rows {
+		rowStyle := containerStyle
+		if i == 0 {
+			rowStyle = rowStyle.PaddingTop(g.padding)
+			if offsetY := g.clampedOffsetY(); offsetY > 0 {
+				rowStyle = rowStyle.MarginTop(offsetY)
+			}
+		}
+		if i == len(rows)-1 {
+			rowStyle = rowStyle.PaddingBottom(g.padding)
+		}
+
+		if i > 0 {
+			if _, err := io.WriteString(w, "\n"); err != nil {
+				return err
+			}
+		}
+		if _, err := io.WriteString(w, rowStyle.Render(row)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderRows renders each row of cells
+func (g *GridLayout) renderRows(cellWidth int) []string {
 	var rows []string
 	for row := 0; row < g.dimensions.Rows; row++ {
 		var rowCells []string
@@ -254,10 +478,27 @@ func (g *GridLayout) Render(termWidth int) string {
 			var cellContent string
 			var cellStyle lipgloss.Style
 
+			// A spanned cell is as wide as the cells it covers
+			span := 1
 			if exists {
+				span = cell.columns()
+			}
+			width := g.renderedSpanWidth(cellWidth, span)
+
+			if exists && g.borderless {
+				// Borderless cell; an explicit empty border keeps the
+				// cell's own style from adding one back
 				cellContent = cell.Content
 				cellStyle = cellStyle.
-					Width(cellWidth).
+					Width(width).
+					Height(1).
+					Align(lipgloss.Center, lipgloss.Center).
+					Border(lipgloss.Border{}, false).
+					Inherit(cell.Style)
+			} else if exists {
+				cellContent = cell.Content
+				cellStyle = cellStyle.
+					Width(width).
 					Height(g.cellHeight).
 					Align(lipgloss.Center, lipgloss.Center).
 					Border(lipgloss.RoundedBorder()).
@@ -270,10 +511,17 @@ func (g *GridLayout) Render(termWidth int) string {
 				cellContent = ""
 				cellStyle = lipgloss.NewStyle().
 					Width(cellWidth).
-					Height(g.cellHeight)
+					Height(g.renderedCellHeight())
 			}
 
+			// Borderless cells are separated by single spaces
+			if g.borderless && col > 0 {
+				rowCells = append(rowCells, " ")
+			}
 			rowCells = append(rowCells, cellStyle.Render(cellContent))
+
+			// Skip the columns the cell covers
+			col += span - 1
 		}
 
 		// Join cells in row with spacing
@@ -281,26 +529,26 @@ func (g *GridLayout) Render(termWidth int) string {
 		rows = append(rows, rowString)
 	}
 
-	// Join rows with vertical spacing
-	gridContent := lipgloss.JoinVertical(lipgloss.Left, rows...)
-
-	// Apply container styling
-	containerStyle := lipgloss.NewStyle().
-		Width(totalWidth).
-		Padding(g.padding)
-
-	return containerStyle.Render(gridContent)
+	return rows
 }
 
-// GetCellAtPosition returns the grid cell at the given screen position
+// GetCellAtPosition returns the grid cell at the given screen position. A
+// spanned cell is found across its full width, at the column it starts in.
 func (g *GridLayout) GetCellAtPosition(x, y int, cellWidth int) (col, row int, found bool) {
 	for rowIdx := 0; rowIdx < g.dimensions.Rows; rowIdx++ {
 		for colIdx := 0; colIdx < g.dimensions.Columns; colIdx++ {
+			width := cellWidth
+			if cell, err := g.GetCoveringCell(colIdx, rowIdx); err == nil {
+				if cell.Position.Column != colIdx {
+					continue // Part of a spanned cell already checked
+				}
+				width = g.SpannedWidth(cellWidth, cell.columns())
+			}
 			cellX, cellY := g.GetCellPosition(colIdx, rowIdx, cellWidth)
 
 			// Check if the click/touch is within this cell's bounds
-			if x >= cellX && x < cellX+cellWidth &&
-			   y >= cellY && y < cellY+g.cellHeight {
+			if x >= cellX && x < cellX+width &&
+			   y >= cellY && y < cellY+g.renderedCellHeight() {
 				return colIdx, rowIdx, true
 			}
 		}
@@ -314,6 +562,28 @@ func (g *GridLayout) GetDimensions() GridDimensions {
 	return g.dimensions
 }
 
+// GetCellSize returns the configured cell width and the height cells are rendered at
+func (g *GridLayout) GetCellSize() (width, height int) {
+	return g.cellWidth, g.renderedCellHeight()
+}
+
+// RowWidth returns the columns a full row of cells occupies when rendered,
+// including borders or the spaces between borderless cells
+func (g *GridLayout) RowWidth(cellWidth int) int {
+	if g.borderless {
+		return g.dimensions.Columns*cellWidth + g.dimensions.Columns - 1
+	}
+	return g.dimensions.Columns * (cellWidth + 2)
+}
+
+// renderedCellHeight returns the cell height, a single row when borderless
+func (g *GridLayout) renderedCellHeight() int {
+	if g.borderless {
+		return 1
+	}
+	return g.cellHeight
+}
+
 // GetCellCount returns the number of cells in the grid
 func (g *GridLayout) GetCellCount() int {
 	return len(g.cells)
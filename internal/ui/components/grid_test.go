@@ -1,6 +1,7 @@
 package components
 
 import (
+	"strings"
 	"testing"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/stretchr/testify/assert"
@@ -43,6 +44,46 @@ func TestGridLayout_WithCellSize(t *testing.T) {
 	assert.Equal(t, 4, grid.cellHeight)
 }
 
+func TestGridLayout_WithSquareCells(t *testing.T) {
+	grid := NewGridLayout().WithCellSize(10, 1)
+	modified := grid.WithSquareCells(DefaultCellAspectRatio)
+
+	// A 10-wide cell is 12 columns with its border, so 6 rows tall: 4 inside it
+	assert.Same(t, grid, modified)
+	assert.Equal(t, DefaultCellAspectRatio, grid.GetCellAspectRatio())
+	assert.Equal(t, 10, grid.cellWidth)
+	assert.Equal(t, 4, grid.cellHeight)
+
+	// The height follows later width changes, and the configured ratio
+	grid.WithCellSize(22, 1)
+	assert.Equal(t, 10, grid.cellHeight)
+	grid.WithSquareCells(3)
+	assert.Equal(t, 6, grid.cellHeight)
+
+	// Narrow cells keep at least one row
+	grid.WithCellSize(1, 5)
+	assert.Equal(t, 1, grid.cellHeight)
+
+	// Borderless cells have no border to count: 10 wide is 5 rows at a ratio of 2
+	grid.WithCellSize(10, 1).WithSquareCells(DefaultCellAspectRatio).WithBorderless(true)
+	assert.Equal(t, 5, grid.cellHeight)
+	grid.WithBorderless(false)
+	assert.Equal(t, 4, grid.cellHeight)
+
+	// Turning the mode off leaves the height to WithCellSize
+	grid.WithSquareCells(0)
+	assert.Zero(t, grid.GetCellAspectRatio())
+	grid.WithCellSize(22, 2)
+	assert.Equal(t, 2, grid.cellHeight)
+
+	// Rendered cells take the derived height
+	square := NewGridLayout().WithResponsive(false).WithCellSize(10, 1).WithSquareCells(DefaultCellAspectRatio)
+	require.NoError(t, square.AddCell(0, 0, "7", lipgloss.NewStyle()))
+	_, height := square.GetCellSize()
+	assert.Equal(t, 4, height)
+	assert.Contains(t, square.Render(80), "7")
+}
+
 func TestGridLayout_WithPadding(t *testing.T) {
 	grid := NewGridLayout()
 	modified := grid.WithPadding(2)
@@ -359,6 +400,89 @@ func TestGridLayout_GetCellPosition(t *testing.T) {
 	}
 }
 
+func TestGridLayout_WithOffset(t *testing.T) {
+	cellWidth := 6
+	centered := NewGridLayout()
+	nudged := NewGridLayout().WithOffset(5, 2)
+
+	// Centering still applies: (80 - 29) / 2 = 25 columns plus padding
+	x, _ := nudged.GetCellPosition(0, 0, cellWidth)
+	assert.Equal(t, 1+25+5, x)
+
+	for row := 0; row < 5; row++ {
+		for col := 0; col < 4; col++ {
+			baseX, baseY := centered.GetCellPosition(col, row, cellWidth)
+			gotX, gotY := nudged.GetCellPosition(col, row, cellWidth)
+			assert.Equal(t, baseX+5, gotX, "cell %d,%d x", col, row)
+			assert.Equal(t, baseY+2, gotY, "cell %d,%d y", col, row)
+		}
+	}
+
+	t.Run("clamped to stay on-screen", func(t *testing.T) {
+		grid := NewGridLayout().WithOffset(100, -10)
+		x, y := grid.GetCellPosition(3, 0, cellWidth)
+		assert.Equal(t, 80-1, x+cellWidth, "rightmost cell should end at the maximum width less padding")
+		assert.Equal(t, 1, y, "negative vertical offsets should not move the grid up")
+
+		grid.WithOffset(-100, 0)
+		x, _ = grid.GetCellPosition(0, 0, cellWidth)
+		assert.Equal(t, 1, x, "grid should not move past the left edge")
+	})
+
+	t.Run("render", func(t *testing.T) {
+		grid := NewGridLayout().WithResponsive(false).WithOffset(5, 2)
+		require.NoError(t, grid.AddCell(0, 0, "7", lipgloss.NewStyle()))
+
+		lines := strings.Split(grid.Render(80), "\n")
+		assert.Equal(t, "", strings.TrimSpace(lines[0]))
+		assert.Equal(t, "", strings.TrimSpace(lines[1]))
+		assert.True(t, strings.HasPrefix(lines[2], "     "), "rows should be indented by the offset")
+	})
+}
+
+func TestGridLayout_Borderless(t *testing.T) {
+	newGrid := func(borderless bool) *GridLayout {
+		grid := NewGridLayout().WithBorderless(borderless)
+		labels := [][]string{{"7", "8", "9", "÷"}, {"4", "5", "6", "×"}}
+		for row, rowLabels := range labels {
+			for col, label := range rowLabels {
+				require.NoError(t, grid.AddCell(col, row, label, lipgloss.NewStyle().Border(lipgloss.NormalBorder())))
+			}
+		}
+		return grid
+	}
+
+	bordered := newGrid(false).Render(80)
+	borderless := newGrid(true).Render(80)
+
+	for _, label := range []string{"7", "8", "9", "÷", "4", "5", "6", "×"} {
+		assert.Contains(t, borderless, label)
+	}
+	assert.NotContains(t, borderless, "│", "borderless cells should not be separated by borders")
+	assert.Contains(t, bordered, "│")
+	assert.Less(t, lipgloss.Height(borderless), lipgloss.Height(bordered), "borderless grid should use fewer rows")
+
+	// A borderless row fits in fewer columns, and within the grid's total width
+	cellWidth, totalWidth := newGrid(true).CalculateDimensions(80)
+	assert.Less(t, newGrid(true).RowWidth(cellWidth), newGrid(false).RowWidth(cellWidth))
+	labelRow := strings.Split(borderless, "\n")[1]
+	assert.Equal(t, totalWidth, lipgloss.Width(labelRow), "borderless row should not wrap")
+	assert.Contains(t, labelRow, "9")
+	assert.Contains(t, labelRow, "÷")
+
+	// Positions follow the single-row, single-space layout
+	grid := newGrid(true).WithCentered(false)
+	_, height := grid.GetCellSize()
+	assert.Equal(t, 1, height)
+	x, y := grid.GetCellPosition(2, 1, 6)
+	assert.Equal(t, 1+2*7, x)
+	assert.Equal(t, 1+1, y)
+	col, row, found := grid.GetCellAtPosition(x, y, 6)
+	assert.True(t, found)
+	assert.Equal(t, 2, col)
+	assert.Equal(t, 1, row)
+}
+
 func TestGridLayout_GetCellAtPosition(t *testing.T) {
 	// Test without centering to avoid complex calculations
 	grid := NewGridLayout().WithCentered(false)
@@ -422,6 +546,132 @@ func TestGridLayout_GetCellAtPosition(t *testing.T) {
 	}
 }
 
+func TestGridLayout_SpannedCell(t *testing.T) {
+	newGrid := func() *GridLayout {
+		grid := NewGridLayout().WithCentered(false).WithDimensions(4, 2)
+		style := lipgloss.NewStyle()
+		grid.AddCell(0, 0, "1", style)
+		grid.AddCell(1, 0, "2", style)
+		grid.AddCell(2, 0, "3", style)
+		grid.AddCell(3, 0, "+", style)
+		require.NoError(t, grid.AddSpannedCell(0, 1, 2, "0", style))
+		grid.AddCell(2, 1, ".", style)
+		grid.AddCell(3, 1, "=", style)
+		return grid
+	}
+	cellWidth := 6
+
+	t.Run("rejects a span past the last column", func(t *testing.T) {
+		grid := newGrid()
+		assert.Equal(t, ErrInvalidColumn, grid.AddSpannedCell(3, 0, 2, "x", lipgloss.NewStyle()))
+	})
+
+	t.Run("finds the spanned cell from each column it covers", func(t *testing.T) {
+		grid := newGrid()
+		for col := 0; col < 2; col++ {
+			cell, err := grid.GetCoveringCell(col, 1)
+			require.NoError(t, err)
+			assert.Equal(t, "0", cell.Content)
+		}
+
+		cell, err := grid.GetCoveringCell(2, 1)
+		require.NoError(t, err)
+		assert.Equal(t, ".", cell.Content)
+
+		_, err = grid.GetCell(1, 1)
+		assert.Equal(t, ErrCellNotFound, err, "a covered column holds no cell of its own")
+	})
+
+	t.Run("positions and hit-tests the full width", func(t *testing.T) {
+		grid := newGrid()
+		x0, y0 := grid.GetCellPosition(0, 1, cellWidth)
+		x1, y1 := grid.GetCellPosition(1, 1, cellWidth)
+		assert.Equal(t, x0, x1, "a covered column gives the spanned cell's position")
+		assert.Equal(t, y0, y1)
+
+		// The second column of the zero is part of it, not a gap
+		secondX, _ := grid.GetCellPosition(1, 0, cellWidth)
+		col, row, found := grid.GetCellAtPosition(secondX+1, y0+1, cellWidth)
+		require.True(t, found)
+		assert.Equal(t, 0, col)
+		assert.Equal(t, 1, row)
+
+		// Cells after the span keep their columns
+		dotX, _ := grid.GetCellPosition(2, 1, cellWidth)
+		threeX, _ := grid.GetCellPosition(2, 0, cellWidth)
+		assert.Equal(t, threeX, dotX)
+		col, _, found = grid.GetCellAtPosition(dotX, y0, cellWidth)
+		require.True(t, found)
+		assert.Equal(t, 2, col)
+
+		assert.Equal(t, 2*cellWidth+1, grid.SpannedWidth(cellWidth, 2))
+	})
+}
+
+// TestGridLayout_RenderSpannedAlignment tests that a spanned cell lines up
+// with the cells of the row above at every supported width
+func TestGridLayout_RenderSpannedAlignment(t *testing.T) {
+	// runeIndex returns the column of the first occurrence of substr in line
+	runeIndex := func(line, substr string) int {
+		index := strings.Index(line, substr)
+		if index < 0 {
+			return -1
+		}
+		return len([]rune(line[:index]))
+	}
+	// lineWith returns the first line of the rendered grid containing substr
+	lineWith := func(lines []string, substr string, from int) (string, int) {
+		for i := from; i < len(lines); i++ {
+			if strings.Contains(lines[i], substr) {
+				return lines[i], i
+			}
+		}
+		return "", -1
+	}
+
+	for _, borderless := range []bool{false, true} {
+		for width := 60; width <= 120; width += 5 {
+			grid := NewGridLayout().WithDimensions(4, 2).WithBorderless(borderless)
+			style := lipgloss.NewStyle()
+			grid.AddCell(0, 0, "1", style)
+			grid.AddCell(1, 0, "2", style)
+			grid.AddCell(2, 0, "3", style)
+			grid.AddCell(3, 0, "+", style)
+			require.NoError(t, grid.AddSpannedCell(0, 1, 2, "0", style))
+			grid.AddCell(2, 1, ".", style)
+			grid.AddCell(3, 1, "=", style)
+
+			lines := strings.Split(grid.Render(width), "\n")
+			for i, line := range lines {
+				assert.Equal(t, lipgloss.Width(lines[0]), lipgloss.Width(line),
+					"width %d borderless %v: line %d is misaligned", width, borderless, i)
+			}
+
+			// The cells after the zero sit under the cells of the row above
+			threeLine, threeRow := lineWith(lines, "3", 0)
+			dotLine, _ := lineWith(lines, ".", threeRow+1)
+			assert.Equal(t, runeIndex(threeLine, "3"), runeIndex(dotLine, "."),
+				"width %d borderless %v: '.' is not under '3'", width, borderless)
+			assert.Equal(t, runeIndex(threeLine, "+"), runeIndex(dotLine, "="),
+				"width %d borderless %v: '=' is not under '+'", width, borderless)
+
+			// The zero's box ends where the second column's box does
+			if !borderless {
+				upperTop, upperRow := lineWith(lines, "╭", 0)
+				lowerTop, _ := lineWith(lines, "╭", upperRow+1)
+				upper := []rune(upperTop)
+				lower := []rune(lowerTop)
+				require.Equal(t, len(upper), len(lower))
+				for i := range lower {
+					if lower[i] == '╮' {
+						assert.Equal(t, '╮', upper[i], "width %d: corner at %d has no cell above", width, i)
+					}
+				}
+			}
+		}
+	}
+}
+
 func TestGridLayout_GetDimensions(t *testing.T) {
 	grid := NewGridLayout()
 	dimensions := grid.GetDimensions()
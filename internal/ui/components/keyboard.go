@@ -2,6 +2,7 @@ package components
 
 import (
 	"fmt"
+	"io"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/bubbles/key"
@@ -13,6 +14,30 @@ type KeyboardHandler struct {
 	keyBindings      keyMap
 	shortcuts        map[string]key.Binding
 	shortcutBindings map[string]string
+
+	// Opt-in key-to-action audit log for accessibility reviews
+	auditEnabled bool
+	auditLog     []AuditEntry
+}
+
+// AuditEntry records a single key press and the action it produced
+type AuditEntry struct {
+	Key     string
+	Action  ButtonAction
+	Handled bool
+}
+
+// String returns a human-readable "key -> action" line for the entry
+func (e AuditEntry) String() string {
+	if !e.Handled {
+		return fmt.Sprintf("%s -> (no action)", e.Key)
+	}
+
+	label := ""
+	if e.Action.Button != nil {
+		label = e.Action.Button.GetLabel()
+	}
+	return fmt.Sprintf("%s -> %s %s [%s]", e.Key, e.Action.Type, e.Action.Value, label)
 }
 
 // keyMap defines all keyboard bindings for the button grid
@@ -32,6 +57,11 @@ type keyMap struct {
 	// Special calculator keys
 	escape key.Binding
 	clear  key.Binding
+
+	// Jump to the first button of a group
+	jumpNumber   key.Binding
+	jumpOperator key.Binding
+	jumpSpecial  key.Binding
 }
 
 // NewKeyboardHandler creates a new keyboard handler for button navigation
@@ -97,11 +127,32 @@ func newKeyBindings() keyMap {
 			key.WithKeys("c", "C"),
 			key.WithHelp("C", "clear input"),
 		),
+
+		// Group jumps
+		jumpNumber: key.NewBinding(
+			key.WithKeys("alt+n"),
+			key.WithHelp("Alt+N", "first number"),
+		),
+		jumpOperator: key.NewBinding(
+			key.WithKeys("alt+o"),
+			key.WithHelp("Alt+O", "first operator"),
+		),
+		jumpSpecial: key.NewBinding(
+			key.WithKeys("alt+s"),
+			key.WithHelp("Alt+S", "first special"),
+		),
 	}
 }
 
 // HandleKeyPress processes a keyboard input and returns the action taken
 func (kh *KeyboardHandler) HandleKeyPress(msg tea.KeyMsg) (ButtonAction, bool) {
+	action, handled := kh.handleKeyPress(msg)
+	kh.recordAudit(msg, action, handled)
+	return action, handled
+}
+
+// handleKeyPress dispatches a key press to the matching binding
+func (kh *KeyboardHandler) handleKeyPress(msg tea.KeyMsg) (ButtonAction, bool) {
 	// Check each key binding manually
 	for _, key := range kh.keyBindings.up.Keys() {
 		if kh.matchesKey(msg, key) {
@@ -153,6 +204,21 @@ func (kh *KeyboardHandler) HandleKeyPress(msg tea.KeyMsg) (ButtonAction, bool) {
 			return kh.handleClearKey()
 		}
 	}
+	for _, key := range kh.keyBindings.jumpNumber.Keys() {
+		if kh.matchesKey(msg, key) {
+			return kh.handleGroupJump(TypeNumber)
+		}
+	}
+	for _, key := range kh.keyBindings.jumpOperator.Keys() {
+		if kh.matchesKey(msg, key) {
+			return kh.handleGroupJump(TypeOperator)
+		}
+	}
+	for _, key := range kh.keyBindings.jumpSpecial.Keys() {
+		if kh.matchesKey(msg, key) {
+			return kh.handleGroupJump(TypeSpecial)
+		}
+	}
 
 	// Check for direct number/operator key mappings
 	return kh.handleDirectKeyMapping(msg)
@@ -183,6 +249,19 @@ func (kh *KeyboardHandler) handleNavigation(direction Direction) (ButtonAction,
 	return ButtonAction{}, false
 }
 
+// handleGroupJump moves focus to the first button of the given type
+func (kh *KeyboardHandler) handleGroupJump(buttonType ButtonType) (ButtonAction, bool) {
+	if kh.focusManager == nil {
+		return ButtonAction{}, false
+	}
+
+	if err := kh.focusManager.FocusFirstOfType(buttonType); err != nil {
+		return ButtonAction{}, false
+	}
+
+	return kh.createNavigationAction(fmt.Sprintf("first_%s", buttonType))
+}
+
 // handleActivation processes Enter/Space key presses
 func (kh *KeyboardHandler) handleActivation() (ButtonAction, bool) {
 	if kh.focusManager == nil {
@@ -378,6 +457,9 @@ func (kh *KeyboardHandler) matchesKey(msg tea.KeyMsg, keyStr string) bool {
 		return keyStr == "end"
 	case tea.KeyRunes:
 		if len(msg.Runes) > 0 {
+			if msg.Alt {
+				return "alt+"+string(msg.Runes) == keyStr
+			}
 			return string(msg.Runes) == keyStr
 		}
 	}
@@ -510,6 +592,9 @@ func (kh *KeyboardHandler) GetKeyBindings() []key.Binding {
 		kh.keyBindings.shiftTab,
 		kh.keyBindings.escape,
 		kh.keyBindings.clear,
+		kh.keyBindings.jumpNumber,
+		kh.keyBindings.jumpOperator,
+		kh.keyBindings.jumpSpecial,
 	}
 }
 
@@ -536,6 +621,9 @@ func (kh *KeyboardHandler) GetHelpText() string {
 	help += fmt.Sprintf("  %s\n", kh.keyBindings.shiftTab.Help())
 	help += fmt.Sprintf("  %s\n", kh.keyBindings.escape.Help())
 	help += fmt.Sprintf("  %s\n", kh.keyBindings.clear.Help())
+	help += fmt.Sprintf("  %s\n", kh.keyBindings.jumpNumber.Help())
+	help += fmt.Sprintf("  %s\n", kh.keyBindings.jumpOperator.Help())
+	help += fmt.Sprintf("  %s\n", kh.keyBindings.jumpSpecial.Help())
 
 	// Add special key mappings
 	help += "\nSpecial Key Mappings:\n"
@@ -729,6 +817,7 @@ func (kh *KeyboardHandler) handlePageDownKey() (ButtonAction, bool) {
 func (kh *KeyboardHandler) EnhancedHandleKeyPress(msg tea.KeyMsg) (ButtonAction, bool) {
 	// First try special navigation
 	if action, handled := kh.HandleSpecialNavigation(msg); handled {
+		kh.recordAudit(msg, action, true)
 		return action, true
 	}
 
@@ -736,6 +825,51 @@ func (kh *KeyboardHandler) EnhancedHandleKeyPress(msg tea.KeyMsg) (ButtonAction,
 	return kh.HandleKeyPress(msg)
 }
 
+// EnableAuditLog turns key-to-action audit logging on or off
+func (kh *KeyboardHandler) EnableAuditLog(enabled bool) {
+	kh.auditEnabled = enabled
+}
+
+// IsAuditLogEnabled returns whether audit logging is on
+func (kh *KeyboardHandler) IsAuditLogEnabled() bool {
+	return kh.auditEnabled
+}
+
+// GetAuditLog returns a copy of the recorded audit entries
+func (kh *KeyboardHandler) GetAuditLog() []AuditEntry {
+	entries := make([]AuditEntry, len(kh.auditLog))
+	copy(entries, kh.auditLog)
+	return entries
+}
+
+// ClearAuditLog discards all recorded audit entries
+func (kh *KeyboardHandler) ClearAuditLog() {
+	kh.auditLog = nil
+}
+
+// ExportAuditLog writes the audit log to w, one "key -> action" line per key press
+func (kh *KeyboardHandler) ExportAuditLog(w io.Writer) error {
+	for _, entry := range kh.auditLog {
+		if _, err := fmt.Fprintln(w, entry.String()); err != nil {
+			return fmt.Errorf("failed to export audit log: %w", err)
+		}
+	}
+	return nil
+}
+
+// recordAudit appends a key press to the audit log when logging is enabled
+func (kh *KeyboardHandler) recordAudit(msg tea.KeyMsg, action ButtonAction, handled bool) {
+	if !kh.auditEnabled {
+		return
+	}
+
+	kh.auditLog = append(kh.auditLog, AuditEntry{
+		Key:     msg.String(),
+		Action:  action,
+		Handled: handled,
+	})
+}
+
 // RegisterCalculatorShortcuts registers common calculator keyboard shortcuts
 func (kh *KeyboardHandler) RegisterCalculatorShortcuts() {
 	// Common calculator shortcuts
@@ -802,6 +936,7 @@ func (kh *KeyboardHandler) GetQuickReference() string {
 	ref += "│ Next/Prev       │ Tab/Shift+Tab          │\n"
 	ref += "│ First/Last      │ Home/End               │\n"
 	ref += "│ Page Nav        │ PageUp/PageDown        │\n"
+	ref += "│ Jump to Group   │ Alt+N, Alt+O, Alt+S    │\n"
 	ref += "│ Clear           │ C, Esc, Backspace      │\n"
 	ref += "│ Equals          │ =, Enter               │\n"
 	ref += "│ Multiply        │ *, x, X                │\n"
@@ -0,0 +1,120 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newAuditTestHandler(t *testing.T) *KeyboardHandler {
+	fm := NewFocusManager()
+	buttons := []ButtonConfig{
+		{Label: "7", Type: TypeNumber, Value: "7", Position: Position{Row: 0, Column: 0}},
+		{Label: "8", Type: TypeNumber, Value: "8", Position: Position{Row: 0, Column: 1}},
+		{Label: "+", Type: TypeOperator, Value: "+", Position: Position{Row: 1, Column: 0}},
+		{Label: "=", Type: TypeSpecial, Value: "=", Position: Position{Row: 1, Column: 1}},
+	}
+	for _, config := range buttons {
+		require.NoError(t, fm.AddButton(NewButton(config)))
+	}
+
+	return NewKeyboardHandler(fm)
+}
+
+func TestKeyboardHandler_AuditLog(t *testing.T) {
+	t.Run("disabled by default", func(t *testing.T) {
+		kh := newAuditTestHandler(t)
+
+		kh.HandleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'7'}})
+
+		assert.False(t, kh.IsAuditLogEnabled())
+		assert.Empty(t, kh.GetAuditLog())
+	})
+
+	t.Run("records key to action pairs", func(t *testing.T) {
+		kh := newAuditTestHandler(t)
+		kh.EnableAuditLog(true)
+
+		keys := []tea.KeyMsg{
+			{Type: tea.KeyRunes, Runes: []rune{'8'}},
+			{Type: tea.KeyRunes, Runes: []rune{'+'}},
+			{Type: tea.KeyRight},
+			{Type: tea.KeyRunes, Runes: []rune{'z'}},
+		}
+		for _, msg := range keys {
+			kh.HandleKeyPress(msg)
+		}
+
+		log := kh.GetAuditLog()
+		require.Len(t, log, 4)
+
+		assert.Equal(t, "8", log[0].Key)
+		assert.True(t, log[0].Handled)
+		assert.Equal(t, "activate", log[0].Action.Type)
+		assert.Equal(t, "8", log[0].Action.Value)
+
+		assert.Equal(t, "+", log[1].Key)
+		assert.Equal(t, "+", log[1].Action.Value)
+
+		assert.Equal(t, "right", log[2].Key)
+		assert.Equal(t, "navigate", log[2].Action.Type)
+		assert.Equal(t, "=", log[2].Action.Button.GetLabel())
+
+		assert.Equal(t, "z", log[3].Key)
+		assert.False(t, log[3].Handled)
+	})
+
+	t.Run("exports one line per key", func(t *testing.T) {
+		kh := newAuditTestHandler(t)
+		kh.EnableAuditLog(true)
+
+		kh.HandleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'7'}})
+		kh.HandleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'z'}})
+
+		var out strings.Builder
+		require.NoError(t, kh.ExportAuditLog(&out))
+
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		require.Len(t, lines, 2)
+		assert.Equal(t, "7 -> activate 7 [7]", lines[0])
+		assert.Equal(t, "z -> (no action)", lines[1])
+	})
+
+	t.Run("clear discards entries", func(t *testing.T) {
+		kh := newAuditTestHandler(t)
+		kh.EnableAuditLog(true)
+
+		kh.HandleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'7'}})
+		kh.ClearAuditLog()
+
+		assert.Empty(t, kh.GetAuditLog())
+	})
+}
+
+func TestKeyboardHandler_GroupJumps(t *testing.T) {
+	kh := newAuditTestHandler(t)
+	altKey := func(r rune) tea.KeyMsg {
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}, Alt: true}
+	}
+
+	action, handled := kh.HandleKeyPress(altKey('o'))
+	require.True(t, handled)
+	assert.Equal(t, "navigate", action.Type)
+	assert.Equal(t, "first_operator", action.Value)
+	assert.Equal(t, "+", kh.focusManager.GetFocusedButton().GetLabel())
+
+	_, handled = kh.HandleKeyPress(altKey('s'))
+	require.True(t, handled)
+	assert.Equal(t, "=", kh.focusManager.GetFocusedButton().GetLabel())
+
+	_, handled = kh.HandleKeyPress(altKey('n'))
+	require.True(t, handled)
+	assert.Equal(t, "7", kh.focusManager.GetFocusedButton().GetLabel())
+
+	// Without Alt the letters keep their usual meaning
+	_, handled = kh.HandleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+	assert.False(t, handled)
+}
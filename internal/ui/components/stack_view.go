@@ -0,0 +1,78 @@
+package components
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// defaultStackViewDepth is how many stack values are shown by default
+const defaultStackViewDepth = 4
+
+// StackView displays the top values of an RPN stack. Each value is labelled
+// with its stack level, and the top of the stack is drawn last so that it
+// sits directly above the input line.
+type StackView struct {
+	depth  int
+	values []float64
+	style  lipgloss.Style
+}
+
+// NewStackView creates a stack view showing the default number of values
+func NewStackView() *StackView {
+	return &StackView{
+		depth:  defaultStackViewDepth,
+		values: []float64{},
+		style:  lipgloss.NewStyle().Foreground(lipgloss.Color("8")),
+	}
+}
+
+// WithDepth sets how many of the top stack values are shown
+func (sv *StackView) WithDepth(depth int) *StackView {
+	if depth > 0 {
+		sv.depth = depth
+	}
+	return sv
+}
+
+// WithStyle sets the style used to render each stack line
+func (sv *StackView) WithStyle(style lipgloss.Style) *StackView {
+	sv.style = style
+	return sv
+}
+
+// GetDepth returns how many stack values are shown
+func (sv *StackView) GetDepth() int {
+	return sv.depth
+}
+
+// SetValues replaces the displayed stack, bottom first
+func (sv *StackView) SetValues(values []float64) {
+	sv.values = append([]float64(nil), values...)
+}
+
+// Lines returns the visible stack lines, deepest first, e.g. "2: 3" then "1: 4"
+func (sv *StackView) Lines() []string {
+	visible := sv.values
+	if len(visible) > sv.depth {
+		visible = visible[len(visible)-sv.depth:]
+	}
+
+	lines := make([]string, len(visible))
+	for i, value := range visible {
+		level := len(visible) - i
+		lines[i] = fmt.Sprintf("%d: %s", level, strconv.FormatFloat(value, 'f', -1, 64))
+	}
+	return lines
+}
+
+// Render renders the visible stack lines, or nothing when the stack is empty
+func (sv *StackView) Render() string {
+	lines := sv.Lines()
+	for i, line := range lines {
+		lines[i] = sv.style.Render(line)
+	}
+	return strings.Join(lines, "\n")
+}
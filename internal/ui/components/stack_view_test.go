@@ -0,0 +1,31 @@
+package components
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStackView_Lines(t *testing.T) {
+	view := NewStackView()
+	assert.Empty(t, view.Lines())
+	assert.Equal(t, "", view.Render())
+
+	// The top of the stack is drawn last, at level 1
+	view.SetValues([]float64{3, 4})
+	assert.Equal(t, []string{"2: 3", "1: 4"}, view.Lines())
+
+	view.SetValues([]float64{7})
+	assert.Equal(t, []string{"1: 7"}, view.Lines())
+}
+
+func TestStackView_Depth(t *testing.T) {
+	view := NewStackView().WithDepth(2)
+	view.SetValues([]float64{1, 2, 3.5})
+
+	assert.Equal(t, 2, view.GetDepth())
+	assert.Equal(t, []string{"2: 2", "1: 3.5"}, view.Lines())
+
+	// Non-positive depths are ignored
+	assert.Equal(t, 2, view.WithDepth(0).GetDepth())
+}
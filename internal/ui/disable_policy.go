@@ -0,0 +1,36 @@
+package ui
+
+import "ccpm-demo/internal/ui/components"
+
+// DisablePolicy decides whether a button is disabled for the given input
+// state, and why. It is consulted for every button after each update.
+type DisablePolicy func(state InputState, button *components.Button) (bool, string)
+
+// SetDisablePolicy sets the policy deciding which buttons are disabled and
+// applies it right away. A nil policy enables every button.
+func (m *Model) SetDisablePolicy(policy DisablePolicy) {
+	m.disablePolicy = policy
+	m.applyDisablePolicy()
+}
+
+// applyDisablePolicy disables or enables the grid buttons as the policy
+// decides for the current input state
+func (m *Model) applyDisablePolicy() {
+	if m.buttonGrid == nil {
+		return
+	}
+
+	state := m.InputState()
+	for _, button := range m.buttonGrid.GetButtons() {
+		disabled, reason := false, ""
+		if m.disablePolicy != nil {
+			disabled, reason = m.disablePolicy(state, button)
+		}
+
+		if disabled {
+			button.DisableWithReason(reason)
+		} else {
+			button.Enable()
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"ccpm-demo/internal/calculator"
+	"ccpm-demo/internal/ui/components"
+)
+
+// equalsButton returns the grid's "=" button
+func equalsButton(t *testing.T, m Model) *components.Button {
+	t.Helper()
+	for _, button := range m.buttonGrid.GetButtons() {
+		if button.GetValue() == "=" {
+			return button
+		}
+	}
+	t.Fatal("No '=' button in the grid")
+	return nil
+}
+
+func TestDisablePolicy(t *testing.T) {
+	updated, _ := NewModel(calculator.NewEngine()).Update(tea.WindowSizeMsg{Width: 80, Height: 40})
+	model := updated.(Model)
+
+	const reason = "Finish the expression first"
+	model.SetDisablePolicy(func(state InputState, button *components.Button) (bool, string) {
+		if button.GetValue() == "=" && (state.Input == "" || state.PendingOperator != "") {
+			return true, reason
+		}
+		return false, ""
+	})
+
+	// Disabled while there is nothing to evaluate
+	if equalsButton(t, model).IsInteractive() {
+		t.Error("Expected '=' to be disabled for empty input")
+	}
+
+	model = typeKeys(model, "2+")
+	button := equalsButton(t, model)
+	if button.GetState() != components.StateDisabled {
+		t.Fatalf("Expected '=' to be disabled after '2 +', got %s", button.GetState())
+	}
+	if button.DisabledReason() != reason {
+		t.Errorf("Expected the reason '%s', got '%s'", reason, button.DisabledReason())
+	}
+
+	// Clicking a disabled button does nothing
+	model, _ = pressButton(t, model, "=")
+	if model.GetInput() != "2 + " || model.GetOutput() != "" {
+		t.Errorf("Expected the disabled '=' to be ignored, got input '%s' and output '%s'", model.GetInput(), model.GetOutput())
+	}
+	model = releaseMouse(model)
+
+	// Enabled once the expression is complete
+	model = typeKeys(model, "3")
+	button = equalsButton(t, model)
+	if !button.IsInteractive() || button.DisabledReason() != "" {
+		t.Errorf("Expected '=' to be enabled after '2 + 3', got %s with reason '%s'", button.GetState(), button.DisabledReason())
+	}
+	model, _ = pressButton(t, model, "=")
+	if model.GetOutput() != "5" {
+		t.Errorf("Expected '=' to evaluate to 5, got '%s'", model.GetOutput())
+	}
+
+	// Removing the policy enables every button
+	model = typeKeys(model, "4+")
+	model.SetDisablePolicy(nil)
+	if !equalsButton(t, model).IsInteractive() {
+		t.Error("Expected '=' to be enabled without a policy")
+	}
+}
@@ -0,0 +1,53 @@
+package ui
+
+import "fmt"
+
+// integerDigitsHint is shown in the status bar when a digit is rejected
+const integerDigitsHint = "At most %d digits before the decimal point"
+
+// GetMaxIntegerDigits returns how many integer digits a number may be entered
+// with (0 means unlimited)
+func (m Model) GetMaxIntegerDigits() int {
+	return m.maxIntegerDigits
+}
+
+// SetMaxIntegerDigits caps the integer part of the number being entered, like
+// the fixed entry width of a hardware calculator. Digits after the decimal
+// point and in an exponent are not counted. A limit of 0 disables the cap.
+func (m *Model) SetMaxIntegerDigits(limit int) {
+	if limit < 0 {
+		limit = 0
+	}
+	m.maxIntegerDigits = limit
+}
+
+// appendDigit appends digit to the input unless the integer part of the
+// number being entered is already at the limit, in which case it is rejected
+// with a hint. It reports whether the digit was added.
+func (m *Model) appendDigit(digit string) bool {
+	if m.maxIntegerDigits > 0 && integerDigitsEntered(m.input) >= m.maxIntegerDigits {
+		m.entryHint = fmt.Sprintf(integerDigitsHint, m.maxIntegerDigits)
+		return false
+	}
+
+	m.input += digit
+	m.cursorPosition++
+	return true
+}
+
+// integerDigitsEntered returns how many integer digits the number at the end
+// of input has, or 0 once it has a decimal point or an exponent
+func integerDigitsEntered(input string) int {
+	if inExponent(input) {
+		return 0
+	}
+
+	digits := 0
+	for i := len(input) - 1; i >= 0 && (isDigitByte(input[i]) || input[i] == '.'); i-- {
+		if input[i] == '.' {
+			return 0
+		}
+		digits++
+	}
+	return digits
+}
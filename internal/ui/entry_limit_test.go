@@ -0,0 +1,46 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"ccpm-demo/internal/calculator"
+)
+
+func TestMaxIntegerDigits(t *testing.T) {
+	updated, _ := NewModel(calculator.NewEngine()).Update(tea.WindowSizeMsg{Width: 80, Height: 40})
+	model := updated.(Model)
+	model.SetMaxIntegerDigits(8)
+
+	model = typeKeys(model, "123456789")
+	if model.GetInput() != "12345678" {
+		t.Errorf("Expected the 9th integer digit to be rejected, got '%s'", model.GetInput())
+	}
+	if !strings.Contains(model.statusText(), "8 digits") {
+		t.Errorf("Expected a hint about the digit limit, got '%s'", model.statusText())
+	}
+
+	// Decimal digits are still accepted, and the hint goes with the next change
+	model = typeKeys(model, ".12345")
+	if model.GetInput() != "12345678.12345" {
+		t.Errorf("Expected decimal digits to be accepted, got '%s'", model.GetInput())
+	}
+	if model.statusText() != "" {
+		t.Errorf("Expected the hint to clear once the input changes, got '%s'", model.statusText())
+	}
+
+	// The limit applies to each number separately
+	model = typeKeys(model, "+987654321")
+	if model.GetInput() != "12345678.12345 + 98765432" {
+		t.Errorf("Expected the next number to be capped too, got '%s'", model.GetInput())
+	}
+
+	// Number buttons are capped the same way
+	model.SetInput("11111111")
+	model, _ = pressButton(t, model, "1")
+	if model.GetInput() != "11111111" {
+		t.Errorf("Expected a number button to be rejected at the limit, got '%s'", model.GetInput())
+	}
+}
@@ -0,0 +1,50 @@
+package ui
+
+// EqualsMode controls what the input line holds after "=" is pressed
+type EqualsMode int
+
+const (
+	// EqualsClearInput empties the input line, leaving the result in the display
+	EqualsClearInput EqualsMode = iota
+	// EqualsShowResult replaces the input with the result, ready to continue from
+	EqualsShowResult
+	// EqualsShowExpression shows the full "expr = result" line until the input changes
+	EqualsShowExpression
+)
+
+// String returns the string representation of the equals mode
+func (e EqualsMode) String() string {
+	switch e {
+	case EqualsShowResult:
+		return "result"
+	case EqualsShowExpression:
+		return "expression"
+	default:
+		return "clear"
+	}
+}
+
+// GetEqualsMode returns what the input line holds after "="
+func (m Model) GetEqualsMode() EqualsMode {
+	return m.equalsMode
+}
+
+// SetEqualsMode sets what the input line holds after "="
+func (m *Model) SetEqualsMode(mode EqualsMode) {
+	m.equalsMode = mode
+	m.equation = ""
+}
+
+// applyEqualsMode fills the input line after expression evaluated to the
+// current output
+func (m *Model) applyEqualsMode(expression string) {
+	switch m.equalsMode {
+	case EqualsShowResult:
+		if m.output != digitOverflowIndicator {
+			m.input = m.output
+			m.cursorPosition = len(m.input)
+		}
+	case EqualsShowExpression:
+		m.equation = expression + " = " + m.output
+	}
+}
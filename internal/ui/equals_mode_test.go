@@ -0,0 +1,65 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"ccpm-demo/internal/calculator"
+)
+
+func TestEqualsMode(t *testing.T) {
+	updated, _ := NewModel(calculator.NewEngine()).Update(tea.WindowSizeMsg{Width: 80, Height: 40})
+	model := updated.(Model)
+
+	if model.GetEqualsMode() != EqualsClearInput {
+		t.Errorf("Expected '=' to clear the input by default, got %s", model.GetEqualsMode())
+	}
+
+	tests := []struct {
+		mode     EqualsMode
+		expected string
+	}{
+		{EqualsClearInput, ""},
+		{EqualsShowResult, "36"},
+		{EqualsShowExpression, "12 * 3 = 36"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.mode.String(), func(t *testing.T) {
+			m := model
+			m.SetEqualsMode(test.mode)
+			m = typeKeys(m, "12*3=")
+
+			if line := m.inputLineText(); line != test.expected {
+				t.Errorf("Expected input line '%s' after 12*3=, got '%s'", test.expected, line)
+			}
+			if m.GetOutput() != "36" {
+				t.Errorf("Expected output '36', got '%s'", m.GetOutput())
+			}
+		})
+	}
+}
+
+func TestEqualsModeContinues(t *testing.T) {
+	updated, _ := NewModel(calculator.NewEngine()).Update(tea.WindowSizeMsg{Width: 80, Height: 40})
+	model := updated.(Model)
+
+	// The result is ready to continue from
+	model.SetEqualsMode(EqualsShowResult)
+	result := typeKeys(model, "12*3=+4=")
+	if result.GetOutput() != "40" {
+		t.Errorf("Expected 36+4 to give '40', got '%s'", result.GetOutput())
+	}
+
+	// The expression line goes as soon as something else is typed or cleared
+	model.SetEqualsMode(EqualsShowExpression)
+	typed := typeKeys(model, "12*3=7")
+	if line := typed.inputLineText(); line != "7" {
+		t.Errorf("Expected typing to replace the expression line, got '%s'", line)
+	}
+	cleared := typeKeys(model, "12*3=c")
+	if line := cleared.inputLineText(); line != "" {
+		t.Errorf("Expected clear to remove the expression line, got '%s'", line)
+	}
+}
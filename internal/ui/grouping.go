@@ -0,0 +1,97 @@
+package ui
+
+import "strings"
+
+// thousandsSeparator is inserted between digit groups on the input line
+const thousandsSeparator = ','
+
+// GetInputGrouping returns whether the input line groups integer digits in thousands
+func (m Model) GetInputGrouping() bool {
+	return m.inputGrouping
+}
+
+// SetInputGrouping sets whether the input line groups integer digits in
+// thousands as they are typed. Only the display is affected; the stored input
+// used for evaluation never contains separators.
+func (m *Model) SetInputGrouping(enabled bool) {
+	m.inputGrouping = enabled
+}
+
+// InputDisplayText returns the input as shown on the input line
+func (m Model) InputDisplayText() string {
+	if !m.inputGrouping {
+		return m.input
+	}
+	grouped, _ := groupThousands(m.input, -1)
+	return grouped
+}
+
+// inputLineText returns the input line with the cursor drawn in it
+func (m Model) inputLineText() string {
+	if m.input == "" && m.equation != "" {
+		return m.equation
+	}
+
+	text, cursor := m.input, m.cursorPosition
+	if m.inputGrouping {
+		text, cursor = groupThousands(m.input, m.cursorPosition)
+	}
+
+	if m.cursorPosition >= 0 && m.cursorPosition < len(m.input) {
+		// Show cursor position
+		return text[:cursor] + "█" + text[cursor:]
+	}
+	return text
+}
+
+// groupThousands inserts separators into the integer part of every number in
+// text. Digits after a decimal point or inside a name (e.g. "x10") are left
+// alone. It also returns where the byte offset cursor ends up in the result.
+func groupThousands(text string, cursor int) (string, int) {
+	var grouped strings.Builder
+	groupedCursor := cursor
+
+	for i := 0; i < len(text); {
+		if !isDigitByte(text[i]) {
+			if i == cursor {
+				groupedCursor = grouped.Len()
+			}
+			grouped.WriteByte(text[i])
+			i++
+			continue
+		}
+
+		end := i
+		for end < len(text) && isDigitByte(text[end]) {
+			end++
+		}
+
+		// Only integer parts are grouped
+		integer := i == 0 || !isNumberPrefix(text[i-1])
+		for j := i; j < end; j++ {
+			if integer && j > i && (end-j)%3 == 0 {
+				grouped.WriteByte(thousandsSeparator)
+			}
+			if j == cursor {
+				groupedCursor = grouped.Len()
+			}
+			grouped.WriteByte(text[j])
+		}
+		i = end
+	}
+
+	if cursor >= len(text) {
+		groupedCursor = grouped.Len()
+	}
+	return grouped.String(), groupedCursor
+}
+
+// isNumberPrefix reports whether a digit following b is not part of an integer part
+func isNumberPrefix(b byte) bool {
+	return b == '.' || b == '_' || (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z')
+}
+
+// isDigitByte reports whether b is an ASCII digit
+func isDigitByte(b byte) bool {
+	return b >= '0' && b <= '9'
+}
@@ -0,0 +1,56 @@
+package ui
+
+import "strings"
+
+// HistoryEntryFormat controls how each history entry is shown, both in the
+// history panel and in the rows above the input line
+type HistoryEntryFormat int
+
+const (
+	// HistoryFormatExpression shows the expression and its result, "12 * 3 = 36"
+	HistoryFormatExpression HistoryEntryFormat = iota
+	// HistoryFormatResult shows only the result, "36"
+	HistoryFormatResult
+)
+
+// String returns the string representation of the format
+func (f HistoryEntryFormat) String() string {
+	switch f {
+	case HistoryFormatResult:
+		return "result"
+	default:
+		return "expression"
+	}
+}
+
+// format renders a recorded history entry in this format. Entries without a
+// result are shown as recorded.
+func (f HistoryEntryFormat) format(entry string) string {
+	if f != HistoryFormatResult {
+		return entry
+	}
+	if i := strings.LastIndex(entry, " = "); i >= 0 {
+		return entry[i+len(" = "):]
+	}
+	return entry
+}
+
+// GetHistoryEntryFormat returns how history entries are shown
+func (m Model) GetHistoryEntryFormat() HistoryEntryFormat {
+	return m.historyFormat
+}
+
+// SetHistoryEntryFormat sets how history entries are shown. Entries are
+// always recorded in full, so switching back restores the expressions.
+func (m *Model) SetHistoryEntryFormat(format HistoryEntryFormat) {
+	m.historyFormat = format
+}
+
+// FormattedHistory returns the history entries in the current format, oldest first
+func (m Model) FormattedHistory() []string {
+	entries := make([]string, len(m.history))
+	for i, entry := range m.history {
+		entries[i] = m.historyFormat.format(entry)
+	}
+	return entries
+}
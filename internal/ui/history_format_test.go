@@ -0,0 +1,40 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"ccpm-demo/internal/calculator"
+)
+
+func TestHistoryEntryFormat(t *testing.T) {
+	updated, _ := NewModel(calculator.NewEngine()).Update(tea.WindowSizeMsg{Width: 80, Height: 40})
+	model := typeKeys(updated.(Model), "12*3=")
+
+	if model.GetHistoryEntryFormat() != HistoryFormatExpression {
+		t.Errorf("Expected the expression format by default, got %s", model.GetHistoryEntryFormat())
+	}
+	if got := model.FormattedHistory(); len(got) != 1 || got[0] != "12 * 3 = 36" {
+		t.Fatalf("Expected the entry '12 * 3 = 36', got %v", got)
+	}
+	if !strings.Contains(model.View(), "→ 12 * 3 = 36") {
+		t.Error("Expected the history panel to show the expression and result")
+	}
+
+	model.SetHistoryEntryFormat(HistoryFormatResult)
+	if got := model.FormattedHistory(); got[0] != "36" {
+		t.Errorf("Expected the entry '36', got '%s'", got[0])
+	}
+	view := model.View()
+	if !strings.Contains(view, "→ 36") || strings.Contains(view, "12 * 3 = 36") {
+		t.Error("Expected the history panel to show only the result")
+	}
+
+	// The full entry is kept, so switching back restores it
+	model.SetHistoryEntryFormat(HistoryFormatExpression)
+	if got := model.FormattedHistory(); got[0] != "12 * 3 = 36" {
+		t.Errorf("Expected the expression to be restored, got '%s'", got[0])
+	}
+}
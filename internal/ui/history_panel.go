@@ -0,0 +1,81 @@
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// historyPanelRows is how many history entries the panel below the grid shows
+const historyPanelRows = 5
+
+// ScrollHistoryMsg scrolls the history panel by Delta entries; positive
+// values scroll back towards older entries
+type ScrollHistoryMsg struct {
+	Delta int
+}
+
+// ScrollHistory scrolls the history panel by delta entries, positive towards
+// older entries, stopping at the oldest and newest entries
+func (m *Model) ScrollHistory(delta int) {
+	m.historyOffset += delta
+
+	maxOffset := len(m.history) - historyPanelRows
+	if m.historyOffset > maxOffset {
+		m.historyOffset = maxOffset
+	}
+	if m.historyOffset < 0 {
+		m.historyOffset = 0
+	}
+}
+
+// HistoryOffset returns how many entries the history panel is scrolled back
+func (m Model) HistoryOffset() int {
+	return m.historyOffset
+}
+
+// historyWindow returns the index range of the entries visible in the panel
+func (m Model) historyWindow() (start, end int) {
+	end = len(m.history) - m.historyOffset
+	if end < 0 {
+		end = 0
+	}
+	start = end - historyPanelRows
+	if start < 0 {
+		start = 0
+	}
+	return start, end
+}
+
+// VisibleHistory returns the entries shown in the history panel, oldest first
+func (m Model) VisibleHistory() []string {
+	start, end := m.historyWindow()
+	return m.history[start:end]
+}
+
+// isOverHistoryPanel reports whether screen row y falls on the history panel
+func (m Model) isOverHistoryPanel(y int) bool {
+	if len(m.history) == 0 || m.search.active || m.palette.active || m.preview.active {
+		return false
+	}
+
+	// The panel follows the grid, inside the app border
+	above := m.renderHeader(m.updateStyles()) + m.buttonGrid.Render(m.width)
+	top := 1 + strings.Count(above, "\n") + 1
+	start, end := m.historyWindow()
+	return y >= top && y <= top+(end-start)
+}
+
+// handleHistoryPanelWheel scrolls the history panel if the wheel event is over it
+func handleHistoryPanelWheel(m Model, msg tea.MouseMsg) (Model, bool) {
+	if !m.isOverHistoryPanel(msg.Y) {
+		return m, false
+	}
+
+	if msg.Type == tea.MouseWheelUp {
+		m.ScrollHistory(1)
+	} else {
+		m.ScrollHistory(-1)
+	}
+	return m, true
+}
@@ -0,0 +1,94 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"ccpm-demo/internal/calculator"
+)
+
+// historyPanelRow returns the screen row of the first visible history entry
+func historyPanelRow(t *testing.T, m Model) int {
+	t.Helper()
+	for row, line := range strings.Split(m.View(), "\n") {
+		if strings.Contains(line, "History:") {
+			return row + 1
+		}
+	}
+	t.Fatal("Expected the view to show the history panel")
+	return -1
+}
+
+// wheel sends a mouse wheel event at screen row y
+func wheel(m Model, msgType tea.MouseEventType, y int) Model {
+	updated, _ := m.Update(tea.MouseMsg{X: 5, Y: y, Type: msgType})
+	return updated.(Model)
+}
+
+func TestHistoryPanelWheelScroll(t *testing.T) {
+	updated, _ := NewModel(calculator.NewEngine()).Update(tea.WindowSizeMsg{Width: 80, Height: 60})
+	model := updated.(Model)
+	for i := 1; i <= 8; i++ {
+		model = typeKeys(model, fmt.Sprintf("%d+0=", i))
+	}
+
+	row := historyPanelRow(t, model)
+	if visible := model.VisibleHistory(); visible[0] != model.history[3] {
+		t.Fatalf("Expected the newest entries to be visible, got %v", visible)
+	}
+
+	// Wheel up pages back and stops at the oldest entry
+	for i := 0; i < 5; i++ {
+		model = wheel(model, tea.MouseWheelUp, row)
+	}
+	if model.HistoryOffset() != 3 {
+		t.Errorf("Expected the offset to stop at 3, got %d", model.HistoryOffset())
+	}
+	visible := model.VisibleHistory()
+	if len(visible) != historyPanelRows || visible[0] != model.history[0] {
+		t.Errorf("Expected the oldest entries to be visible, got %v", visible)
+	}
+	if !strings.Contains(model.View(), "1 + 0 = 1") || strings.Contains(model.View(), "8 + 0 = 8") {
+		t.Error("Expected the panel to render the scrolled window")
+	}
+
+	// Wheel down pages forward and stops at the newest entry
+	model = wheel(model, tea.MouseWheelDown, row)
+	if model.HistoryOffset() != 2 {
+		t.Errorf("Expected wheel down to scroll forward one entry, got offset %d", model.HistoryOffset())
+	}
+	for i := 0; i < 5; i++ {
+		model = wheel(model, tea.MouseWheelDown, row)
+	}
+	if model.HistoryOffset() != 0 {
+		t.Errorf("Expected the offset to stop at 0, got %d", model.HistoryOffset())
+	}
+
+	// Away from the panel the wheel keeps navigating the input history
+	model = wheel(model, tea.MouseWheelUp, 0)
+	if model.HistoryOffset() != 0 || model.GetInput() != "7 + 0" {
+		t.Errorf("Expected the wheel off the panel to recall an entry, got offset %d and input '%s'", model.HistoryOffset(), model.GetInput())
+	}
+}
+
+func TestHistoryPanelScrollMsg(t *testing.T) {
+	model := NewModel(calculator.NewEngine())
+	for i := 1; i <= 6; i++ {
+		model = typeKeys(model, fmt.Sprintf("%d+0=", i))
+	}
+
+	updated, _ := model.Update(ScrollHistoryMsg{Delta: 10})
+	model = updated.(Model)
+	if model.HistoryOffset() != 1 {
+		t.Errorf("Expected the offset to be clamped to 1, got %d", model.HistoryOffset())
+	}
+
+	// A new result snaps the panel back to the newest entries
+	model = typeKeys(model, "7+0=")
+	if model.HistoryOffset() != 0 {
+		t.Errorf("Expected a new entry to reset the offset, got %d", model.HistoryOffset())
+	}
+}
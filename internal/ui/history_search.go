@@ -0,0 +1,125 @@
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// historySearchKey opens the history search when there is no operand to divide
+const historySearchKey = "/"
+
+// maxSearchResults is the number of matches shown in the search overlay
+const maxSearchResults = 5
+
+// historySearch holds the state of the history search overlay
+type historySearch struct {
+	active   bool
+	query    string
+	selected int
+}
+
+// OpenHistorySearch shows the history search overlay with an empty query
+func (m *Model) OpenHistorySearch() {
+	m.search = historySearch{active: true}
+}
+
+// CloseHistorySearch hides the history search overlay
+func (m *Model) CloseHistorySearch() {
+	m.search = historySearch{}
+}
+
+// IsHistorySearchActive returns whether the history search overlay is open
+func (m Model) IsHistorySearchActive() bool {
+	return m.search.active
+}
+
+// HistorySearchQuery returns the current search query
+func (m Model) HistorySearchQuery() string {
+	return m.search.query
+}
+
+// HistorySearchMatches returns the history entries containing the query, newest first
+func (m Model) HistorySearchMatches() []string {
+	var matches []string
+	for i := len(m.history) - 1; i >= 0; i-- {
+		if strings.Contains(m.history[i], m.search.query) {
+			matches = append(matches, m.history[i])
+		}
+	}
+	return matches
+}
+
+// SelectedHistoryMatch returns the highlighted match, if any
+func (m Model) SelectedHistoryMatch() (string, bool) {
+	matches := m.HistorySearchMatches()
+	if m.search.selected < 0 || m.search.selected >= len(matches) {
+		return "", false
+	}
+	return matches[m.search.selected], true
+}
+
+// handleHistorySearchKey processes keys while the history search overlay is open
+func handleHistorySearchKey(m Model, msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc, tea.KeyCtrlC:
+		m.CloseHistorySearch()
+
+	case tea.KeyEnter:
+		// Load the expression part of the selected entry into the input line
+		if entry, ok := m.SelectedHistoryMatch(); ok {
+			expression, _, _ := strings.Cut(entry, " = ")
+			m.SetInput(expression)
+		}
+		m.CloseHistorySearch()
+
+	case tea.KeyUp:
+		if m.search.selected > 0 {
+			m.search.selected--
+		}
+
+	case tea.KeyDown:
+		// Only the visible matches can be selected
+		if m.search.selected < min(len(m.HistorySearchMatches()), maxSearchResults)-1 {
+			m.search.selected++
+		}
+
+	case tea.KeyBackspace:
+		if len(m.search.query) > 0 {
+			runes := []rune(m.search.query)
+			m.search.query = string(runes[:len(runes)-1])
+			m.search.selected = 0
+		}
+
+	case tea.KeyRunes, tea.KeySpace:
+		m.search.query += string(msg.Runes)
+		m.search.selected = 0
+	}
+
+	return m, nil
+}
+
+// renderHistorySearch renders the search overlay in place of the history list
+func (m Model) renderHistorySearch(styles styles) string {
+	search := strings.Builder{}
+	search.WriteString("Search history: " + m.search.query + "█\n")
+
+	matches := m.HistorySearchMatches()
+	if len(matches) == 0 {
+		search.WriteString("  (no matches)\n")
+		return search.String()
+	}
+
+	for i, match := range matches {
+		if i >= maxSearchResults {
+			break
+		}
+		prefix := "  "
+		if i == m.search.selected {
+			prefix = "→ "
+		}
+		search.WriteString(prefix + match + "\n")
+	}
+
+	return search.String()
+}
@@ -0,0 +1,116 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"ccpm-demo/internal/calculator"
+)
+
+func newSearchTestModel() Model {
+	model := NewModel(calculator.NewEngine())
+	model.addToHistory("1 + 1 = 2")
+	model.addToHistory("6 * 7 = 42")
+	model.addToHistory("10 + 5 = 15")
+	model.addToHistory("9 / 3 = 3")
+	return model
+}
+
+func TestHistorySearchFilters(t *testing.T) {
+	m := sendKeys(newSearchTestModel(), runeKey('/'), runeKey('+'))
+
+	if !m.IsHistorySearchActive() {
+		t.Fatal("Expected '/' to open the history search")
+	}
+	if m.HistorySearchQuery() != "+" {
+		t.Errorf("Expected query '+', got '%s'", m.HistorySearchQuery())
+	}
+
+	matches := m.HistorySearchMatches()
+	expected := []string{"10 + 5 = 15", "1 + 1 = 2"}
+	if len(matches) != len(expected) {
+		t.Fatalf("Expected %d matches, got %v", len(expected), matches)
+	}
+	for i, match := range matches {
+		if match != expected[i] {
+			t.Errorf("Expected match %d to be '%s', got '%s'", i, expected[i], match)
+		}
+	}
+
+	view := m.View()
+	if !strings.Contains(view, "Search history: +") || strings.Contains(view, "6 * 7") {
+		t.Error("Expected the overlay to show only matching entries")
+	}
+}
+
+func TestHistorySearchSelectPopulatesInput(t *testing.T) {
+	m := sendKeys(newSearchTestModel(),
+		runeKey('/'), runeKey('+'),
+		tea.KeyMsg{Type: tea.KeyDown},
+		tea.KeyMsg{Type: tea.KeyEnter},
+	)
+
+	if m.IsHistorySearchActive() {
+		t.Error("Expected Enter to close the history search")
+	}
+	if m.GetInput() != "1 + 1" {
+		t.Errorf("Expected input '1 + 1', got '%s'", m.GetInput())
+	}
+}
+
+func TestHistorySearchSelectionStaysVisible(t *testing.T) {
+	model := NewModel(calculator.NewEngine())
+	for i := 1; i <= maxSearchResults+2; i++ {
+		model.addToHistory(fmt.Sprintf("%d + 1 = %d", i, i+1))
+	}
+
+	keys := []tea.KeyMsg{runeKey('/'), runeKey('+')}
+	for i := 0; i < maxSearchResults+2; i++ {
+		keys = append(keys, tea.KeyMsg{Type: tea.KeyDown})
+	}
+	m := sendKeys(model, keys...)
+
+	selected, ok := m.SelectedHistoryMatch()
+	if !ok || selected != "3 + 1 = 4" {
+		t.Errorf("Expected the selection to stop at the last visible match '3 + 1 = 4', got '%s'", selected)
+	}
+	if !strings.Contains(m.View(), "→ 3 + 1 = 4") {
+		t.Error("Expected the selected match to be shown in the overlay")
+	}
+
+	m = sendKeys(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.GetInput() != "3 + 1" {
+		t.Errorf("Expected input '3 + 1', got '%s'", m.GetInput())
+	}
+}
+
+func TestHistorySearchEscapeCancels(t *testing.T) {
+	m := sendKeys(newSearchTestModel(), runeKey('/'), runeKey('9'), tea.KeyMsg{Type: tea.KeyEsc})
+
+	if m.IsHistorySearchActive() {
+		t.Error("Expected Esc to close the history search")
+	}
+	if m.quitting {
+		t.Error("Expected Esc to close the search without quitting")
+	}
+	if m.GetInput() != "" {
+		t.Errorf("Expected input to stay empty, got '%s'", m.GetInput())
+	}
+}
+
+func TestHistorySearchSlashStillDivides(t *testing.T) {
+	model := newSearchTestModel()
+	model.SetInput("8")
+
+	m := sendKeys(model, runeKey('/'))
+
+	if m.IsHistorySearchActive() {
+		t.Error("Expected '/' after an operand to divide, not search")
+	}
+	if m.GetInput() != "8 / " {
+		t.Errorf("Expected input '8 / ', got '%s'", m.GetInput())
+	}
+}
@@ -0,0 +1,54 @@
+package ui_test
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"ccpm-demo/internal/calculator"
+	"ccpm-demo/internal/ui"
+	"ccpm-demo/internal/ui/input"
+)
+
+func TestModelHoverHighlightsButtonUnderPointer(t *testing.T) {
+	model := ui.NewModel(calculator.NewEngine())
+	hover := input.NewHoverManager()
+	model.SetHoverTracker(hover)
+
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 80, Height: 40})
+	model = updated.(ui.Model)
+	grid := model.GetButtonGrid()
+
+	moveTo := func(m ui.Model, x, y int) ui.Model {
+		updated, _ := m.Update(tea.MouseMsg{X: x, Y: y, Type: tea.MouseMotion, Action: tea.MouseActionMotion})
+		return updated.(ui.Model)
+	}
+
+	rect, exists := grid.GetButtonBounds()["button_2_1"]
+	if !exists {
+		t.Fatal("Expected bounds to be registered for button_2_1")
+	}
+
+	model = moveTo(model, rect.X, rect.Y)
+	if !hover.IsHovering("button_2_1") {
+		t.Errorf("Expected the hover manager to report button_2_1, got '%s'", hover.GetHoveredElement())
+	}
+	if !grid.IsButtonHovered("button_2_1") || grid.IsButtonHovered("button_2_2") {
+		t.Error("Expected only the button under the pointer to render as hovered")
+	}
+
+	// Moving off the grid clears the highlight
+	model = moveTo(model, 0, 0)
+	if grid.IsButtonHovered("button_2_1") {
+		t.Error("Expected the highlight to clear when the pointer leaves the button")
+	}
+
+	// Hover follows the layout after a resize
+	updated, _ = model.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	model = updated.(ui.Model)
+	rect = grid.GetButtonBounds()["button_2_1"]
+	moveTo(model, rect.X, rect.Y)
+	if !grid.IsButtonHovered("button_2_1") {
+		t.Error("Expected hover to follow the button after resizing")
+	}
+}
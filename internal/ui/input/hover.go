@@ -92,6 +92,16 @@ func (hm *HoverManager) UpdateHover(x, y int, timestamp int64) *HoverState {
 	return hoverState
 }
 
+// RegisterButton registers a button's boundaries for hover hit testing
+func (hm *HoverManager) RegisterButton(id string, x, y, width, height int) {
+	hm.state.RegisterButton(id, x, y, width, height)
+}
+
+// ClearButtons removes all registered button boundaries
+func (hm *HoverManager) ClearButtons() {
+	hm.state.ButtonBounds = make(map[string]ButtonRect)
+}
+
 // IsHovering returns true if an element is currently being hovered
 func (hm *HoverManager) IsHovering(elementID string) bool {
 	return hm.state.HoveredButton == elementID && hm.state.IsHovering
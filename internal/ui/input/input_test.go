@@ -1,6 +1,7 @@
 package input
 
 import (
+	"reflect"
 	"testing"
 	"time"
 
@@ -191,6 +192,49 @@ func TestInputValidator_ExpressionTokenization(t *testing.T) {
 	}
 }
 
+// TestInputValidator_BitwiseTokenization tests tokenizing bitwise operators
+func TestInputValidator_BitwiseTokenization(t *testing.T) {
+	validator := NewInputValidator()
+
+	tokens := validator.tokenizeExpression("1<<4 | 0xF0^^3&1>>2")
+	expected := []string{"1", "<<", "4", "|", "0xF0", "^^", "3", "&", "1", ">>", "2"}
+	if !reflect.DeepEqual(tokens, expected) {
+		t.Errorf("Expected tokens %v, got %v", expected, tokens)
+	}
+
+	result := validator.ValidateExpression("0xF0 | 15 << 1")
+	if !result.IsValid {
+		t.Errorf("Expected a bitwise expression to be valid, got error: %s", result.ErrorMsg)
+	}
+
+	result = validator.ValidateExpression("1 <<")
+	if result.IsValid {
+		t.Error("Expected a bitwise operator at end to be rejected")
+	}
+}
+
+// TestInputValidator_FactorialTokenization tests tokenizing factorials and
+// combinatorics functions
+func TestInputValidator_FactorialTokenization(t *testing.T) {
+	validator := NewInputValidator()
+
+	tokens := validator.tokenizeExpression("nCr(5,2)+3!")
+	expected := []string{"nCr", "(", "5", ",", "2", ")", "+", "3", "!"}
+	if !reflect.DeepEqual(tokens, expected) {
+		t.Errorf("Expected tokens %v, got %v", expected, tokens)
+	}
+
+	for _, expression := range []string{"5!", "nPr(5,2)", "NCR(5, 2) * 2", "(1+2)!"} {
+		if result := validator.ValidateExpression(expression); !result.IsValid {
+			t.Errorf("Expected '%s' to be valid, got error: %s", expression, result.ErrorMsg)
+		}
+	}
+
+	if result := validator.ValidateExpression("nXr(5,2)"); result.IsValid {
+		t.Error("Expected an unknown function to be rejected")
+	}
+}
+
 // TestInputSystem_Initialization tests input system initialization
 func TestInputSystem_Initialization(t *testing.T) {
 	system := NewInputSystem()
@@ -362,6 +406,124 @@ func TestInputSystem_HistoryNavigation(t *testing.T) {
 	}
 }
 
+// TestInputSystem_ClearHistory tests clearing history without touching the current input
+func TestInputSystem_ClearHistory(t *testing.T) {
+	system := NewInputSystem()
+	model := createMockModel()
+
+	system.addToHistory("123 + 456")
+	system.addToHistory("789 - 123")
+	model.SetInput("12 + 3")
+
+	model, _ = system.ProcessMessage(model, ui.ClearHistoryMsg{})
+
+	if len(system.GetHistory()) != 0 {
+		t.Errorf("Expected empty history after clearing, got %d entries", len(system.GetHistory()))
+	}
+	if system.GetCurrentHistoryIndex() != -1 {
+		t.Errorf("Expected history index -1 after clearing, got %d", system.GetCurrentHistoryIndex())
+	}
+	if system.GetCurrentInput() != "12 + 3" {
+		t.Errorf("Expected current input '12 + 3' to be intact, got '%s'", system.GetCurrentInput())
+	}
+	if model.GetInput() != "12 + 3" {
+		t.Errorf("Expected model input '12 + 3' to be intact, got '%s'", model.GetInput())
+	}
+}
+
+// TestInputSystem_InvalidInputFlash tests that rejected input flashes its button
+func TestInputSystem_InvalidInputFlash(t *testing.T) {
+	t.Run("second decimal point flashes and is rejected", func(t *testing.T) {
+		system := NewInputSystem()
+		system.Initialize()
+		model := createMockModel()
+		model.SetInput("1.5")
+
+		model, _ = system.ProcessMessage(model, NumberInputMsg{Value: "."})
+
+		if model.GetInput() != "1.5" {
+			t.Errorf("Expected input '1.5' to be unchanged, got '%s'", model.GetInput())
+		}
+		if model.GetError() == "" {
+			t.Error("Expected an error hint for the second decimal point")
+		}
+
+		flashes := system.GetFeedbackManager().GetActiveFlashEffects()
+		if len(flashes) != 1 {
+			t.Fatalf("Expected 1 flash effect, got %d", len(flashes))
+		}
+		if flashes[0].Button.GetValue() != "." {
+			t.Errorf("Expected the '.' button to flash, got '%s'", flashes[0].Button.GetValue())
+		}
+		if flashes[0].Color != invalidInputFlashColor {
+			t.Errorf("Expected error flash color %s, got %s", invalidInputFlashColor, flashes[0].Color)
+		}
+	})
+
+	t.Run("decimal point in a new number is allowed", func(t *testing.T) {
+		system := NewInputSystem()
+		system.Initialize()
+		model := createMockModel()
+		model.SetInput("1.5 + 2")
+
+		model, _ = system.ProcessMessage(model, NumberInputMsg{Value: "."})
+
+		if model.GetInput() != "1.5 + 2." {
+			t.Errorf("Expected input '1.5 + 2.', got '%s'", model.GetInput())
+		}
+		if len(system.GetFeedbackManager().GetActiveFlashEffects()) != 0 {
+			t.Error("Expected no flash for valid input")
+		}
+	})
+
+	t.Run("reduced motion keeps the hint without flashing", func(t *testing.T) {
+		system := NewInputSystem()
+		system.Initialize()
+		system.SetReducedMotion(true)
+		model := createMockModel()
+		model.SetInput("1.5")
+
+		model, _ = system.ProcessMessage(model, NumberInputMsg{Value: "."})
+
+		if model.GetError() == "" {
+			t.Error("Expected an error hint with reduced motion")
+		}
+		if len(system.GetFeedbackManager().GetActiveFlashEffects()) != 0 {
+			t.Error("Expected no flash with reduced motion")
+		}
+	})
+}
+
+// TestInputSystem_DecimalPlacesCap tests that decimal digits stop at the configured maximum
+func TestInputSystem_DecimalPlacesCap(t *testing.T) {
+	system := NewInputSystem()
+	system.Initialize()
+	system.GetValidator().SetMaxDecimalPlaces(4)
+	model := createMockModel()
+
+	for _, digit := range []string{"1", ".", "1", "2", "3", "4"} {
+		model, _ = system.ProcessMessage(model, NumberInputMsg{Value: digit})
+	}
+	if model.GetInput() != "1.1234" || model.GetError() != "" {
+		t.Fatalf("Expected '1.1234' without error, got '%s' (%s)", model.GetInput(), model.GetError())
+	}
+
+	// The 5th decimal digit is rejected with a hint
+	model, _ = system.ProcessMessage(model, NumberInputMsg{Value: "5"})
+	if model.GetInput() != "1.1234" {
+		t.Errorf("Expected input to stay at '1.1234', got '%s'", model.GetInput())
+	}
+	if model.GetError() != "Too many decimal places (max 4)" {
+		t.Errorf("Expected a decimal places hint, got '%s'", model.GetError())
+	}
+
+	// The cap applies per operand
+	result := system.GetValidator().ValidateNumberInput("1.1234 + 2.5", "6")
+	if !result.IsValid {
+		t.Errorf("Expected the next operand to take decimals, got error: %s", result.ErrorMsg)
+	}
+}
+
 // TestInputSystem_EnabledState tests enabled state management
 func TestInputSystem_EnabledState(t *testing.T) {
 	system := NewInputSystem()
@@ -480,6 +642,43 @@ func TestInputSystem_Reset(t *testing.T) {
 	}
 }
 
+// TestInputSystem_Snapshot tests that the snapshot reflects the processed input and is a copy
+func TestInputSystem_Snapshot(t *testing.T) {
+	system := NewInputSystem()
+	model := createMockModel()
+
+	for _, msg := range []tea.Msg{NumberInputMsg{Value: "1"}, NumberInputMsg{Value: "2"}, NumberInputMsg{Value: "3"}, BackspaceInputMsg{}} {
+		model, _ = system.ProcessMessage(model, msg)
+	}
+
+	snapshot := system.Snapshot()
+	expected := ui.InputState{Input: "12", Cursor: 2, Mode: ui.InputModeInfix}
+	if snapshot != expected {
+		t.Fatalf("Expected snapshot %+v, got %+v", expected, snapshot)
+	}
+
+	// Mutating the snapshot leaves the system alone
+	snapshot.Input = "999"
+	snapshot.Mode = ui.InputModeRPN
+	if system.GetCurrentInput() != "12" || system.Snapshot() != expected {
+		t.Errorf("Expected the system to be unaffected, got %+v", system.Snapshot())
+	}
+
+	// RPN results become the last result
+	system.SetRPNMode(true)
+	processRPNKeys(system, createMockModel(), "3", "Enter", "4", "+")
+	snapshot = system.Snapshot()
+	if snapshot.Mode != ui.InputModeRPN || snapshot.LastResult != "7" || snapshot.Input != "" {
+		t.Errorf("Expected RPN mode with last result 7, got %+v", snapshot)
+	}
+
+	// A pending operator is read from the input
+	state := ui.NewInputState("12 + ", 5, "", ui.InputModeInfix)
+	if state.PendingOperator != "+" {
+		t.Errorf("Expected the pending operator '+', got '%s'", state.PendingOperator)
+	}
+}
+
 // TestInputSystem_Integration tests the full integration with model
 func TestInputSystem_Integration(t *testing.T) {
 	system := NewInputSystem()
@@ -4,10 +4,15 @@ import (
 	"fmt"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 
 	"ccpm-demo/internal/ui"
+	"ccpm-demo/internal/ui/components"
 )
 
+// invalidInputFlashColor is the flash color shown on a button whose input was rejected
+const invalidInputFlashColor = lipgloss.Color("196")
+
 // InputSystem integrates all input components into a unified system
 type InputSystem struct {
 	router       *EventRouter
@@ -15,11 +20,30 @@ type InputSystem struct {
 	isEnabled    bool
 	isProcessing bool
 
+	// Validation feedback
+	feedback      *components.FeedbackManager
+	reducedMotion bool
+
+	// Whether operators are spaced as they are entered
+	autoSpace bool
+
 	// Integration state
 	currentInput string
+	cursor       int
+	lastResult   string
 	errorState   string
 	history      []string
 	historyIndex int
+
+	// Reverse Polish Notation state
+	rpnMode        bool
+	rpnEqualsLabel string
+	stack          []float64
+
+	// Prefix notation state: the operators still waiting for operands,
+	// innermost last
+	prefixMode    bool
+	prefixPending []prefixFrame
 }
 
 // NewInputSystem creates a new integrated input system
@@ -27,12 +51,17 @@ func NewInputSystem() *InputSystem {
 	system := &InputSystem{
 		router:       NewEventRouter(),
 		validator:    NewInputValidator(),
+		feedback:     components.NewFeedbackManager(),
 		isEnabled:    true,
 		isProcessing: true,
 		currentInput: "",
 		errorState:   "",
 		history:      []string{},
 		historyIndex: -1,
+		autoSpace:    true,
+
+		rpnEqualsLabel: defaultRPNEqualsLabel,
+		stack:          []float64{},
 	}
 
 	// Register the validator with the router
@@ -76,13 +105,27 @@ func (is *InputSystem) ProcessMessage(model ui.Model, msg tea.Msg) (ui.Model, te
 	case NumberInputMsg:
 		model, err = is.handleNumberInput(model, m.Value)
 	case OperatorInputMsg:
-		model, err = is.handleOperatorInput(model, m.Operator)
+		if is.rpnMode {
+			model, err = is.handleRPNOperator(model, m.Operator)
+		} else if is.prefixMode {
+			model, err = is.handlePrefixOperator(model, m.Operator)
+		} else {
+			model, err = is.handleOperatorInput(model, m.Operator)
+		}
 	case EqualsInputMsg:
-		model, err = is.handleEqualsInput(model)
+		if is.rpnMode {
+			model, err = is.handleRPNEnter(model)
+		} else if is.prefixMode {
+			model, err = is.handlePrefixOperand(model)
+		} else {
+			model, err = is.handleEqualsInput(model)
+		}
 	case ClearInputMsg:
 		model, err = is.handleClearInput(model)
 	case BackspaceInputMsg:
 		model, err = is.handleBackspaceInput(model)
+	case ui.ClearHistoryMsg:
+		model, err = is.handleClearHistory(model)
 	}
 
 	// Update error state if there was an error
@@ -96,15 +139,36 @@ func (is *InputSystem) ProcessMessage(model ui.Model, msg tea.Msg) (ui.Model, te
 
 	// Update current input state
 	is.currentInput = model.GetInput()
+	is.cursor = model.GetCursorPosition()
+	is.lastResult = model.GetOutput()
+
+	// Let the model show the stack as it now stands
+	if is.rpnMode {
+		updated, _ := model.Update(ui.RPNStackChangedMsg{})
+		model = updated.(ui.Model)
+	}
 
 	return model, command
 }
 
+// Snapshot returns a copy of the current input context, as of the last
+// processed message
+func (is *InputSystem) Snapshot() ui.InputState {
+	mode := ui.InputModeInfix
+	if is.rpnMode {
+		mode = ui.InputModeRPN
+	} else if is.prefixMode {
+		mode = ui.InputModePrefix
+	}
+	return ui.NewInputState(is.currentInput, is.cursor, is.lastResult, mode)
+}
+
 // handleNumberInput handles number input from both keyboard and mouse
 func (is *InputSystem) handleNumberInput(model ui.Model, value string) (ui.Model, error) {
 	// Validate the number input
 	result := is.validator.ValidateNumberInput(is.currentInput, value)
 	if !result.IsValid {
+		is.flashInvalidInput(model, value)
 		return model, fmt.Errorf(result.ErrorMsg)
 	}
 
@@ -118,7 +182,7 @@ func (is *InputSystem) handleNumberInput(model ui.Model, value string) (ui.Model
 	}
 
 	// Update the model with the new input
-	model.SetInput(expressionResult.Sanitized)
+	model.SetInput(is.formatInput(expressionResult.Sanitized))
 	return model, nil
 }
 
@@ -126,6 +190,7 @@ func (is *InputSystem) handleNumberInput(model ui.Model, value string) (ui.Model
 func (is *InputSystem) handleOperatorInput(model ui.Model, operator string) (ui.Model, error) {
 	// Validate the operator
 	if !is.validator.validateOperatorInput(operator) {
+		is.flashInvalidInput(model, operator)
 		return model, fmt.Errorf(is.validator.GetValidationError())
 	}
 
@@ -136,20 +201,25 @@ func (is *InputSystem) handleOperatorInput(model ui.Model, operator string) (ui.
 			model.SetInput("-")
 			return model, nil
 		}
+		is.flashInvalidInput(model, operator)
 		return model, fmt.Errorf("Cannot start with operator")
 	}
 
-	// Add operator with proper spacing
-	newInput := is.currentInput + " " + operator + " "
-
-	// Validate the complete expression
-	expressionResult := is.validator.ValidateExpression(newInput)
+	// The expression before the operator must be complete; the operator
+	// itself waits for the operand typed next
+	expressionResult := is.validator.ValidateExpression(is.currentInput)
 	if !expressionResult.IsValid {
 		return model, fmt.Errorf(expressionResult.ErrorMsg)
 	}
 
+	// Add the operator, spaced unless auto-spacing is off
+	newInput := expressionResult.Sanitized + operator
+	if is.autoSpace {
+		newInput = expressionResult.Sanitized + " " + operator + " "
+	}
+
 	// Update the model
-	model.SetInput(expressionResult.Sanitized)
+	model.SetInput(newInput)
 	return model, nil
 }
 
@@ -184,6 +254,7 @@ func (is *InputSystem) handleClearInput(model ui.Model) (ui.Model, error) {
 	model.SetOutput("")
 	is.currentInput = ""
 	is.errorState = ""
+	is.stack = []float64{}
 	return model, nil
 }
 
@@ -207,6 +278,32 @@ func (is *InputSystem) handleBackspaceInput(model ui.Model) (ui.Model, error) {
 	return model, nil
 }
 
+// handleClearHistory empties the history while leaving the current input intact
+func (is *InputSystem) handleClearHistory(model ui.Model) (ui.Model, error) {
+	is.ClearHistory()
+	model.ClearHistory()
+	return model, nil
+}
+
+// flashInvalidInput flashes the button for a rejected value, unless reduced motion is on
+func (is *InputSystem) flashInvalidInput(model ui.Model, value string) {
+	if is.reducedMotion || is.feedback == nil {
+		return
+	}
+
+	buttonGrid := model.GetButtonGrid()
+	if buttonGrid == nil {
+		return
+	}
+
+	for _, button := range buttonGrid.GetButtons() {
+		if button.GetValue() == value {
+			is.feedback.TriggerFlashEffect(button, invalidInputFlashColor)
+			return
+		}
+	}
+}
+
 // addToHistory adds an expression to the history
 func (is *InputSystem) addToHistory(expression string) {
 	is.history = append(is.history, expression)
@@ -216,6 +313,12 @@ func (is *InputSystem) addToHistory(expression string) {
 	is.historyIndex = len(is.history) - 1
 }
 
+// ClearHistory empties the input history
+func (is *InputSystem) ClearHistory() {
+	is.history = []string{}
+	is.historyIndex = -1
+}
+
 // GetHistory returns the input history
 func (is *InputSystem) GetHistory() []string {
 	return is.history
@@ -290,6 +393,26 @@ func (is *InputSystem) ClearError() {
 	is.errorState = ""
 }
 
+// SetFeedbackManager sets the feedback manager used to flash rejected input
+func (is *InputSystem) SetFeedbackManager(feedback *components.FeedbackManager) {
+	is.feedback = feedback
+}
+
+// GetFeedbackManager returns the feedback manager used to flash rejected input
+func (is *InputSystem) GetFeedbackManager() *components.FeedbackManager {
+	return is.feedback
+}
+
+// SetReducedMotion disables the flash on rejected input; the error hint is still shown
+func (is *InputSystem) SetReducedMotion(reduced bool) {
+	is.reducedMotion = reduced
+}
+
+// IsReducedMotion returns whether reduced motion is enabled
+func (is *InputSystem) IsReducedMotion() bool {
+	return is.reducedMotion
+}
+
 // GetRouter returns the event router
 func (is *InputSystem) GetRouter() *EventRouter {
 	return is.router
@@ -362,9 +485,13 @@ func (is *InputSystem) RemoveCustomValidator(validator *InputValidator) {
 // Reset resets the input system to its initial state
 func (is *InputSystem) Reset() {
 	is.currentInput = ""
+	is.cursor = 0
+	is.lastResult = ""
 	is.errorState = ""
 	is.history = []string{}
 	is.historyIndex = -1
+	is.stack = []float64{}
+	is.prefixPending = nil
 	is.router.ClearEventQueue()
 	is.router.GetMouseHandler().Reset()
 }
@@ -378,6 +505,10 @@ func (is *InputSystem) GetSystemState() map[string]interface{} {
 		"errorState":    is.errorState,
 		"historyCount":  len(is.history),
 		"historyIndex":  is.historyIndex,
+		"rpnMode":       is.rpnMode,
+		"stackDepth":    len(is.stack),
+		"prefixMode":    is.prefixMode,
+		"autoSpace":     is.autoSpace,
 		"eventQueueLen": len(is.router.GetEventQueue()),
 	}
 }
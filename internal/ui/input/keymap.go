@@ -0,0 +1,270 @@
+package input
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// KeymapFileName is the keymap file looked for in the home directory
+const KeymapFileName = ".ccpm_keys.json"
+
+var (
+	ErrInvalidKeymap  = errors.New("invalid keymap")
+	ErrKeymapConflict = errors.New("conflicting keymap")
+)
+
+// keyNames names the keys a keymap file can bind
+var keyNames = map[tea.KeyType]string{
+	tea.KeyRunes:     "runes",
+	tea.KeyEnter:     "enter",
+	tea.KeyTab:       "tab",
+	tea.KeyShiftTab:  "shift+tab",
+	tea.KeyBackspace: "backspace",
+	tea.KeyDelete:    "delete",
+	tea.KeyUp:        "up",
+	tea.KeyDown:      "down",
+	tea.KeyLeft:      "left",
+	tea.KeyRight:     "right",
+	tea.KeySpace:     "space",
+	tea.KeyEsc:       "esc",
+	tea.KeyCtrlC:     "ctrl+c",
+	tea.KeyHome:      "home",
+	tea.KeyEnd:       "end",
+	tea.KeyPgUp:      "pgup",
+	tea.KeyPgDown:    "pgdown",
+}
+
+// keyActionNames names the actions a keymap file can bind keys to
+var keyActionNames = map[KeyAction]string{
+	KeyActionNumber:        "number",
+	KeyActionOperator:      "operator",
+	KeyActionEquals:        "equals",
+	KeyActionClear:         "clear",
+	KeyActionBackspace:     "backspace",
+	KeyActionNavigate:      "navigate",
+	KeyActionFocusActivate: "activate",
+	KeyActionQuit:          "quit",
+}
+
+// keyBindingFile is how a KeyBinding is stored in a keymap file. Rune
+// bindings match the character in Value.
+type keyBindingFile struct {
+	Key         string `json:"key"`
+	Alt         bool   `json:"alt,omitempty"`
+	Action      string `json:"action"`
+	Value       string `json:"value"`
+	Description string `json:"description,omitempty"`
+}
+
+// keymapFile is the layout of a keymap file
+type keymapFile struct {
+	Bindings []keyBindingFile `json:"bindings"`
+}
+
+// DefaultKeymapPath returns the keymap file in the home directory, or an
+// empty string if the home directory is unknown
+func DefaultKeymapPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, KeymapFileName)
+}
+
+// Validate checks that every binding uses a known key and action, that rune
+// bindings say which character they match, and that no key is bound twice
+func (c *KeyBindingsConfig) Validate() error {
+	bound := make(map[string]KeyBinding)
+	for _, binding := range c.Bindings {
+		if _, ok := keyNames[binding.Key]; !ok {
+			return fmt.Errorf("%w: unsupported key %s", ErrInvalidKeymap, binding.Key)
+		}
+		if _, ok := keyActionNames[binding.Action]; !ok {
+			return fmt.Errorf("%w: unknown action %d for %s", ErrInvalidKeymap, binding.Action, keyID(binding))
+		}
+		if binding.Key == tea.KeyRunes && binding.Value == "" {
+			return fmt.Errorf("%w: rune binding without a character", ErrInvalidKeymap)
+		}
+
+		id := keyID(binding)
+		if other, exists := bound[id]; exists {
+			return fmt.Errorf("%w: %s is bound to both %s %q and %s %q", ErrKeymapConflict, id,
+				keyActionNames[other.Action], other.Value, keyActionNames[binding.Action], binding.Value)
+		}
+		bound[id] = binding
+	}
+	return nil
+}
+
+// keyID identifies the key a binding matches
+func keyID(binding KeyBinding) string {
+	id := keyNames[binding.Key]
+	if binding.Key == tea.KeyRunes {
+		id = binding.Value
+	}
+	if binding.Alt {
+		id = "alt+" + id
+	}
+	return id
+}
+
+// LoadKeyBindings reads a keymap file. A missing file gives the default
+// bindings; an unreadable, invalid or conflicting one gives an error.
+func LoadKeyBindings(path string) (*KeyBindingsConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return DefaultKeyBindings(), nil
+		}
+		return nil, fmt.Errorf("failed to read keymap: %w", err)
+	}
+
+	var file keymapFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidKeymap, err)
+	}
+
+	config := &KeyBindingsConfig{}
+	for _, stored := range file.Bindings {
+		binding, err := stored.keyBinding()
+		if err != nil {
+			return nil, err
+		}
+		config.Bindings = append(config.Bindings, binding)
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// LoadKeyBindingsOrDefault reads a keymap file like LoadKeyBindings, falling
+// back to the default bindings if it cannot be used. The returned error says
+// why, for a warning.
+func LoadKeyBindingsOrDefault(path string) (*KeyBindingsConfig, error) {
+	config, err := LoadKeyBindings(path)
+	if err != nil {
+		return DefaultKeyBindings(), err
+	}
+	return config, nil
+}
+
+// SaveKeyBindings writes config to a keymap file, refusing invalid or
+// conflicting bindings
+func SaveKeyBindings(path string, config *KeyBindingsConfig) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+
+	file := keymapFile{Bindings: make([]keyBindingFile, len(config.Bindings))}
+	for i, binding := range config.Bindings {
+		file.Bindings[i] = keyBindingFile{
+			Key:         keyNames[binding.Key],
+			Alt:         binding.Alt,
+			Action:      keyActionNames[binding.Action],
+			Value:       binding.Value,
+			Description: binding.Description,
+		}
+	}
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal keymap: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write keymap: %w", err)
+	}
+	return nil
+}
+
+// keyBinding converts a stored binding back into a KeyBinding
+func (f keyBindingFile) keyBinding() (KeyBinding, error) {
+	binding := KeyBinding{Alt: f.Alt, Value: f.Value, Description: f.Description}
+
+	keyFound := false
+	for key, name := range keyNames {
+		if name == f.Key {
+			binding.Key, keyFound = key, true
+			break
+		}
+	}
+	if !keyFound {
+		return KeyBinding{}, fmt.Errorf("%w: unknown key %q", ErrInvalidKeymap, f.Key)
+	}
+
+	actionFound := false
+	for action, name := range keyActionNames {
+		if name == f.Action {
+			binding.Action, actionFound = action, true
+			break
+		}
+	}
+	if !actionFound {
+		return KeyBinding{}, fmt.Errorf("%w: unknown action %q", ErrInvalidKeymap, f.Action)
+	}
+
+	return binding, nil
+}
+
+// SetKeyBindings replaces the key bindings used by the handler
+func (kh *KeyboardHandler) SetKeyBindings(config *KeyBindingsConfig) {
+	kh.keyBindingManager = NewKeyBindingManager(config)
+}
+
+// KeyRemapper translates key presses bound by a custom keymap into the keys
+// the default bindings use for the same action, so a ui.Model, which only
+// knows the default keys, follows the custom keymap
+type KeyRemapper struct {
+	custom   *KeyBindingManager
+	defaults *KeyBindingManager
+}
+
+// NewKeyRemapper creates a remapper for config
+func NewKeyRemapper(config *KeyBindingsConfig) *KeyRemapper {
+	return &KeyRemapper{
+		custom:   NewKeyBindingManager(config),
+		defaults: NewKeyBindingManager(DefaultKeyBindings()),
+	}
+}
+
+// RemapKey returns the default key for the action msg is bound to, preferring
+// a typed character since the model sends Enter and Space to the focused
+// button first. Keys the keymap does not bind, or binds to an action without
+// a default key, are returned unchanged.
+func (r *KeyRemapper) RemapKey(msg tea.KeyMsg) tea.KeyMsg {
+	binding := r.custom.GetActionForKey(msg)
+	if binding == nil {
+		return msg
+	}
+
+	remapped, found := msg, false
+	for _, original := range r.defaults.GetBindingsByAction(binding.Action) {
+		if original.Value != binding.Value && !valueInsensitive(binding.Action) {
+			continue
+		}
+		if original.Key == tea.KeyRunes {
+			return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(original.Value), Alt: original.Alt}
+		}
+		if !found {
+			remapped, found = tea.KeyMsg{Type: original.Key, Alt: original.Alt}, true
+		}
+	}
+	return remapped
+}
+
+// valueInsensitive reports whether action does the same whatever its value,
+// which for rune bindings is only the character they match
+func valueInsensitive(action KeyAction) bool {
+	switch action {
+	case KeyActionEquals, KeyActionClear, KeyActionFocusActivate, KeyActionQuit:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,127 @@
+package input
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"ccpm-demo/internal/calculator"
+	"ccpm-demo/internal/ui"
+)
+
+// customKeyBindings moves equals to Tab and quit to "Q"
+func customKeyBindings() *KeyBindingsConfig {
+	config := DefaultKeyBindings()
+	for i, binding := range config.Bindings {
+		switch binding.Action {
+		case KeyActionEquals:
+			if binding.Key == tea.KeyEnter {
+				config.Bindings[i].Key = tea.KeyTab
+			}
+		case KeyActionQuit:
+			config.Bindings[i] = KeyBinding{Key: tea.KeyRunes, Action: KeyActionQuit, Value: "Q", Description: "Quit application"}
+		case KeyActionNavigate:
+			if binding.Key == tea.KeyTab {
+				config.Bindings[i].Key = tea.KeyShiftTab
+			}
+		}
+	}
+	return config
+}
+
+// TestKeyBindings_RoundTrip tests that a saved custom keymap loads back unchanged
+func TestKeyBindings_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), KeymapFileName)
+	config := customKeyBindings()
+
+	if err := SaveKeyBindings(path, config); err != nil {
+		t.Fatalf("Failed to save keymap: %v", err)
+	}
+
+	loaded, err := LoadKeyBindings(path)
+	if err != nil {
+		t.Fatalf("Failed to load keymap: %v", err)
+	}
+	if !reflect.DeepEqual(loaded, config) {
+		t.Errorf("Expected the keymap to round-trip, got %+v", loaded.Bindings)
+	}
+}
+
+// TestKeyBindings_RejectsConflict tests that a key bound twice is refused
+func TestKeyBindings_RejectsConflict(t *testing.T) {
+	config := DefaultKeyBindings()
+	config.Bindings = append(config.Bindings, KeyBinding{Key: tea.KeyRunes, Action: KeyActionQuit, Value: "5"})
+
+	if err := config.Validate(); !errors.Is(err, ErrKeymapConflict) {
+		t.Errorf("Expected '5' bound to number and quit to conflict, got %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), KeymapFileName)
+	if err := SaveKeyBindings(path, config); !errors.Is(err, ErrKeymapConflict) {
+		t.Errorf("Expected saving a conflicting keymap to fail, got %v", err)
+	}
+
+	// A conflicting file falls back to the defaults
+	conflicting := `{"bindings": [
+		{"key": "enter", "action": "equals", "value": "="},
+		{"key": "enter", "action": "quit", "value": "quit"}
+	]}`
+	if err := os.WriteFile(path, []byte(conflicting), 0644); err != nil {
+		t.Fatalf("Failed to write keymap: %v", err)
+	}
+	loaded, err := LoadKeyBindingsOrDefault(path)
+	if !errors.Is(err, ErrKeymapConflict) {
+		t.Errorf("Expected a conflict warning, got %v", err)
+	}
+	if !reflect.DeepEqual(loaded, DefaultKeyBindings()) {
+		t.Error("Expected a conflicting keymap to fall back to the defaults")
+	}
+}
+
+// TestKeyBindings_Fallback tests loading missing and invalid keymap files
+func TestKeyBindings_Fallback(t *testing.T) {
+	dir := t.TempDir()
+
+	loaded, err := LoadKeyBindings(filepath.Join(dir, "missing.json"))
+	if err != nil || !reflect.DeepEqual(loaded, DefaultKeyBindings()) {
+		t.Errorf("Expected a missing keymap to give the defaults, got error %v", err)
+	}
+
+	path := filepath.Join(dir, KeymapFileName)
+	if err := os.WriteFile(path, []byte(`{"bindings": [{"key": "f13", "action": "equals"}]}`), 0644); err != nil {
+		t.Fatalf("Failed to write keymap: %v", err)
+	}
+	if _, err := LoadKeyBindings(path); !errors.Is(err, ErrInvalidKeymap) {
+		t.Errorf("Expected an unknown key to be invalid, got %v", err)
+	}
+	if loaded, err := LoadKeyBindingsOrDefault(path); err == nil || !reflect.DeepEqual(loaded, DefaultKeyBindings()) {
+		t.Errorf("Expected an invalid keymap to fall back to the defaults with a warning, got error %v", err)
+	}
+}
+
+// TestKeyRemapper_Model tests that a model follows a custom keymap
+func TestKeyRemapper_Model(t *testing.T) {
+	model := ui.NewModel(calculator.NewEngine())
+	model.SetKeyRemapper(NewKeyRemapper(customKeyBindings()))
+
+	for _, r := range "2+3" {
+		updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		model = updated.(ui.Model)
+	}
+
+	// Tab now evaluates, as Enter did
+	updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyTab})
+	model = updated.(ui.Model)
+	if model.GetOutput() != "5" {
+		t.Errorf("Expected Tab to evaluate '2 + 3', got output '%s'", model.GetOutput())
+	}
+
+	// "Q" quits, as Esc did
+	if _, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'Q'}}); cmd == nil {
+		t.Error("Expected 'Q' to quit")
+	}
+}
@@ -0,0 +1,109 @@
+package input
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"ccpm-demo/internal/ui"
+)
+
+// prefixFrame is an operator entered in prefix mode and the operands it has
+// received so far
+type prefixFrame struct {
+	operator string
+	operands []float64
+}
+
+// SetPrefixMode switches between infix and prefix (Polish) notation input.
+// In prefix mode an operator is entered first and the equals key completes
+// each operand, so "+ 2 = 3 =" yields 5. An operator entered while another
+// waits for operands nests inside it: "* + 1 = 2 = 3 =" yields 9. Switching
+// modes discards a pending expression. Enabling prefix mode turns RPN mode off.
+func (is *InputSystem) SetPrefixMode(enabled bool) {
+	is.prefixMode = enabled
+	is.prefixPending = nil
+	if enabled {
+		is.rpnMode = false
+		is.stack = []float64{}
+	}
+}
+
+// IsPrefixMode returns whether prefix notation input is enabled
+func (is *InputSystem) IsPrefixMode() bool {
+	return is.prefixMode
+}
+
+// PendingPrefix returns the prefix expression waiting for operands, such as
+// "+ 2", or an empty string when nothing is pending
+func (is *InputSystem) PendingPrefix() string {
+	var parts []string
+	for _, frame := range is.prefixPending {
+		parts = append(parts, frame.operator)
+		for _, operand := range frame.operands {
+			parts = append(parts, formatStackValue(operand))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// handlePrefixOperator starts a new operator waiting for its operands,
+// completing a pending entry first so that "+ 2 * 3 = 4 =" works
+func (is *InputSystem) handlePrefixOperator(model ui.Model, operator string) (ui.Model, error) {
+	if !is.validator.validateOperatorInput(operator) {
+		is.flashInvalidInput(model, operator)
+		return model, fmt.Errorf("%s", is.validator.GetValidationError())
+	}
+
+	if is.currentInput != "" {
+		var err error
+		if model, err = is.handlePrefixOperand(model); err != nil {
+			return model, err
+		}
+		is.currentInput = ""
+	}
+
+	is.prefixPending = append(is.prefixPending, prefixFrame{operator: operator})
+	model.SetOutput(is.PendingPrefix())
+	return model, nil
+}
+
+// handlePrefixOperand completes the current entry as an operand of the
+// innermost pending operator, evaluating every operator it completes
+func (is *InputSystem) handlePrefixOperand(model ui.Model) (ui.Model, error) {
+	if len(is.prefixPending) == 0 {
+		return model, fmt.Errorf("enter an operator first")
+	}
+	if is.currentInput == "" {
+		return model, fmt.Errorf("nothing to enter")
+	}
+
+	value, err := strconv.ParseFloat(is.currentInput, 64)
+	if err != nil {
+		return model, fmt.Errorf("invalid number: %s", is.currentInput)
+	}
+	model.SetInput("")
+
+	for {
+		top := len(is.prefixPending) - 1
+		frame := &is.prefixPending[top]
+		frame.operands = append(frame.operands, value)
+		if len(frame.operands) < 2 {
+			model.SetOutput(is.PendingPrefix())
+			return model, nil
+		}
+
+		// The operator is complete; its result is an operand of the one outside it
+		result, err := applyRPNOperator(frame.operands[0], frame.operands[1], frame.operator)
+		if err != nil {
+			is.prefixPending = nil
+			return model, err
+		}
+		is.prefixPending = is.prefixPending[:top]
+		if len(is.prefixPending) == 0 {
+			model.SetOutput(formatStackValue(result))
+			return model, nil
+		}
+		value = result
+	}
+}
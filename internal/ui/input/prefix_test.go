@@ -0,0 +1,103 @@
+package input
+
+import (
+	"testing"
+
+	"ccpm-demo/internal/ui"
+)
+
+// TestInputSystem_PrefixAddition tests that + 2 3 yields 5
+func TestInputSystem_PrefixAddition(t *testing.T) {
+	system := NewInputSystem()
+	system.SetPrefixMode(true)
+
+	model := processRPNKeys(system, createMockModel(), "+", "2", "Enter", "3", "Enter")
+
+	if model.GetError() != "" {
+		t.Fatalf("Expected no error, got '%s'", model.GetError())
+	}
+	if model.GetOutput() != "5" {
+		t.Errorf("Expected output '5', got '%s'", model.GetOutput())
+	}
+	if system.PendingPrefix() != "" {
+		t.Errorf("Expected nothing pending, got '%s'", system.PendingPrefix())
+	}
+}
+
+// TestInputSystem_PrefixIncomplete tests that + 2 waits for the second operand
+func TestInputSystem_PrefixIncomplete(t *testing.T) {
+	system := NewInputSystem()
+	system.SetPrefixMode(true)
+
+	model := processRPNKeys(system, createMockModel(), "+", "2", "Enter")
+
+	if model.GetError() != "" {
+		t.Fatalf("Expected no error, got '%s'", model.GetError())
+	}
+	if system.PendingPrefix() != "+ 2" {
+		t.Errorf("Expected '+ 2' to wait for an operand, got '%s'", system.PendingPrefix())
+	}
+	if model.GetOutput() != "+ 2" {
+		t.Errorf("Expected the pending expression instead of a result, got '%s'", model.GetOutput())
+	}
+
+	model = processRPNKeys(system, model, "3", "Enter")
+	if model.GetOutput() != "5" {
+		t.Errorf("Expected the second operand to complete '+ 2 3', got '%s'", model.GetOutput())
+	}
+}
+
+// TestInputSystem_PrefixNested tests that * + 1 2 3 yields 9, with an operator
+// completing the entry before it
+func TestInputSystem_PrefixNested(t *testing.T) {
+	system := NewInputSystem()
+	system.SetPrefixMode(true)
+
+	model := processRPNKeys(system, createMockModel(), "*", "+", "1", "Enter", "2", "Enter", "3", "Enter")
+	if model.GetOutput() != "9" {
+		t.Errorf("Expected '* + 1 2 3' to yield 9, got '%s'", model.GetOutput())
+	}
+
+	model = processRPNKeys(system, model, "-", "1", "0", "/", "6", "Enter", "2", "Enter")
+	if model.GetOutput() != "7" {
+		t.Errorf("Expected '- 10 / 6 2' to yield 7, got '%s'", model.GetOutput())
+	}
+}
+
+// TestInputSystem_PrefixErrors tests operands without an operator and division by zero
+func TestInputSystem_PrefixErrors(t *testing.T) {
+	system := NewInputSystem()
+	system.SetPrefixMode(true)
+
+	model := processRPNKeys(system, createMockModel(), "2", "Enter")
+	if model.GetError() != "enter an operator first" {
+		t.Errorf("Expected an operand without an operator to be refused, got '%s'", model.GetError())
+	}
+
+	model = processRPNKeys(system, createMockModel(), "/", "1", "Enter", "0", "Enter")
+	if model.GetError() != "Division by zero" {
+		t.Errorf("Expected a division by zero error, got '%s'", model.GetError())
+	}
+	if system.PendingPrefix() != "" {
+		t.Errorf("Expected an error to discard the pending expression, got '%s'", system.PendingPrefix())
+	}
+}
+
+// TestInputSystem_PrefixModeSwitch tests that prefix and RPN modes exclude each other
+func TestInputSystem_PrefixModeSwitch(t *testing.T) {
+	system := NewInputSystem()
+	system.SetPrefixMode(true)
+	if system.Snapshot().Mode != ui.InputModePrefix {
+		t.Errorf("Expected the prefix input mode, got %s", system.Snapshot().Mode)
+	}
+
+	system.SetRPNMode(true)
+	if system.IsPrefixMode() {
+		t.Error("Expected RPN mode to turn prefix mode off")
+	}
+
+	system.SetPrefixMode(true)
+	if system.IsRPNMode() {
+		t.Error("Expected prefix mode to turn RPN mode off")
+	}
+}
@@ -0,0 +1,137 @@
+package input
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"ccpm-demo/internal/ui"
+)
+
+const (
+	// defaultEqualsLabel is the equals key label outside RPN mode
+	defaultEqualsLabel = "="
+
+	// defaultRPNEqualsLabel is the equals key label in RPN mode, where it pushes the entry
+	defaultRPNEqualsLabel = "Enter"
+)
+
+// SetRPNMode switches between infix and Reverse Polish Notation input.
+// In RPN mode the equals key pushes the current entry onto the stack and
+// operators apply to the top two stack values. Switching modes empties the
+// stack. Enabling RPN mode turns prefix mode off.
+func (is *InputSystem) SetRPNMode(enabled bool) {
+	is.rpnMode = enabled
+	is.stack = []float64{}
+	if enabled {
+		is.prefixMode = false
+		is.prefixPending = nil
+	}
+}
+
+// IsRPNMode returns whether Reverse Polish Notation input is enabled
+func (is *InputSystem) IsRPNMode() bool {
+	return is.rpnMode
+}
+
+// SetRPNEqualsLabel sets the label shown on the equals key in RPN mode
+func (is *InputSystem) SetRPNEqualsLabel(label string) {
+	is.rpnEqualsLabel = label
+}
+
+// EqualsLabel returns the equals key label for the current input mode
+func (is *InputSystem) EqualsLabel() string {
+	if is.rpnMode {
+		return is.rpnEqualsLabel
+	}
+	return defaultEqualsLabel
+}
+
+// GetStack returns a copy of the RPN stack, bottom first
+func (is *InputSystem) GetStack() []float64 {
+	stack := make([]float64, len(is.stack))
+	copy(stack, is.stack)
+	return stack
+}
+
+// StackDisplay returns the RPN stack one value per line, with the top of the
+// stack on the last line
+func (is *InputSystem) StackDisplay() string {
+	lines := make([]string, len(is.stack))
+	for i, value := range is.stack {
+		lines[i] = formatStackValue(value)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// handleRPNEnter pushes the current entry onto the stack
+func (is *InputSystem) handleRPNEnter(model ui.Model) (ui.Model, error) {
+	if is.currentInput == "" {
+		return model, fmt.Errorf("Nothing to push")
+	}
+
+	value, err := strconv.ParseFloat(is.currentInput, 64)
+	if err != nil {
+		return model, fmt.Errorf("Invalid number: %s", is.currentInput)
+	}
+
+	is.stack = append(is.stack, value)
+	model.SetInput("")
+	model.SetOutput(formatStackValue(value))
+	return model, nil
+}
+
+// handleRPNOperator applies an operator to the top two stack values, pushing
+// a pending entry first so that "3 Enter 4 +" works without a second Enter
+func (is *InputSystem) handleRPNOperator(model ui.Model, operator string) (ui.Model, error) {
+	if !is.validator.validateOperatorInput(operator) {
+		is.flashInvalidInput(model, operator)
+		return model, fmt.Errorf("%s", is.validator.GetValidationError())
+	}
+
+	if is.currentInput != "" {
+		var err error
+		if model, err = is.handleRPNEnter(model); err != nil {
+			return model, err
+		}
+		is.currentInput = ""
+	}
+
+	if len(is.stack) < 2 {
+		return model, fmt.Errorf("Stack underflow")
+	}
+
+	top := len(is.stack) - 1
+	result, err := applyRPNOperator(is.stack[top-1], is.stack[top], operator)
+	if err != nil {
+		return model, err
+	}
+
+	is.stack = append(is.stack[:top-1], result)
+	model.SetOutput(formatStackValue(result))
+	return model, nil
+}
+
+// applyRPNOperator applies a binary operator to two operands
+func applyRPNOperator(a, b float64, operator string) (float64, error) {
+	switch operator {
+	case "+":
+		return a + b, nil
+	case "-":
+		return a - b, nil
+	case "*":
+		return a * b, nil
+	case "/":
+		if b == 0 {
+			return 0, fmt.Errorf("Division by zero")
+		}
+		return a / b, nil
+	default:
+		return 0, fmt.Errorf("Invalid operator: %s", operator)
+	}
+}
+
+// formatStackValue formats a stack value without trailing zeros
+func formatStackValue(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -0,0 +1,92 @@
+package input
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"ccpm-demo/internal/ui"
+)
+
+// processRPNKeys feeds space separated RPN keys through the input system
+func processRPNKeys(system *InputSystem, model ui.Model, keys ...string) ui.Model {
+	for _, key := range keys {
+		var msg tea.Msg
+		switch key {
+		case "Enter":
+			msg = EqualsInputMsg{}
+		case "+", "-", "*", "/":
+			msg = OperatorInputMsg{Operator: key}
+		default:
+			msg = NumberInputMsg{Value: key}
+		}
+		model, _ = system.ProcessMessage(model, msg)
+	}
+	return model
+}
+
+// TestInputSystem_RPNAddition tests that 3 Enter 4 + yields 7
+func TestInputSystem_RPNAddition(t *testing.T) {
+	system := NewInputSystem()
+	system.SetRPNMode(true)
+
+	model := processRPNKeys(system, createMockModel(), "3", "Enter", "4", "+")
+
+	if model.GetError() != "" {
+		t.Fatalf("Expected no error, got '%s'", model.GetError())
+	}
+	if model.GetOutput() != "7" {
+		t.Errorf("Expected output '7', got '%s'", model.GetOutput())
+	}
+	if stack := system.GetStack(); len(stack) != 1 || stack[0] != 7 {
+		t.Errorf("Expected stack [7], got %v", stack)
+	}
+}
+
+// TestInputSystem_RPNNestedOperations tests that 5 Enter 1 Enter 2 + * yields 15
+func TestInputSystem_RPNNestedOperations(t *testing.T) {
+	system := NewInputSystem()
+	system.SetRPNMode(true)
+
+	model := processRPNKeys(system, createMockModel(), "5", "Enter", "1", "Enter", "2", "+", "*")
+
+	if model.GetOutput() != "15" {
+		t.Errorf("Expected output '15', got '%s'", model.GetOutput())
+	}
+	if system.StackDisplay() != "15" {
+		t.Errorf("Expected stack display '15', got '%s'", system.StackDisplay())
+	}
+}
+
+// TestInputSystem_RPNStackUnderflow tests that operators need two stack values
+func TestInputSystem_RPNStackUnderflow(t *testing.T) {
+	system := NewInputSystem()
+	system.SetRPNMode(true)
+
+	model := processRPNKeys(system, createMockModel(), "3", "Enter", "+")
+
+	if model.GetError() != "Stack underflow" {
+		t.Errorf("Expected a stack underflow error, got '%s'", model.GetError())
+	}
+	if stack := system.GetStack(); len(stack) != 1 || stack[0] != 3 {
+		t.Errorf("Expected the stack to be left as [3], got %v", stack)
+	}
+}
+
+// TestInputSystem_RPNEqualsLabel tests the equals key label in each mode
+func TestInputSystem_RPNEqualsLabel(t *testing.T) {
+	system := NewInputSystem()
+	if system.EqualsLabel() != "=" {
+		t.Errorf("Expected '=' outside RPN mode, got '%s'", system.EqualsLabel())
+	}
+
+	system.SetRPNMode(true)
+	if system.EqualsLabel() != "Enter" {
+		t.Errorf("Expected 'Enter' in RPN mode, got '%s'", system.EqualsLabel())
+	}
+
+	system.SetRPNEqualsLabel("↵")
+	if system.EqualsLabel() != "↵" {
+		t.Errorf("Expected the configured label, got '%s'", system.EqualsLabel())
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"math"
 
 	tea "github.com/charmbracelet/bubbletea"
+
+	"ccpm-demo/internal/ui"
 )
 
 // ScrollManager handles scroll wheel interactions
@@ -136,6 +138,18 @@ func (sm *ScrollManager) RegisterScrollAction(context string, action ScrollActio
 	sm.scrollActions[context] = action
 }
 
+// RegisterHistoryPanelScroll makes vertical scrolling page the history panel,
+// one entry per wheel step; the model clamps the panel to its entries
+func (sm *ScrollManager) RegisterHistoryPanelScroll() {
+	sm.RegisterScrollAction("vertical", ScrollAction{
+		Type:      "history",
+		Direction: ScrollVertical,
+		Handler: func(delta float64) tea.Msg {
+			return ui.ScrollHistoryMsg{Delta: int(math.Round(delta))}
+		},
+	})
+}
+
 // UnregisterScrollAction removes a scroll action
 func (sm *ScrollManager) UnregisterScrollAction(context string) {
 	delete(sm.scrollActions, context)
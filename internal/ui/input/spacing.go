@@ -0,0 +1,68 @@
+package input
+
+import (
+	"bytes"
+	"strings"
+)
+
+// spacedOperators are the operators written with a space on each side
+const spacedOperators = "+-*/"
+
+// signPrecedingChars are the characters after which + or - is a sign rather
+// than an operator, as in "2 * -3" or "(-1"
+const signPrecedingChars = "+-*/^&|<>(,"
+
+// SetAutoSpaceOperators sets whether operators are written with a space on
+// each side as they are entered, so typing 1+2 stores "1 + 2". It is on by
+// default; when off, operators are stored exactly as typed.
+func (is *InputSystem) SetAutoSpaceOperators(enabled bool) {
+	is.autoSpace = enabled
+}
+
+// IsAutoSpaceOperators returns whether operators are spaced during entry
+func (is *InputSystem) IsAutoSpaceOperators() bool {
+	return is.autoSpace
+}
+
+// formatInput applies operator spacing to an entered expression, if enabled
+func (is *InputSystem) formatInput(expression string) string {
+	if !is.autoSpace {
+		return expression
+	}
+	return spaceOperators(expression)
+}
+
+// spaceOperators puts a single space on each side of every binary operator,
+// leaving signs attached to their numbers, including exponent signs such as
+// 1E-5. A trailing operator keeps its space so the next number follows it.
+func spaceOperators(expression string) string {
+	out := make([]byte, 0, len(expression)+8)
+	for i := 0; i < len(expression); i++ {
+		c := expression[i]
+		if !strings.ContainsRune(spacedOperators, rune(c)) || isSignPosition(out) {
+			out = append(out, c)
+			continue
+		}
+
+		out = append(bytes.TrimRight(out, " "), ' ', c, ' ')
+		for i+1 < len(expression) && expression[i+1] == ' ' {
+			i++
+		}
+	}
+	return string(out)
+}
+
+// isSignPosition reports whether a + or - following text would be a sign
+func isSignPosition(text []byte) bool {
+	text = bytes.TrimRight(text, " ")
+	if len(text) == 0 {
+		return true
+	}
+
+	last := text[len(text)-1]
+	if strings.ContainsRune(signPrecedingChars, rune(last)) {
+		return true
+	}
+	// The sign of an exponent, as in 1E-5
+	return (last == 'E' || last == 'e') && len(text) > 1 && text[len(text)-2] >= '0' && text[len(text)-2] <= '9'
+}
@@ -0,0 +1,71 @@
+package input
+
+import (
+	"testing"
+
+	"ccpm-demo/internal/calculator"
+)
+
+// TestInputSystem_AutoSpaceOperators tests that typing 1+2*3 stores "1 + 2 * 3"
+func TestInputSystem_AutoSpaceOperators(t *testing.T) {
+	system := NewInputSystem()
+	if !system.IsAutoSpaceOperators() {
+		t.Fatal("Expected operators to be auto-spaced by default")
+	}
+
+	model := processRPNKeys(system, createMockModel(), "1", "+", "2", "*", "3")
+
+	if model.GetError() != "" {
+		t.Fatalf("Expected no error, got '%s'", model.GetError())
+	}
+	if model.GetInput() != "1 + 2 * 3" {
+		t.Errorf("Expected stored input '1 + 2 * 3', got '%s'", model.GetInput())
+	}
+
+	// Spacing does not change the result
+	engine := calculator.NewEngine()
+	spaced, err := engine.Evaluate(model.GetInput())
+	if err != nil {
+		t.Fatalf("Expected '%s' to evaluate, got error: %v", model.GetInput(), err)
+	}
+	unspaced, _ := engine.Evaluate("1+2*3")
+	if spaced != 7 || spaced != unspaced {
+		t.Errorf("Expected both forms to evaluate to 7, got %v and %v", spaced, unspaced)
+	}
+}
+
+// TestInputSystem_AutoSpaceOperatorsDisabled tests that operators are stored as typed
+func TestInputSystem_AutoSpaceOperatorsDisabled(t *testing.T) {
+	system := NewInputSystem()
+	system.SetAutoSpaceOperators(false)
+
+	model := processRPNKeys(system, createMockModel(), "1", "+", "2", "*", "3")
+
+	if model.GetInput() != "1+2*3" {
+		t.Errorf("Expected stored input '1+2*3', got '%s'", model.GetInput())
+	}
+}
+
+// TestSpaceOperators tests spacing of binary operators while signs stay attached
+func TestSpaceOperators(t *testing.T) {
+	tests := []struct {
+		expression string
+		expected   string
+	}{
+		{"1+2", "1 + 2"},
+		{"1 +2", "1 + 2"},
+		{"1  *   2", "1 * 2"},
+		{"1 +", "1 + "},
+		{"-1-2", "-1 - 2"},
+		{"2*-3", "2 * -3"},
+		{"(-1+2)/3", "(-1 + 2) / 3"},
+		{"1E-5+1", "1E-5 + 1"},
+		{"1 + 2 * 3", "1 + 2 * 3"},
+	}
+
+	for _, tt := range tests {
+		if got := spaceOperators(tt.expression); got != tt.expected {
+			t.Errorf("spaceOperators(%q) = %q, want %q", tt.expression, got, tt.expected)
+		}
+	}
+}
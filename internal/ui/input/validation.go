@@ -6,6 +6,8 @@ import (
 	"strings"
 	"unicode"
 	"unicode/utf8"
+
+	"ccpm-demo/internal/calculator"
 )
 
 // InputValidator implements input validation for calculator operations
@@ -235,10 +237,13 @@ func (iv *InputValidator) isValidExpressionStart(expression string) bool {
 
 	firstChar := expression[0]
 
-	// Can start with: digit, decimal point, minus sign (for negative numbers)
+	// Can start with: digit, decimal point, minus sign (for negative numbers),
+	// function name, opening parenthesis
 	return (firstChar >= '0' && firstChar <= '9') ||
 		firstChar == '.' ||
-		(firstChar == '-' && iv.allowNegative)
+		(firstChar == '-' && iv.allowNegative) ||
+		unicode.IsLetter(rune(firstChar)) ||
+		firstChar == '('
 }
 
 // isValidExpressionEnd checks if the expression ends with a valid token
@@ -249,8 +254,8 @@ func (iv *InputValidator) isValidExpressionEnd(expression string) bool {
 
 	lastChar := expression[len(expression)-1]
 
-	// Can end with: digit, decimal point
-	return (lastChar >= '0' && lastChar <= '9') || lastChar == '.'
+	// Can end with: digit, decimal point, closing parenthesis, factorial
+	return (lastChar >= '0' && lastChar <= '9') || lastChar == '.' || lastChar == ')' || lastChar == '!'
 }
 
 // hasBalancedParentheses checks if parentheses are balanced
@@ -300,13 +305,25 @@ func (iv *InputValidator) tokenizeExpression(expression string) []string {
 	var tokens []string
 	var currentToken strings.Builder
 
-	for _, char := range expression {
-		if unicode.IsSpace(char) {
+	next := 0 // skips the rest of a two-character operator
+	for i, char := range expression {
+		if i < next {
+			continue
+		}
+
+		if op := bitwiseOperatorAt(expression, i); op != "" {
+			if currentToken.Len() > 0 {
+				tokens = append(tokens, currentToken.String())
+				currentToken.Reset()
+			}
+			tokens = append(tokens, op)
+			next = i + len(op)
+		} else if unicode.IsSpace(char) {
 			if currentToken.Len() > 0 {
 				tokens = append(tokens, currentToken.String())
 				currentToken.Reset()
 			}
-		} else if iv.isOperatorToken(char) {
+		} else if iv.isOperatorToken(char) || strings.ContainsRune(punctuationTokens, char) {
 			if currentToken.Len() > 0 {
 				tokens = append(tokens, currentToken.String())
 				currentToken.Reset()
@@ -324,6 +341,24 @@ func (iv *InputValidator) tokenizeExpression(expression string) []string {
 	return tokens
 }
 
+// bitwiseOperators are the bitwise operators, which work on whole numbers
+var bitwiseOperators = []string{"<<", ">>", "^^", "&", "|"}
+
+// bitwiseOperatorAt returns the bitwise operator starting at i, or an empty
+// string if there is none
+func bitwiseOperatorAt(expression string, i int) string {
+	for _, op := range bitwiseOperators {
+		if strings.HasPrefix(expression[i:], op) {
+			return op
+		}
+	}
+	return ""
+}
+
+// punctuationTokens are the characters other than operators that form a
+// token on their own: brackets, argument separators and the factorial "!"
+const punctuationTokens = "(),!"
+
 // isOperatorToken checks if a character is an operator
 func (iv *InputValidator) isOperatorToken(char rune) bool {
 	return char == '+' || char == '-' || char == '*' || char == '/'
@@ -331,6 +366,11 @@ func (iv *InputValidator) isOperatorToken(char rune) bool {
 
 // isOperator checks if a token is an operator
 func (iv *InputValidator) isOperator(token string) bool {
+	for _, op := range bitwiseOperators {
+		if token == op {
+			return true
+		}
+	}
 	return token == "+" || token == "-" || token == "*" || token == "/"
 }
 
@@ -340,11 +380,26 @@ func (iv *InputValidator) isValidToken(token string) bool {
 		return true
 	}
 
+	// Brackets, separators, factorials and function names
+	if len(token) == 1 && strings.Contains(punctuationTokens, token) {
+		return true
+	}
+	if isFunctionName(token) {
+		return true
+	}
+
 	// Check if it's a valid number
 	if _, err := strconv.ParseFloat(token, 64); err == nil {
 		return true
 	}
 
+	// Or a hexadecimal, octal or binary integer such as 0xFF
+	if len(token) > 2 && token[0] == '0' && strings.ContainsRune("xXoObB", rune(token[1])) {
+		if _, err := strconv.ParseUint(token, 0, 64); err == nil {
+			return true
+		}
+	}
+
 	// Check if it has valid decimal places
 	if strings.Contains(token, ".") {
 		parts := strings.Split(token, ".")
@@ -364,6 +419,16 @@ func (iv *InputValidator) isValidToken(token string) bool {
 	return false
 }
 
+// isFunctionName reports whether token names a builtin function, in any case
+func isFunctionName(token string) bool {
+	for _, name := range calculator.FunctionNames() {
+		if strings.EqualFold(token, name) {
+			return true
+		}
+	}
+	return false
+}
+
 // SanitizeInput sanitizes input by removing invalid characters
 func (iv *InputValidator) SanitizeInput(input string) string {
 	var sanitized strings.Builder
@@ -428,12 +493,18 @@ func (iv *InputValidator) ValidateNumberInput(currentInput, newChar string) Vali
 		return result
 	}
 
-	// Check for multiple decimal points
-	if newChar == "." && strings.Contains(currentInput, ".") {
+	// Check for multiple decimal points within the number being entered
+	if newChar == "." && strings.Contains(currentNumber(currentInput), ".") {
 		result.ErrorMsg = "Multiple decimal points not allowed"
 		return result
 	}
 
+	// Stop decimal digits once the operand has the maximum number of places
+	if _, decimals, found := strings.Cut(currentNumber(currentInput), "."); found && newChar != "." && len(decimals) >= iv.maxDecimalPlaces {
+		result.ErrorMsg = fmt.Sprintf("Too many decimal places (max %d)", iv.maxDecimalPlaces)
+		return result
+	}
+
 	// Check for leading zero issues
 	if iv.hasLeadingZeroIssue(currentInput, newChar) {
 		result.ErrorMsg = "Invalid number format"
@@ -445,6 +516,11 @@ func (iv *InputValidator) ValidateNumberInput(currentInput, newChar string) Vali
 	return result
 }
 
+// currentNumber returns the trailing number of an expression, i.e. the operand being entered
+func currentNumber(input string) string {
+	return input[strings.LastIndexAny(input, "+-*/^() ")+1:]
+}
+
 // hasLeadingZeroIssue checks for invalid leading zero patterns
 func (iv *InputValidator) hasLeadingZeroIssue(currentInput, newChar string) bool {
 	if newChar == "0" && currentInput == "" {
@@ -0,0 +1,58 @@
+package ui
+
+// InputMode is the way expressions are entered
+type InputMode int
+
+const (
+	// InputModeInfix enters expressions with operators between operands
+	InputModeInfix InputMode = iota
+	// InputModeRPN enters operands first and applies operators to the stack
+	InputModeRPN
+	// InputModePrefix enters an operator first, followed by its operands
+	InputModePrefix
+)
+
+// String returns the string representation of the input mode
+func (i InputMode) String() string {
+	switch i {
+	case InputModeRPN:
+		return "rpn"
+	case InputModePrefix:
+		return "prefix"
+	default:
+		return "infix"
+	}
+}
+
+// InputState is a snapshot of the input context: what has been typed, where
+// the cursor is, the operator waiting for an operand, the last result and the
+// input mode. Changing it does not affect the model it was taken from.
+type InputState struct {
+	Input           string
+	Cursor          int
+	PendingOperator string
+	LastResult      string
+	Mode            InputMode
+}
+
+// NewInputState creates an input state, working out the pending operator
+// from the input
+func NewInputState(input string, cursor int, lastResult string, mode InputMode) InputState {
+	operator, _ := pendingOperator(input)
+	return InputState{
+		Input:           input,
+		Cursor:          cursor,
+		PendingOperator: operator,
+		LastResult:      lastResult,
+		Mode:            mode,
+	}
+}
+
+// InputState returns a snapshot of the current input context
+func (m Model) InputState() InputState {
+	mode := InputModeInfix
+	if m.rpn != nil && m.rpn.IsRPNMode() {
+		mode = InputModeRPN
+	}
+	return NewInputState(m.input, m.cursorPosition, m.output, mode)
+}
@@ -2,6 +2,8 @@ package integration
 
 import (
 	"fmt"
+	"io"
+	"sort"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -14,12 +16,52 @@ import (
 // ButtonGrid represents the complete calculator button grid integration
 // It combines buttons, grid layout, styling, and interaction handling
 type ButtonGrid struct {
-	buttons       map[string]*components.Button
-	grid          *components.GridLayout
-	themeManager  *styles.ThemeManager
-	focusedButton string
-	pressedButton string
-	dimensions    GridDimensions
+	buttons          map[string]*components.Button
+	grid             *components.GridLayout
+	themeManager     *styles.ThemeManager
+	focusedButton    string
+	pressedButton    string
+	dimensions       GridDimensions
+	buttonBounds     map[string]ButtonRect
+	hoverReporter    HoverReporter
+	operatorPosition OperatorPosition
+
+	// customLayout replaces the calculator layout when set
+	customLayout []ButtonDefinition
+
+	// scientific adds the scientific rows to the calculator layout
+	scientific bool
+
+	// wideZero makes the 0 key span two columns in the right-operator layout
+	wideZero bool
+}
+
+// OperatorPosition controls where the arithmetic operator buttons are placed
+type OperatorPosition int
+
+const (
+	// OperatorsRight places operators in the right column like a Casio calculator
+	OperatorsRight OperatorPosition = iota
+	// OperatorsBottom places operators along the bottom row
+	OperatorsBottom
+)
+
+// String returns the string representation of the operator position
+func (p OperatorPosition) String() string {
+	switch p {
+	case OperatorsRight:
+		return "right"
+	case OperatorsBottom:
+		return "bottom"
+	default:
+		return "unknown"
+	}
+}
+
+// HoverReporter reports whether an element is currently hovered.
+// It is satisfied by input.HoverManager.
+type HoverReporter interface {
+	IsHovering(elementID string) bool
 }
 
 // GridDimensions defines the size of the button grid
@@ -35,10 +77,25 @@ type ButtonDefinition struct {
 	Type     components.ButtonType
 	Row      int
 	Column   int
+	Span     int // grid columns covered from Column; 0 counts as 1
 	Width    int
 	Height   int
 }
 
+// ButtonRect represents the clickable screen area of a button
+type ButtonRect struct {
+	X      int
+	Y      int
+	Width  int
+	Height int
+}
+
+// Contains checks if a screen position falls within the rectangle
+func (r ButtonRect) Contains(x, y int) bool {
+	return x >= r.X && x < r.X+r.Width &&
+		y >= r.Y && y < r.Y+r.Height
+}
+
 // ButtonAction represents an action triggered by a button
 type ButtonAction struct {
 	Button   *components.Button
@@ -47,10 +104,19 @@ type ButtonAction struct {
 	ButtonID string
 }
 
+// ButtonInfo describes a single button for tooling that needs a grid manifest
+type ButtonInfo struct {
+	ID       string
+	Position components.Position
+	Label    string
+	Value    string
+	Type     components.ButtonType
+}
+
 // NewButtonGrid creates a new button grid with default calculator layout
 func NewButtonGrid() *ButtonGrid {
 	themeManager := styles.NewThemeManager()
-	grid := components.NewGridLayout()
+	grid := components.NewGridLayout().WithDimensions(4, 6)
 
 	buttonGrid := &ButtonGrid{
 		buttons:      make(map[string]*components.Button),
@@ -58,7 +124,7 @@ func NewButtonGrid() *ButtonGrid {
 		themeManager: themeManager,
 		dimensions: GridDimensions{
 			Columns: 4,
-			Rows:    5,
+			Rows:    6,
 		},
 	}
 
@@ -77,7 +143,7 @@ func NewButtonGridWithTheme(themeName string) (*ButtonGrid, error) {
 		return nil, fmt.Errorf("failed to set theme: %w", err)
 	}
 
-	grid := components.NewGridLayout()
+	grid := components.NewGridLayout().WithDimensions(4, 6)
 
 	buttonGrid := &ButtonGrid{
 		buttons:      make(map[string]*components.Button),
@@ -85,20 +151,77 @@ func NewButtonGridWithTheme(themeName string) (*ButtonGrid, error) {
 		themeManager: themeManager,
 		dimensions: GridDimensions{
 			Columns: 4,
-			Rows:    5,
+			Rows:    6,
 		},
 	}
 
+	if err := ValidateButtonDefinitions(buttonGrid.layoutDefinitions()); err != nil {
+		return nil, fmt.Errorf("invalid button layout: %w", err)
+	}
+
 	// Initialize the calculator button layout
 	buttonGrid.initializeCalculatorLayout()
 
 	return buttonGrid, nil
 }
 
-// initializeCalculatorLayout creates the standard calculator button arrangement
+// initializeCalculatorLayout creates the calculator button arrangement for the
+// configured operator position, replacing any existing buttons
 func (bg *ButtonGrid) initializeCalculatorLayout() {
-	// Standard calculator button layout (4x5 grid)
-	buttonDefs := []ButtonDefinition{
+	buttonDefs := bg.layoutDefinitions()
+
+	bg.buttons = make(map[string]*components.Button)
+	bg.grid.Clear()
+
+	// Create buttons from definitions
+	for _, def := range buttonDefs {
+		buttonID := bg.generateButtonID(def.Row, def.Column)
+		button := bg.createButton(def)
+		bg.buttons[buttonID] = button
+
+		// Add button to grid
+		buttonStyle := bg.getButtonStyle(button)
+		bg.grid.AddSpannedCell(def.Column, def.Row, button.GetSpan(), def.Label, buttonStyle)
+	}
+
+	// Set initial focus on the first button
+	if len(bg.buttons) > 0 {
+		bg.focusedButton = "button_0_0"  // Focus on the "C" button
+		if button, exists := bg.buttons[bg.focusedButton]; exists {
+			button.Focus()
+		}
+	}
+}
+
+// layoutDefinitions returns the custom button definitions, if any, or those
+// for the configured operator position and scientific mode
+func (bg *ButtonGrid) layoutDefinitions() []ButtonDefinition {
+	if bg.customLayout != nil {
+		return bg.customLayout
+	}
+
+	var defs []ButtonDefinition
+	switch bg.operatorPosition {
+	case OperatorsBottom:
+		defs = bottomOperatorLayout()
+	default:
+		defs = rightOperatorLayout()
+		if bg.wideZero {
+			defs = withWideZero(defs)
+		}
+	}
+
+	if bg.scientific {
+		defs = append(defs, scientificRow(calculatorRows)...)
+		defs = append(defs, exponentRow(calculatorRows+1)...)
+	}
+	return defs
+}
+
+// rightOperatorLayout returns the standard calculator layout (4x6 grid) with
+// operators in the right column
+func rightOperatorLayout() []ButtonDefinition {
+	return []ButtonDefinition{
 		// Row 0 (top row): C, CE, ←, ÷
 		{Label: "C", Value: "clear", Type: components.TypeSpecial, Row: 0, Column: 0, Width: 3, Height: 1},
 		{Label: "CE", Value: "clear_entry", Type: components.TypeSpecial, Row: 0, Column: 1, Width: 3, Height: 1},
@@ -123,30 +246,59 @@ func (bg *ButtonGrid) initializeCalculatorLayout() {
 		{Label: "3", Value: "3", Type: components.TypeNumber, Row: 3, Column: 2, Width: 3, Height: 1},
 		{Label: "+", Value: "+", Type: components.TypeOperator, Row: 3, Column: 3, Width: 3, Height: 1},
 
-		// Row 4 (bottom row): 0, ., =
+		// Row 4 (bottom row): 0, ., =, ±
 		{Label: "0", Value: "0", Type: components.TypeNumber, Row: 4, Column: 0, Width: 3, Height: 1},
 		{Label: ".", Value: ".", Type: components.TypeNumber, Row: 4, Column: 1, Width: 3, Height: 1},
 		{Label: "=", Value: "=", Type: components.TypeSpecial, Row: 4, Column: 2, Width: 3, Height: 1},
-		// Empty cell at row 4, column 3 for balance
+		{Label: "±", Value: "negate", Type: components.TypeSpecial, Row: 4, Column: 3, Width: 3, Height: 1},
+
+		// Row 5 (function row): 1/x, x², x³, ANS
+		{Label: "1/x", Value: "reciprocal", Type: components.TypeSpecial, Row: 5, Column: 0, Width: 3, Height: 1},
+		{Label: "x²", Value: "square", Type: components.TypeSpecial, Row: 5, Column: 1, Width: 3, Height: 1},
+		{Label: "x³", Value: "cube", Type: components.TypeSpecial, Row: 5, Column: 2, Width: 3, Height: 1},
+		{Label: "ANS", Value: "ans", Type: components.TypeSpecial, Row: 5, Column: 3, Width: 3, Height: 1},
 	}
+}
 
-	// Create buttons from definitions
-	for _, def := range buttonDefs {
-		buttonID := bg.generateButtonID(def.Row, def.Column)
-		button := bg.createButton(def)
-		bg.buttons[buttonID] = button
+// bottomOperatorLayout returns a calculator layout (4x6 grid) with operators
+// along the bottom row
+func bottomOperatorLayout() []ButtonDefinition {
+	return []ButtonDefinition{
+		// Row 0 (top row): C, CE, ←, =
+		{Label: "C", Value: "clear", Type: components.TypeSpecial, Row: 0, Column: 0, Width: 3, Height: 1},
+		{Label: "CE", Value: "clear_entry", Type: components.TypeSpecial, Row: 0, Column: 1, Width: 3, Height: 1},
+		{Label: "←", Value: "backspace", Type: components.TypeSpecial, Row: 0, Column: 2, Width: 3, Height: 1},
+		{Label: "=", Value: "=", Type: components.TypeSpecial, Row: 0, Column: 3, Width: 3, Height: 1},
 
-		// Add button to grid
-		buttonStyle := bg.getButtonStyle(button)
-		bg.grid.AddCell(def.Column, def.Row, def.Label, buttonStyle)
-	}
+		// Row 1: 7, 8, 9, ±
+		{Label: "7", Value: "7", Type: components.TypeNumber, Row: 1, Column: 0, Width: 3, Height: 1},
+		{Label: "8", Value: "8", Type: components.TypeNumber, Row: 1, Column: 1, Width: 3, Height: 1},
+		{Label: "9", Value: "9", Type: components.TypeNumber, Row: 1, Column: 2, Width: 3, Height: 1},
+		{Label: "±", Value: "negate", Type: components.TypeSpecial, Row: 1, Column: 3, Width: 3, Height: 1},
 
-	// Set initial focus on the first button
-	if len(bg.buttons) > 0 {
-		bg.focusedButton = "button_0_0"  // Focus on the "C" button
-		if button, exists := bg.buttons[bg.focusedButton]; exists {
-			button.Focus()
-		}
+		// Row 2: 4, 5, 6, .
+		{Label: "4", Value: "4", Type: components.TypeNumber, Row: 2, Column: 0, Width: 3, Height: 1},
+		{Label: "5", Value: "5", Type: components.TypeNumber, Row: 2, Column: 1, Width: 3, Height: 1},
+		{Label: "6", Value: "6", Type: components.TypeNumber, Row: 2, Column: 2, Width: 3, Height: 1},
+		{Label: ".", Value: ".", Type: components.TypeNumber, Row: 2, Column: 3, Width: 3, Height: 1},
+
+		// Row 3: 1, 2, 3, 0
+		{Label: "1", Value: "1", Type: components.TypeNumber, Row: 3, Column: 0, Width: 3, Height: 1},
+		{Label: "2", Value: "2", Type: components.TypeNumber, Row: 3, Column: 1, Width: 3, Height: 1},
+		{Label: "3", Value: "3", Type: components.TypeNumber, Row: 3, Column: 2, Width: 3, Height: 1},
+		{Label: "0", Value: "0", Type: components.TypeNumber, Row: 3, Column: 3, Width: 3, Height: 1},
+
+		// Row 4 (function row): 1/x, x², x³, ANS
+		{Label: "1/x", Value: "reciprocal", Type: components.TypeSpecial, Row: 4, Column: 0, Width: 3, Height: 1},
+		{Label: "x²", Value: "square", Type: components.TypeSpecial, Row: 4, Column: 1, Width: 3, Height: 1},
+		{Label: "x³", Value: "cube", Type: components.TypeSpecial, Row: 4, Column: 2, Width: 3, Height: 1},
+		{Label: "ANS", Value: "ans", Type: components.TypeSpecial, Row: 4, Column: 3, Width: 3, Height: 1},
+
+		// Row 5 (bottom row): ÷, ×, -, +
+		{Label: "÷", Value: "/", Type: components.TypeOperator, Row: 5, Column: 0, Width: 3, Height: 1},
+		{Label: "×", Value: "*", Type: components.TypeOperator, Row: 5, Column: 1, Width: 3, Height: 1},
+		{Label: "-", Value: "-", Type: components.TypeOperator, Row: 5, Column: 2, Width: 3, Height: 1},
+		{Label: "+", Value: "+", Type: components.TypeOperator, Row: 5, Column: 3, Width: 3, Height: 1},
 	}
 }
 
@@ -158,6 +310,7 @@ func (bg *ButtonGrid) createButton(def ButtonDefinition) *components.Button {
 		Value:  def.Value,
 		Width:  def.Width,
 		Height: def.Height,
+		Span:   def.Span,
 		Position: components.Position{
 			Row:    def.Row,
 			Column: def.Column,
@@ -175,19 +328,24 @@ func (bg *ButtonGrid) generateButtonID(row, col int) string {
 // getButtonStyle returns the appropriate style for a button based on its type and state
 func (bg *ButtonGrid) getButtonStyle(button *components.Button) lipgloss.Style {
 	buttonType := button.GetType()
-	state := button.GetState()
+	state := button.GetState().String()
+
+	// Hover highlights a resting button without overriding press or focus feedback
+	if button.GetState() == components.StateNormal && bg.isButtonHovered(button) {
+		state = "hover"
+	}
 
 	var style lipgloss.Style
 
 	switch buttonType {
 	case components.TypeNumber:
-		style = bg.themeManager.GetButtonStyle("number", state.String())
+		style = bg.themeManager.GetButtonStyle("number", state)
 	case components.TypeOperator:
-		style = bg.themeManager.GetButtonStyle("operator", state.String())
+		style = bg.themeManager.GetButtonStyle("operator", state)
 	case components.TypeSpecial:
-		style = bg.themeManager.GetButtonStyle("special", state.String())
+		style = bg.themeManager.GetButtonStyle("special", state)
 	default:
-		style = bg.themeManager.GetButtonStyle("number", state.String())
+		style = bg.themeManager.GetButtonStyle("number", state)
 	}
 
 	// Apply button dimensions
@@ -202,15 +360,45 @@ func (bg *ButtonGrid) getButtonStyle(button *components.Button) lipgloss.Style {
 	return style
 }
 
+// SetHoverReporter sets the source of hover state used when rendering buttons
+func (bg *ButtonGrid) SetHoverReporter(reporter HoverReporter) {
+	bg.hoverReporter = reporter
+}
+
+// IsButtonHovered checks whether the hover reporter reports the given button as hovered
+func (bg *ButtonGrid) IsButtonHovered(buttonID string) bool {
+	button, exists := bg.buttons[buttonID]
+	return exists && bg.isButtonHovered(button)
+}
+
+// isButtonHovered checks whether the hover reporter reports the button as hovered
+func (bg *ButtonGrid) isButtonHovered(button *components.Button) bool {
+	if bg.hoverReporter == nil {
+		return false
+	}
+	position := button.GetPosition()
+	return bg.hoverReporter.IsHovering(bg.generateButtonID(position.Row, position.Column))
+}
+
 // Render renders the entire button grid
 func (bg *ButtonGrid) Render(termWidth int) string {
 	// Update grid styling based on current theme
 	bg.updateGridStyling()
+	bg.updateCellStyling()
 
 	// Render the grid
 	return bg.grid.Render(termWidth)
 }
 
+// RenderTo renders the entire button grid to w, a row at a time, giving the
+// same output as Render without building it all in memory first
+func (bg *ButtonGrid) RenderTo(w io.Writer, termWidth int) error {
+	bg.updateGridStyling()
+	bg.updateCellStyling()
+
+	return bg.grid.RenderTo(w, termWidth)
+}
+
 // updateGridStyling updates the grid layout with current theme styling
 func (bg *ButtonGrid) updateGridStyling() {
 	theme := bg.themeManager.GetCurrentTheme()
@@ -222,6 +410,26 @@ func (bg *ButtonGrid) updateGridStyling() {
 		WithPressedStyle(theme.Styles.Grid.CellPressed)
 }
 
+// updateCellStyling refreshes each cell's style from its button's current state and
+// draws the focus ring around the focused button
+func (bg *ButtonGrid) updateCellStyling() {
+	focusRing := bg.themeManager.GetFocusRing()
+
+	for buttonID, button := range bg.buttons {
+		position := button.GetPosition()
+		label := button.GetLabel()
+		style := bg.getButtonStyle(button)
+
+		// Draw the theme's focus ring around the focused button
+		if buttonID == bg.focusedButton {
+			label = focusRing.Wrap(label)
+			style = focusRing.Style.Inherit(style)
+		}
+
+		bg.grid.AddSpannedCell(position.Column, position.Row, button.GetSpan(), label, style)
+	}
+}
+
 // HandleKeyPress handles keyboard input for button navigation and activation
 func (bg *ButtonGrid) HandleKeyPress(msg tea.KeyMsg) *ButtonAction {
 	switch msg.Type {
@@ -244,12 +452,35 @@ func (bg *ButtonGrid) HandleKeyPress(msg tea.KeyMsg) *ButtonAction {
 	return nil
 }
 
+// TypeExpression feeds each character of an expression through HandleKeyPress
+// and returns the resulting actions in order. Unmapped characters are skipped.
+func (bg *ButtonGrid) TypeExpression(expr string) []*ButtonAction {
+	var actions []*ButtonAction
+
+	for _, char := range expr {
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{char}}
+		if action := bg.HandleKeyPress(msg); action != nil {
+			actions = append(actions, action)
+		}
+	}
+
+	return actions
+}
+
 // HandleMouse handles mouse input for button interaction
 func (bg *ButtonGrid) HandleMouse(msg tea.MouseMsg) *ButtonAction {
 	if msg.Type != tea.MouseLeft {
 		return nil
 	}
 
+	// Prefer the registered screen bounds when available
+	if len(bg.buttonBounds) > 0 {
+		if buttonID, found := bg.ButtonAt(msg.X, msg.Y); found {
+			return bg.activateButton(buttonID)
+		}
+		return nil
+	}
+
 	// Find which button was clicked
 	cellWidth, _ := bg.grid.CalculateDimensions(80) // Use default width for calculation
 	col, row, found := bg.grid.GetCellAtPosition(msg.X, msg.Y, cellWidth)
@@ -262,6 +493,55 @@ func (bg *ButtonGrid) HandleMouse(msg tea.MouseMsg) *ButtonAction {
 	return nil
 }
 
+// ComputeButtonBounds computes the clickable area of every button for the given
+// terminal width, keyed by button ID, so mouse handlers can register accurate regions
+func (bg *ButtonGrid) ComputeButtonBounds(width int) map[string]ButtonRect {
+	bounds := make(map[string]ButtonRect, len(bg.buttons))
+	cellWidth, _ := bg.grid.CalculateDimensions(width)
+	_, cellHeight := bg.grid.GetCellSize()
+
+	for buttonID, button := range bg.buttons {
+		position := button.GetPosition()
+		x, y := bg.grid.GetCellPosition(position.Column, position.Row, cellWidth)
+		bounds[buttonID] = ButtonRect{
+			X:      x,
+			Y:      y,
+			Width:  bg.grid.SpannedWidth(cellWidth, button.GetSpan()),
+			Height: cellHeight,
+		}
+	}
+
+	return bounds
+}
+
+// RegisterButtonBounds replaces the registered clickable areas used for mouse hit testing
+func (bg *ButtonGrid) RegisterButtonBounds(bounds map[string]ButtonRect) {
+	bg.buttonBounds = make(map[string]ButtonRect, len(bounds))
+	for buttonID, rect := range bounds {
+		bg.buttonBounds[buttonID] = rect
+	}
+}
+
+// ClearButtonBounds removes all registered clickable areas
+func (bg *ButtonGrid) ClearButtonBounds() {
+	bg.buttonBounds = nil
+}
+
+// GetButtonBounds returns the registered clickable areas keyed by button ID
+func (bg *ButtonGrid) GetButtonBounds() map[string]ButtonRect {
+	return bg.buttonBounds
+}
+
+// ButtonAt returns the ID of the registered button at the given screen position
+func (bg *ButtonGrid) ButtonAt(x, y int) (string, bool) {
+	for buttonID, rect := range bg.buttonBounds {
+		if rect.Contains(x, y) {
+			return buttonID, true
+		}
+	}
+	return "", false
+}
+
 // navigateButtons handles keyboard navigation between buttons
 func (bg *ButtonGrid) navigateButtons(keyType tea.KeyType) *ButtonAction {
 	if bg.focusedButton == "" {
@@ -282,7 +562,11 @@ func (bg *ButtonGrid) navigateButtons(keyType tea.KeyType) *ButtonAction {
 	case tea.KeyLeft:
 		newRow, newCol = currentRow, currentCol-1
 	case tea.KeyRight:
+		// Step past every column a spanned button covers
 		newRow, newCol = currentRow, currentCol+1
+		if currentButton, exists := bg.buttons[bg.focusedButton]; exists {
+			newCol = currentCol + currentButton.GetSpan()
+		}
 	}
 
 	// Check if new position is valid
@@ -292,8 +576,11 @@ func (bg *ButtonGrid) navigateButtons(keyType tea.KeyType) *ButtonAction {
 			currentButton.Blur()
 		}
 
-		// Focus new button
+		// Focus new button, which is a spanned one for any column it covers
 		bg.focusedButton = bg.generateButtonID(newRow, newCol)
+		if buttonID, exists := bg.buttonIDAt(newCol, newRow); exists {
+			bg.focusedButton = buttonID
+		}
 		if newButton, exists := bg.buttons[bg.focusedButton]; exists {
 			newButton.Focus()
 		}
@@ -304,26 +591,44 @@ func (bg *ButtonGrid) navigateButtons(keyType tea.KeyType) *ButtonAction {
 
 // handleDirectInput handles direct keyboard input for numbers and operators
 func (bg *ButtonGrid) handleDirectInput(char string) *ButtonAction {
-	// Map direct input to buttons
-	inputMap := map[string]string{
-		"0": "button_4_0", "1": "button_3_0", "2": "button_3_1", "3": "button_3_2",
-		"4": "button_2_0", "5": "button_2_1", "6": "button_2_2", "7": "button_1_0",
-		"8": "button_1_1", "9": "button_1_2", ".": "button_4_1",
-		"+": "button_3_3", "-": "button_2_3", "*": "button_1_3", "/": "button_0_3",
-		"=": "button_4_2",
+	// Map direct input to the button carrying that value, wherever the layout placed it
+	if !isDirectInputKey(char) {
+		return nil
 	}
 
-	if buttonID, exists := inputMap[char]; exists {
+	if buttonID, exists := bg.findButtonByValue(char); exists {
 		return bg.activateButton(buttonID)
 	}
 
 	return nil
 }
 
+// isDirectInputKey reports whether a key types its value directly into the grid
+func isDirectInputKey(char string) bool {
+	switch char {
+	case "0", "1", "2", "3", "4", "5", "6", "7", "8", "9", ".",
+		"+", "-", "*", "/", "=", ",":
+		return true
+	}
+	return false
+}
+
+// findButtonByValue returns the ID of the button with the given value. When
+// several buttons share it, the first in layout order wins.
+func (bg *ButtonGrid) findButtonByValue(value string) (string, bool) {
+	for _, def := range bg.layoutDefinitions() {
+		buttonID := bg.generateButtonID(def.Row, def.Column)
+		if button, exists := bg.buttons[buttonID]; exists && button.GetValue() == value {
+			return buttonID, true
+		}
+	}
+	return "", false
+}
+
 // activateButton activates a button and returns the corresponding action
 func (bg *ButtonGrid) activateButton(buttonID string) *ButtonAction {
 	button, exists := bg.buttons[buttonID]
-	if !exists {
+	if !exists || !button.IsInteractive() {
 		return nil
 	}
 
@@ -344,6 +649,74 @@ func (bg *ButtonGrid) activateButton(buttonID string) *ButtonAction {
 	return action
 }
 
+// SelfTest activates every button and verifies it against the layout definition
+// for its position: the action must carry the defined label and value, and keys
+// that type a value directly must reach the same button. Any anomalies are
+// returned; the focus and button states are restored afterwards.
+func (bg *ButtonGrid) SelfTest() []error {
+	var errs []error
+
+	previousFocus := bg.focusedButton
+	defined := make(map[string]bool)
+
+	for _, def := range bg.layoutDefinitions() {
+		buttonID := bg.generateButtonID(def.Row, def.Column)
+		defined[buttonID] = true
+
+		button, exists := bg.buttons[buttonID]
+		if !exists {
+			errs = append(errs, fmt.Errorf("button %s (%s) is missing", buttonID, def.Label))
+			continue
+		}
+
+		action := bg.activateButton(buttonID)
+		switch {
+		case action == nil:
+			errs = append(errs, fmt.Errorf("button %s (%s) produced no action", buttonID, def.Label))
+		case action.Button.GetLabel() != def.Label:
+			errs = append(errs, fmt.Errorf("button %s has label %q, expected %q",
+				buttonID, action.Button.GetLabel(), def.Label))
+		case action.Value != def.Value:
+			errs = append(errs, fmt.Errorf("button %s (%s) produced value %q, expected %q",
+				buttonID, def.Label, action.Value, def.Value))
+		}
+		button.Release()
+
+		// Keys that type the value directly must reach this button
+		if action := bg.handleDirectInput(def.Value); action != nil {
+			if action.ButtonID != buttonID {
+				errs = append(errs, fmt.Errorf("key %q reached button %s, expected %s (%s)",
+					def.Value, action.ButtonID, buttonID, def.Label))
+			}
+			action.Button.Release()
+		} else if isDirectInputKey(def.Value) {
+			errs = append(errs, fmt.Errorf("key %q reached no button, expected %s (%s)",
+				def.Value, buttonID, def.Label))
+		}
+	}
+
+	// Buttons that the layout does not define are wired to nothing
+	buttonIDs := make([]string, 0, len(bg.buttons))
+	for buttonID := range bg.buttons {
+		if !defined[buttonID] {
+			buttonIDs = append(buttonIDs, buttonID)
+		}
+	}
+	sort.Strings(buttonIDs)
+	for _, buttonID := range buttonIDs {
+		errs = append(errs, fmt.Errorf("button %s (%s) is not in the layout",
+			buttonID, bg.buttons[buttonID].GetLabel()))
+	}
+
+	// Restore the focus that was active before the self-test
+	bg.focusedButton = previousFocus
+	if button, exists := bg.buttons[previousFocus]; exists {
+		button.Focus()
+	}
+
+	return errs
+}
+
 // isValidPosition checks if a grid position is valid
 func (bg *ButtonGrid) isValidPosition(col, row int) bool {
 	return col >= 0 && col < bg.dimensions.Columns &&
@@ -364,6 +737,22 @@ func (bg *ButtonGrid) GetFocusedButton() (*components.Button, bool) {
 	return bg.GetButton(bg.focusedButton)
 }
 
+// FocusValue moves focus to the button with the given value, reporting
+// whether such a button exists
+func (bg *ButtonGrid) FocusValue(value string) bool {
+	buttonID, exists := bg.findButtonByValue(value)
+	if !exists {
+		return false
+	}
+
+	if current, exists := bg.buttons[bg.focusedButton]; exists {
+		current.Blur()
+	}
+	bg.focusedButton = buttonID
+	bg.buttons[buttonID].Focus()
+	return true
+}
+
 // GetButtonCount returns the total number of buttons in the grid
 func (bg *ButtonGrid) GetButtonCount() int {
 	return len(bg.buttons)
@@ -374,6 +763,75 @@ func (bg *ButtonGrid) GetButtons() map[string]*components.Button {
 	return bg.buttons
 }
 
+// Manifest returns a description of every button, ordered by row then column
+func (bg *ButtonGrid) Manifest() []ButtonInfo {
+	manifest := make([]ButtonInfo, 0, len(bg.buttons))
+	for buttonID, button := range bg.buttons {
+		manifest = append(manifest, ButtonInfo{
+			ID:       buttonID,
+			Position: button.GetPosition(),
+			Label:    button.GetLabel(),
+			Value:    button.GetValue(),
+			Type:     button.GetType(),
+		})
+	}
+
+	sort.Slice(manifest, func(i, j int) bool {
+		if manifest[i].Position.Row != manifest[j].Position.Row {
+			return manifest[i].Position.Row < manifest[j].Position.Row
+		}
+		return manifest[i].Position.Column < manifest[j].Position.Column
+	})
+
+	return manifest
+}
+
+// ExportLayoutASCII returns a plain text diagram of the button layout, one
+// boxed cell per grid position with its label centered, for embedding in
+// documentation. A spanned button is one box across the columns it covers.
+// It uses no theme styling, so it is the same for every theme.
+func (bg *ButtonGrid) ExportLayoutASCII() string {
+	labels := make([][]string, bg.dimensions.Rows)
+	spans := make([][]int, bg.dimensions.Rows)
+	for row := range labels {
+		labels[row] = make([]string, bg.dimensions.Columns)
+		spans[row] = make([]int, bg.dimensions.Columns)
+	}
+
+	// Every cell is as wide as the widest label, with a space either side
+	cellWidth := 0
+	for _, info := range bg.Manifest() {
+		if info.Position.Row >= bg.dimensions.Rows || info.Position.Column >= bg.dimensions.Columns {
+			continue
+		}
+		labels[info.Position.Row][info.Position.Column] = info.Label
+		spans[info.Position.Row][info.Position.Column] = bg.buttons[info.ID].GetSpan()
+		if width := lipgloss.Width(info.Label); width > cellWidth {
+			cellWidth = width
+		}
+	}
+	cellWidth += 2
+
+	separator := "+" + strings.Repeat(strings.Repeat("-", cellWidth)+"+", bg.dimensions.Columns) + "\n"
+
+	var builder strings.Builder
+	builder.WriteString(separator)
+	for rowIdx, row := range labels {
+		builder.WriteString("|")
+		for col := 0; col < len(row); col++ {
+			label, span := row[col], max(spans[rowIdx][col], 1)
+			padding := span*(cellWidth+1) - 1 - lipgloss.Width(label)
+			left := padding / 2
+			builder.WriteString(strings.Repeat(" ", left) + label + strings.Repeat(" ", padding-left) + "|")
+			col += span - 1
+		}
+		builder.WriteString("\n")
+		builder.WriteString(separator)
+	}
+
+	return builder.String()
+}
+
 // SetTheme changes the theme of the button grid
 func (bg *ButtonGrid) SetTheme(themeName string) error {
 	err := bg.themeManager.SetTheme(themeName)
@@ -387,11 +845,63 @@ func (bg *ButtonGrid) SetTheme(themeName string) error {
 	return nil
 }
 
+// LoadThemeFile loads a custom theme from a JSON or YAML file so SetTheme
+// can select it, returning its name. The current theme is unchanged.
+func (bg *ButtonGrid) LoadThemeFile(path string) (string, error) {
+	theme, err := bg.themeManager.LoadThemeFromFile(path)
+	if err != nil {
+		return "", err
+	}
+	return theme.Name, nil
+}
+
+// SetOperatorPosition moves the operator buttons and rebuilds the layout.
+// Focus returns to the top-left button.
+func (bg *ButtonGrid) SetOperatorPosition(position OperatorPosition) {
+	bg.operatorPosition = position
+	bg.initializeCalculatorLayout()
+}
+
+// SetBorderless sets whether buttons are drawn without borders to save space
+func (bg *ButtonGrid) SetBorderless(borderless bool) {
+	bg.grid.WithBorderless(borderless)
+}
+
+// IsBorderless returns whether buttons are drawn without borders
+func (bg *ButtonGrid) IsBorderless() bool {
+	return bg.grid.IsBorderless()
+}
+
+// GetOperatorPosition returns where the operator buttons are placed
+func (bg *ButtonGrid) GetOperatorPosition() OperatorPosition {
+	return bg.operatorPosition
+}
+
 // GetCurrentTheme returns the current theme name
 func (bg *ButtonGrid) GetCurrentTheme() string {
 	return bg.themeManager.GetCurrentTheme().Name
 }
 
+// GetThemeBackground returns the background color of the current theme
+func (bg *ButtonGrid) GetThemeBackground() lipgloss.Color {
+	return bg.themeManager.GetCurrentTheme().Colors.GetBackground()
+}
+
+// GetLoaderStyles returns the loader animation frames of the current theme,
+// which may be empty
+func (bg *ButtonGrid) GetLoaderStyles() []lipgloss.Style {
+	theme := bg.themeManager.GetCurrentTheme()
+	if theme.Styles == nil {
+		return nil
+	}
+	return theme.Styles.Animation.Loader
+}
+
+// ListThemes returns the names of the available themes
+func (bg *ButtonGrid) ListThemes() []string {
+	return bg.themeManager.ListThemes()
+}
+
 // GetDimensions returns the grid dimensions
 func (bg *ButtonGrid) GetDimensions() GridDimensions {
 	return bg.dimensions
@@ -1,13 +1,20 @@
 package integration
 
 import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"ccpm-demo/internal/ui/components"
+	"ccpm-demo/internal/ui/styles"
 )
 
 func TestNewButtonGrid(t *testing.T) {
@@ -22,11 +29,11 @@ func TestNewButtonGrid(t *testing.T) {
 
 		// Check dimensions
 		assert.Equal(t, 4, grid.dimensions.Columns)
-		assert.Equal(t, 5, grid.dimensions.Rows)
+		assert.Equal(t, 6, grid.dimensions.Rows)
 
 		// Check that buttons were created
 		assert.Greater(t, len(grid.buttons), 0)
-		assert.Equal(t, 19, len(grid.buttons)) // 19 button definitions in the layout
+		assert.Equal(t, 24, len(grid.buttons)) // 24 button definitions in the layout
 
 		// Check default theme
 		assert.Equal(t, "retro-casio", grid.GetCurrentTheme())
@@ -61,6 +68,7 @@ func TestButtonGridInitialization(t *testing.T) {
 			{"button_1_3", "×", components.TypeOperator, "*"},
 			{"button_4_0", "0", components.TypeNumber, "0"},
 			{"button_4_2", "=", components.TypeSpecial, "="},
+			{"button_4_3", "±", components.TypeSpecial, "negate"},
 		}
 
 		for _, test := range tests {
@@ -219,10 +227,10 @@ func TestButtonGridButtonManagement(t *testing.T) {
 
 	t.Run("returns button count", func(t *testing.T) {
 		grid := NewButtonGrid()
-		assert.Equal(t, 19, grid.GetButtonCount())
+		assert.Equal(t, 24, grid.GetButtonCount())
 
 		allButtons := grid.GetButtons()
-		assert.Equal(t, 19, len(allButtons))
+		assert.Equal(t, 24, len(allButtons))
 		assert.Equal(t, grid.buttons, allButtons)
 	})
 
@@ -230,7 +238,7 @@ func TestButtonGridButtonManagement(t *testing.T) {
 		grid := NewButtonGrid()
 		dims := grid.GetDimensions()
 		assert.Equal(t, 4, dims.Columns)
-		assert.Equal(t, 5, dims.Rows)
+		assert.Equal(t, 6, dims.Rows)
 	})
 }
 
@@ -303,7 +311,7 @@ func TestButtonGridPositionValidation(t *testing.T) {
 		assert.False(t, grid.isValidPosition(-1, 0))
 		assert.False(t, grid.isValidPosition(0, -1))
 		assert.False(t, grid.isValidPosition(4, 0))  // Beyond column limit
-		assert.False(t, grid.isValidPosition(0, 5))  // Beyond row limit
+		assert.False(t, grid.isValidPosition(0, 6))  // Beyond row limit
 		assert.False(t, grid.isValidPosition(10, 10)) // Way beyond limits
 	})
 }
@@ -314,8 +322,8 @@ func TestButtonGridStringRepresentation(t *testing.T) {
 
 		str := grid.String()
 		assert.Contains(t, str, "ButtonGrid")
-		assert.Contains(t, str, "4x5")
-		assert.Contains(t, str, "19") // Button count
+		assert.Contains(t, str, "4x6")
+		assert.Contains(t, str, "24") // Button count
 		assert.Contains(t, str, "retro-casio") // Theme
 		assert.Contains(t, str, "button_0_0") // Initial focus
 	})
@@ -360,6 +368,673 @@ func TestButtonGridActionCreation(t *testing.T) {
 	})
 }
 
+func TestButtonGridSelfTest(t *testing.T) {
+	t.Run("default grid passes the self-test", func(t *testing.T) {
+		grid := NewButtonGrid()
+
+		errs := grid.SelfTest()
+		assert.Empty(t, errs)
+	})
+
+	t.Run("bottom operator layout passes the self-test", func(t *testing.T) {
+		grid := NewButtonGrid()
+		grid.SetOperatorPosition(OperatorsBottom)
+
+		assert.Empty(t, grid.SelfTest())
+	})
+
+	t.Run("reports a button wired to the wrong value", func(t *testing.T) {
+		grid := NewButtonGrid()
+		grid.buttons["button_2_1"] = components.NewButton(components.ButtonConfig{
+			Label:    "5",
+			Type:     components.TypeNumber,
+			Value:    "6",
+			Position: components.Position{Row: 2, Column: 1},
+		})
+
+		errs := grid.SelfTest()
+		require.NotEmpty(t, errs)
+
+		var messages []string
+		for _, err := range errs {
+			messages = append(messages, err.Error())
+		}
+		assert.Contains(t, messages, `button button_2_1 (5) produced value "6", expected "5"`)
+		assert.Contains(t, messages, `key "5" reached no button, expected button_2_1 (5)`)
+	})
+
+	t.Run("reports a button missing from the grid", func(t *testing.T) {
+		grid := NewButtonGrid()
+		delete(grid.buttons, "button_4_2")
+
+		errs := grid.SelfTest()
+		require.Len(t, errs, 1)
+		assert.EqualError(t, errs[0], "button button_4_2 (=) is missing")
+	})
+
+	t.Run("restores focus after the self-test", func(t *testing.T) {
+		grid := NewButtonGrid()
+
+		grid.SelfTest()
+
+		focusedButton, exists := grid.GetFocusedButton()
+		require.True(t, exists)
+		assert.Equal(t, "C", focusedButton.GetLabel())
+		assert.True(t, focusedButton.IsFocused())
+	})
+}
+
+func TestButtonGridTypeExpression(t *testing.T) {
+	t.Run("returns actions in typing order", func(t *testing.T) {
+		grid := NewButtonGrid()
+
+		actions := grid.TypeExpression("12+3=")
+
+		var values []string
+		for _, action := range actions {
+			values = append(values, action.Value)
+		}
+		assert.Equal(t, []string{"1", "2", "+", "3", "="}, values)
+	})
+
+	t.Run("skips unmapped characters", func(t *testing.T) {
+		grid := NewButtonGrid()
+
+		actions := grid.TypeExpression("7 x?9")
+
+		require.Len(t, actions, 2)
+		assert.Equal(t, "7", actions[0].Value)
+		assert.Equal(t, "9", actions[1].Value)
+	})
+}
+
+func TestButtonGridOperatorPosition(t *testing.T) {
+	operatorLabels := func(grid *ButtonGrid) map[string]string {
+		positions := make(map[string]string)
+		for buttonID, button := range grid.GetButtons() {
+			if button.GetType() == components.TypeOperator {
+				positions[button.GetLabel()] = buttonID
+			}
+		}
+		return positions
+	}
+
+	t.Run("defaults to the right column", func(t *testing.T) {
+		grid := NewButtonGrid()
+
+		assert.Equal(t, OperatorsRight, grid.GetOperatorPosition())
+		assert.Equal(t, map[string]string{
+			"÷": "button_0_3", "×": "button_1_3", "-": "button_2_3", "+": "button_3_3",
+		}, operatorLabels(grid))
+	})
+
+	t.Run("bottom row places operators along the last row", func(t *testing.T) {
+		grid := NewButtonGrid()
+		grid.SetOperatorPosition(OperatorsBottom)
+
+		assert.Equal(t, OperatorsBottom, grid.GetOperatorPosition())
+		assert.Len(t, grid.GetButtons(), 24)
+		assert.Equal(t, map[string]string{
+			"÷": "button_5_0", "×": "button_5_1", "-": "button_5_2", "+": "button_5_3",
+		}, operatorLabels(grid))
+
+		focused, exists := grid.GetFocusedButton()
+		require.True(t, exists)
+		assert.Equal(t, "C", focused.GetLabel())
+	})
+
+	t.Run("direct input follows the layout", func(t *testing.T) {
+		grid := NewButtonGrid()
+		grid.SetOperatorPosition(OperatorsBottom)
+
+		actions := grid.TypeExpression("7+0.5=")
+
+		var values []string
+		for _, action := range actions {
+			values = append(values, action.Value)
+		}
+		assert.Equal(t, []string{"7", "+", "0", ".", "5", "="}, values)
+		assert.Equal(t, "button_5_3", actions[1].ButtonID)
+	})
+
+	for _, position := range []OperatorPosition{OperatorsRight, OperatorsBottom} {
+		t.Run("navigation reaches every button with operators "+position.String(), func(t *testing.T) {
+			grid := NewButtonGrid()
+			grid.SetOperatorPosition(position)
+
+			visited := make(map[string]bool)
+			visit := func() {
+				if button, exists := grid.GetFocusedButton(); exists {
+					visited[button.GetLabel()] = true
+				}
+			}
+
+			// Snake through the grid: across each row, then down one
+			dims := grid.GetDimensions()
+			for row := 0; row < dims.Rows; row++ {
+				direction := tea.KeyRight
+				if row%2 == 1 {
+					direction = tea.KeyLeft
+				}
+				visit()
+				for col := 1; col < dims.Columns; col++ {
+					grid.HandleKeyPress(tea.KeyMsg{Type: direction})
+					visit()
+				}
+				grid.HandleKeyPress(tea.KeyMsg{Type: tea.KeyDown})
+			}
+
+			for _, button := range grid.GetButtons() {
+				assert.True(t, visited[button.GetLabel()], "button %s not reachable", button.GetLabel())
+			}
+		})
+	}
+}
+
+func TestButtonGridManifest(t *testing.T) {
+	t.Run("lists every button in the default grid", func(t *testing.T) {
+		grid := NewButtonGrid()
+
+		manifest := grid.Manifest()
+
+		// 10 digits, decimal point, 4 operators, equals, C, CE, backspace, ±, 1/x, x², x³ and ANS
+		require.Len(t, manifest, 24)
+		assert.Equal(t, grid.GetButtonCount(), len(manifest))
+
+		var values []string
+		for _, info := range manifest {
+			values = append(values, info.Value)
+		}
+		assert.Equal(t, []string{
+			"clear", "clear_entry", "backspace", "/",
+			"7", "8", "9", "*",
+			"4", "5", "6", "-",
+			"1", "2", "3", "+",
+			"0", ".", "=", "negate",
+			"reciprocal", "square", "cube", "ans",
+		}, values)
+	})
+
+	t.Run("classifies button types", func(t *testing.T) {
+		grid := NewButtonGrid()
+
+		counts := make(map[components.ButtonType]int)
+		for _, info := range grid.Manifest() {
+			counts[info.Type]++
+
+			switch info.Value {
+			case "+", "-", "*", "/":
+				assert.Equal(t, components.TypeOperator, info.Type, info.Label)
+			case "clear", "clear_entry", "backspace", "=", "negate", "reciprocal", "square", "cube", "ans":
+				assert.Equal(t, components.TypeSpecial, info.Type, info.Label)
+			default:
+				assert.Equal(t, components.TypeNumber, info.Type, info.Label)
+			}
+		}
+
+		assert.Equal(t, 11, counts[components.TypeNumber])
+		assert.Equal(t, 4, counts[components.TypeOperator])
+		assert.Equal(t, 9, counts[components.TypeSpecial])
+	})
+
+	t.Run("includes positions and labels", func(t *testing.T) {
+		grid := NewButtonGrid()
+
+		manifest := grid.Manifest()
+
+		assert.Equal(t, "button_0_0", manifest[0].ID)
+		assert.Equal(t, components.Position{Row: 0, Column: 0}, manifest[0].Position)
+		assert.Equal(t, "C", manifest[0].Label)
+		assert.Equal(t, components.Position{Row: 4, Column: 2}, manifest[18].Position)
+		assert.Equal(t, "=", manifest[18].Label)
+	})
+}
+
+func TestButtonGridFocusRing(t *testing.T) {
+	for _, theme := range []string{"retro-casio", "retro-casio-cb", "modern", "minimal", "classic"} {
+		t.Run(theme, func(t *testing.T) {
+			grid := NewButtonGrid()
+			require.NoError(t, grid.SetTheme(theme))
+
+			ring := grid.themeManager.GetFocusRing()
+			require.False(t, ring.IsEmpty(), "every theme should have a visible focus ring")
+
+			// Focus starts on C; 7 is unfocused
+			before := grid.Render(80)
+			assert.Contains(t, before, ring.Wrap("C"))
+			assert.NotContains(t, before, ring.Wrap("7"))
+
+			grid.HandleKeyPress(tea.KeyMsg{Type: tea.KeyDown})
+
+			after := grid.Render(80)
+			assert.Contains(t, after, ring.Wrap("7"))
+			assert.NotContains(t, after, ring.Wrap("C"))
+			assert.NotEqual(t, before, after)
+		})
+	}
+
+	t.Run("minimal theme uses an ASCII ring", func(t *testing.T) {
+		grid := NewButtonGrid()
+		require.NoError(t, grid.SetTheme("minimal"))
+
+		assert.Equal(t, ">7<", grid.themeManager.GetFocusRing().Wrap("7"))
+	})
+}
+
+func TestButtonGridThemesAreDistinct(t *testing.T) {
+	themes := []string{"retro-casio", "modern", "minimal", "classic"}
+	numberColors := make(map[string]lipgloss.TerminalColor)
+
+	for _, theme := range themes {
+		t.Run(theme, func(t *testing.T) {
+			grid, err := NewButtonGridWithTheme(theme)
+			require.NoError(t, err)
+
+			// Every style group is populated, so no theme renders unstyled
+			styles := grid.themeManager.GetCurrentTheme().Styles
+			for _, buttonType := range []string{"number", "operator", "special"} {
+				for _, state := range []string{"normal", "focused", "pressed", "disabled", "hover"} {
+					style := grid.themeManager.GetButtonStyle(buttonType, state)
+					assert.NotEqual(t, lipgloss.NoColor{}, style.GetForeground(), "%s %s button needs a color", buttonType, state)
+				}
+			}
+			assert.NotEqual(t, lipgloss.NoColor{}, styles.Display.Main.GetForeground())
+			assert.NotEqual(t, lipgloss.NoColor{}, styles.Text.Error.GetForeground())
+			assert.NotEqual(t, lipgloss.NoColor{}, styles.Grid.Container.GetBackground())
+			assert.NotEmpty(t, styles.Animation.Loader)
+			assert.NotEmpty(t, styles.Animation.ButtonPress)
+
+			rendering := grid.Render(80)
+			for _, label := range []string{"C", "7", "0", "="} {
+				assert.Contains(t, rendering, label)
+			}
+			numberColors[theme] = grid.themeManager.GetButtonStyle("number", "normal").GetForeground()
+		})
+	}
+
+	// Each theme has its own palette
+	for i, theme := range themes {
+		for _, other := range themes[i+1:] {
+			assert.NotEqual(t, numberColors[theme], numberColors[other],
+				"%s and %s should color number buttons differently", theme, other)
+		}
+	}
+
+	// Minimal buttons are flat: no border and no resting background
+	grid, err := NewButtonGridWithTheme("minimal")
+	require.NoError(t, err)
+	normal := grid.themeManager.GetButtonStyle("number", "normal")
+	assert.Equal(t, lipgloss.NoColor{}, normal.GetBackground())
+	assert.False(t, normal.GetBorderTop() || normal.GetBorderLeft())
+}
+
+func TestButtonGridColorblindTheme(t *testing.T) {
+	grid, err := NewButtonGridWithTheme("retro-casio-cb")
+	require.NoError(t, err)
+	assert.Equal(t, "retro-casio-cb", grid.GetCurrentTheme())
+
+	// Each button type has a luminance of its own, so none relies on hue alone
+	luminance := func(buttonType string) float64 {
+		background, ok := grid.themeManager.GetButtonStyle(buttonType, "normal").GetBackground().(lipgloss.Color)
+		require.True(t, ok)
+		return styles.Luminance(background)
+	}
+	number, operator, special := luminance("number"), luminance("operator"), luminance("special")
+	assert.Greater(t, operator-number, 0.2, "operators should be much brighter than numbers")
+	assert.Greater(t, operator-special, 0.2, "operators should be much brighter than special keys")
+	assert.Greater(t, special-number, 0.1, "special keys should be brighter than numbers")
+
+	assert.InDelta(t, 0, styles.Luminance("16"), 0.001)
+	assert.InDelta(t, 1, styles.Luminance("#ffffff"), 0.001)
+	assert.Zero(t, styles.Luminance("orange"))
+}
+
+func TestButtonGridTrueColor(t *testing.T) {
+	t.Run("uses 256-color codes by default", func(t *testing.T) {
+		t.Setenv("COLORTERM", "")
+		grid := NewButtonGrid()
+
+		assert.False(t, grid.themeManager.IsTrueColor())
+		theme := grid.themeManager.GetCurrentTheme()
+		assert.Equal(t, lipgloss.Color("208"), theme.Colors.OperatorColors.Normal.Background)
+	})
+
+	t.Run("uses hex colors on truecolor terminals", func(t *testing.T) {
+		t.Setenv("COLORTERM", "truecolor")
+		grid := NewButtonGrid()
+
+		assert.True(t, grid.themeManager.IsTrueColor())
+		theme := grid.themeManager.GetCurrentTheme()
+		assert.Equal(t, lipgloss.Color("#ff8800"), theme.Colors.OperatorColors.Normal.Background)
+		assert.Equal(t, lipgloss.Color("#292723"), theme.Colors.Background)
+		assert.Equal(t, lipgloss.Color("15"), theme.Colors.NumberColors.Normal.Foreground, "colors without a hex value are kept")
+	})
+
+	t.Run("each 256-color code is the nearest to its hex color", func(t *testing.T) {
+		for code, hex := range styles.NewColorPalette().TrueColors {
+			assert.Equal(t, code, styles.NearestANSI256(hex), "%s should fall back to %s", hex, code)
+		}
+		assert.Equal(t, lipgloss.Color("15"), styles.NearestANSI256("15"))
+		assert.Equal(t, lipgloss.Color("16"), styles.NearestANSI256("#000000"))
+		assert.Equal(t, lipgloss.Color("231"), styles.NearestANSI256("#FFFFFF"))
+	})
+
+	t.Run("each palette has its own truecolor map", func(t *testing.T) {
+		palette := styles.NewColorPalette()
+		palette.TrueColors["235"] = "#000000"
+		assert.Equal(t, lipgloss.Color("#292723"), styles.NewColorPalette().TrueColors["235"])
+	})
+
+	t.Run("hex colors in theme files fall back without truecolor", func(t *testing.T) {
+		path := writeThemeFile(t, "sunset.json", themeFileJSON)
+
+		t.Setenv("COLORTERM", "")
+		grid := NewButtonGrid()
+		theme, err := grid.themeManager.LoadThemeFromFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, lipgloss.Color("234"), theme.Colors.Background)
+
+		t.Setenv("COLORTERM", "24bit")
+		grid = NewButtonGrid()
+		theme, err = grid.themeManager.LoadThemeFromFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, lipgloss.Color("#1c1c1c"), theme.Colors.Background)
+	})
+}
+
+func TestButtonGridRenderTo(t *testing.T) {
+	// A borderless 4x2 grid, whose output matches the rows joined with
+	// lipgloss.JoinVertical as Render drew them before streaming
+	newSmallGrid := func() *ButtonGrid {
+		grid, err := NewButtonGridWithLayout([]ButtonDefinition{
+			{Label: "7", Value: "7", Type: components.TypeNumber, Row: 0, Column: 0, Width: 3, Height: 1},
+			{Label: "8", Value: "8", Type: components.TypeNumber, Row: 0, Column: 1, Width: 3, Height: 1},
+			{Label: "9", Value: "9", Type: components.TypeNumber, Row: 0, Column: 2, Width: 3, Height: 1},
+			{Label: "+", Value: "+", Type: components.TypeOperator, Row: 0, Column: 3, Width: 3, Height: 1},
+			{Label: "4", Value: "4", Type: components.TypeNumber, Row: 1, Column: 0, Width: 3, Height: 1},
+			{Label: "5", Value: "5", Type: components.TypeNumber, Row: 1, Column: 1, Width: 3, Height: 1},
+			{Label: "6", Value: "6", Type: components.TypeNumber, Row: 1, Column: 2, Width: 3, Height: 1},
+			{Label: "-", Value: "-", Type: components.TypeOperator, Row: 1, Column: 3, Width: 3, Height: 1},
+		})
+		require.NoError(t, err)
+		grid.grid.WithDimensions(4, 2)
+		grid.SetBorderless(true)
+		return grid
+	}
+
+	t.Run("writes the padded rows", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, newSmallGrid().RenderTo(&buf, 40))
+		assert.Equal(t, strings.Join([]string{
+			"                                     ",
+			"   ▶7◀       8        9        +     ",
+			"    4        5        6        -     ",
+			"                                     ",
+		}, "\n"), buf.String())
+	})
+
+	t.Run("writes the offset as margins", func(t *testing.T) {
+		grid := newSmallGrid()
+		grid.grid.WithOffset(2, 1)
+
+		var buf bytes.Buffer
+		require.NoError(t, grid.RenderTo(&buf, 40))
+		assert.Equal(t, strings.Join([]string{
+			"                                       ",
+			"                                       ",
+			"     ▶7◀       8        9        +     ",
+			"      4        5        6        -     ",
+			"                                       ",
+		}, "\n"), buf.String())
+	})
+
+	t.Run("reports write errors", func(t *testing.T) {
+		grid := NewButtonGrid()
+		assert.Error(t, grid.RenderTo(failingWriter{}, 80))
+	})
+}
+
+// failingWriter fails every write
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestButtonGridComputeButtonBounds(t *testing.T) {
+	t.Run("places button 7 at the expected rectangle", func(t *testing.T) {
+		grid := NewButtonGrid()
+
+		bounds := grid.ComputeButtonBounds(80)
+
+		// Cells are 12 wide at width 80 and the grid is centered within the
+		// 80 column max width, so column 0 starts at 1 + (80-53)/2 = 14
+		rect, exists := bounds["button_1_0"]
+		require.True(t, exists)
+		assert.Equal(t, ButtonRect{X: 14, Y: 5, Width: 12, Height: 3}, rect)
+	})
+
+	t.Run("covers all buttons", func(t *testing.T) {
+		grid := NewButtonGrid()
+
+		bounds := grid.ComputeButtonBounds(80)
+
+		assert.Len(t, bounds, grid.GetButtonCount())
+		for buttonID := range grid.GetButtons() {
+			assert.Contains(t, bounds, buttonID)
+		}
+	})
+
+	t.Run("bounds do not overlap", func(t *testing.T) {
+		grid := NewButtonGrid()
+
+		bounds := grid.ComputeButtonBounds(80)
+
+		for id, rect := range bounds {
+			for otherID, other := range bounds {
+				if id == otherID {
+					continue
+				}
+				assert.False(t, rect.Contains(other.X, other.Y),
+					"%s overlaps %s", id, otherID)
+			}
+		}
+	})
+}
+
+// stubHoverReporter reports a fixed element as hovered
+type stubHoverReporter struct {
+	hovered string
+}
+
+func (r stubHoverReporter) IsHovering(elementID string) bool {
+	return elementID == r.hovered
+}
+
+func TestButtonGridHoverStyle(t *testing.T) {
+	t.Run("renders hovered button with the theme hover style", func(t *testing.T) {
+		grid := NewButtonGrid()
+		grid.SetHoverReporter(stubHoverReporter{hovered: "button_2_1"})
+
+		grid.Render(80)
+
+		cell, err := grid.grid.GetCell(1, 2)
+		require.NoError(t, err)
+		hoverStyle := grid.themeManager.GetButtonStyle("number", "hover")
+		assert.Equal(t, hoverStyle.GetBackground(), cell.Style.GetBackground())
+		assert.NotEqual(t, grid.themeManager.GetButtonStyle("number", "normal").GetBackground(), cell.Style.GetBackground())
+	})
+
+	t.Run("leaves other buttons with their normal style", func(t *testing.T) {
+		grid := NewButtonGrid()
+		grid.SetHoverReporter(stubHoverReporter{hovered: "button_2_1"})
+
+		grid.Render(80)
+
+		cell, err := grid.grid.GetCell(2, 2)
+		require.NoError(t, err)
+		normalStyle := grid.themeManager.GetButtonStyle("number", "normal")
+		assert.Equal(t, normalStyle.GetBackground(), cell.Style.GetBackground())
+	})
+}
+
+func TestButtonGridExportLayoutASCII(t *testing.T) {
+	t.Run("draws every label in its row and column", func(t *testing.T) {
+		grid := NewButtonGrid()
+
+		expected := strings.Join([]string{
+			"+-----+-----+-----+-----+",
+			"|  C  | CE  |  ←  |  ÷  |",
+			"+-----+-----+-----+-----+",
+			"|  7  |  8  |  9  |  ×  |",
+			"+-----+-----+-----+-----+",
+			"|  4  |  5  |  6  |  -  |",
+			"+-----+-----+-----+-----+",
+			"|  1  |  2  |  3  |  +  |",
+			"+-----+-----+-----+-----+",
+			"|  0  |  .  |  =  |  ±  |",
+			"+-----+-----+-----+-----+",
+			"| 1/x | x²  | x³  | ANS |",
+			"+-----+-----+-----+-----+",
+		}, "\n") + "\n"
+		assert.Equal(t, expected, grid.ExportLayoutASCII())
+	})
+
+	t.Run("is the same for every theme", func(t *testing.T) {
+		grid := NewButtonGrid()
+		export := grid.ExportLayoutASCII()
+
+		for _, theme := range grid.ListThemes() {
+			require.NoError(t, grid.SetTheme(theme))
+			assert.Equal(t, export, grid.ExportLayoutASCII(), theme)
+		}
+		assert.NotContains(t, export, "\x1b", "Export should carry no styling")
+	})
+
+	t.Run("follows the operator position", func(t *testing.T) {
+		grid := NewButtonGrid()
+		grid.SetOperatorPosition(OperatorsBottom)
+
+		lines := strings.Split(grid.ExportLayoutASCII(), "\n")
+		require.Len(t, lines, 2*grid.GetDimensions().Rows+2)
+		assert.Equal(t, "|  ÷  |  ×  |  -  |  +  |", lines[11])
+	})
+}
+
+// themeFileJSON is a complete custom theme in the theme file layout
+const themeFileJSON = `{
+  "name": "sunset",
+  "description": "Warm orange keys",
+  "buttons": {
+    "number":   {"normal": {"foreground": "230", "background": "94"},  "focused": {"foreground": "15", "background": "130"},
+                 "pressed": {"foreground": "15", "background": "166"}, "disabled": {"foreground": "242", "background": "52"},
+                 "hover": {"foreground": "15", "background": "130"}},
+    "operator": {"normal": {"foreground": "15", "background": "208"}, "focused": {"foreground": "15", "background": "214"},
+                 "pressed": {"foreground": "15", "background": "220"}, "disabled": {"foreground": "242", "background": "94"},
+                 "hover": {"foreground": "15", "background": "214"}},
+    "special":  {"normal": {"foreground": "15", "background": "160"}, "focused": {"foreground": "15", "background": "196"},
+                 "pressed": {"foreground": "15", "background": "203"}, "disabled": {"foreground": "242", "background": "52"},
+                 "hover": {"foreground": "15", "background": "196"}}
+  },
+  "grid": {"background": "#1c1c1c", "foreground": "230", "border": "94", "focused": "214"},
+  "display": {"foreground": "230", "background": "52"},
+  "borders": {"style": "double", "normal": "94", "focused": "214"}
+}`
+
+// writeThemeFile writes content to a file named name in a temporary directory
+func writeThemeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func TestButtonGridLoadThemeFile(t *testing.T) {
+	t.Run("loads a JSON theme that SetTheme can select", func(t *testing.T) {
+		grid := NewButtonGrid()
+
+		name, err := grid.LoadThemeFile(writeThemeFile(t, "sunset.json", themeFileJSON))
+		require.NoError(t, err)
+		assert.Equal(t, "sunset", name)
+		assert.Equal(t, "retro-casio", grid.GetCurrentTheme(), "loading should not switch themes")
+
+		require.NoError(t, grid.SetTheme(name))
+		assert.Equal(t, "sunset", grid.GetCurrentTheme())
+		theme := grid.themeManager.GetCurrentTheme()
+		assert.Equal(t, "Warm orange keys", theme.Description)
+		assert.Equal(t, lipgloss.Color("208"), theme.Colors.OperatorColors.Normal.Background)
+		assert.Equal(t, lipgloss.DoubleBorder(), theme.Styles.Border.Normal)
+		assert.Equal(t, lipgloss.Color("214"), theme.Styles.Border.Colors.Pressed, "pressed border defaults to focused")
+	})
+
+	t.Run("loads a YAML theme", func(t *testing.T) {
+		grid := NewButtonGrid()
+		yamlTheme := `name: dusk
+buttons:
+  number:
+    normal: {foreground: 250, background: 236}
+    focused: {foreground: 15, background: 238}
+    pressed: {foreground: 15, background: 240}
+    disabled: {foreground: 242, background: 235}
+    hover: {foreground: 15, background: 237}
+  operator:
+    normal: {foreground: 15, background: 61}
+    focused: {foreground: 15, background: 62}
+    pressed: {foreground: 15, background: 63}
+    disabled: {foreground: 242, background: 60}
+    hover: {foreground: 15, background: 62}
+  special:
+    normal: {foreground: 15, background: 125}
+    focused: {foreground: 15, background: 161}
+    pressed: {foreground: 15, background: 162}
+    disabled: {foreground: 242, background: 89}
+    hover: {foreground: 15, background: 161}
+grid: {background: 234, foreground: 250, border: 238, focused: 62}
+display: {foreground: 250, background: 235}
+borders: {normal: 238, focused: 62}
+`
+
+		name, err := grid.LoadThemeFile(writeThemeFile(t, "dusk.yml", yamlTheme))
+		require.NoError(t, err)
+		require.NoError(t, grid.SetTheme(name))
+		theme := grid.themeManager.GetCurrentTheme()
+		assert.Equal(t, lipgloss.Color("61"), theme.Colors.OperatorColors.Normal.Background)
+		assert.Equal(t, lipgloss.RoundedBorder(), theme.Styles.Border.Normal, "border style defaults to rounded")
+	})
+
+	t.Run("lists every missing key", func(t *testing.T) {
+		grid := NewButtonGrid()
+		content := strings.Replace(themeFileJSON, `"display": {"foreground": "230", "background": "52"},`, "", 1)
+		content = strings.Replace(content, `"name": "sunset",`, "", 1)
+
+		_, err := grid.LoadThemeFile(writeThemeFile(t, "broken.json", content))
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, styles.ErrInvalidTheme))
+		assert.Contains(t, err.Error(), "missing name, display.foreground, display.background")
+		assert.Error(t, grid.SetTheme("sunset"), "an invalid theme should not be registered")
+	})
+
+	t.Run("rejects invalid files", func(t *testing.T) {
+		grid := NewButtonGrid()
+		cases := map[string]string{
+			"bad color":     strings.Replace(themeFileJSON, `"background": "208"`, `"background": "orange"`, 1),
+			"bad border":    strings.Replace(themeFileJSON, `"style": "double"`, `"style": "wavy"`, 1),
+			"built-in name": strings.Replace(themeFileJSON, `"name": "sunset"`, `"name": "modern"`, 1),
+			"malformed":     themeFileJSON[:100],
+		}
+		for name, content := range cases {
+			_, err := grid.LoadThemeFile(writeThemeFile(t, "theme.json", content))
+			assert.True(t, errors.Is(err, styles.ErrInvalidTheme), name)
+		}
+
+		_, err := grid.LoadThemeFile(writeThemeFile(t, "theme.toml", themeFileJSON))
+		assert.True(t, errors.Is(err, styles.ErrInvalidTheme), "unsupported extension")
+
+		_, err = grid.LoadThemeFile(filepath.Join(t.TempDir(), "missing.json"))
+		assert.Error(t, err)
+	})
+}
+
 // Benchmark tests
 func BenchmarkButtonGridRender(b *testing.B) {
 	grid := NewButtonGrid()
@@ -388,4 +1063,144 @@ func BenchmarkButtonGridMouseHandling(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		_ = grid.HandleMouse(msg)
 	}
-}
\ No newline at end of file
+}
+
+func TestButtonGridScientificMode(t *testing.T) {
+	grid := NewButtonGrid()
+	baseCount := grid.GetButtonCount()
+	_, exists := grid.findButtonByValue(",")
+	assert.False(t, exists, "the comma button should only exist in scientific mode")
+	assert.Nil(t, grid.HandleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{','}}))
+
+	grid.SetScientificMode(true)
+	assert.True(t, grid.IsScientificMode())
+	assert.Equal(t, baseCount+7, grid.GetButtonCount())
+	for _, value := range []string{"(", ")", ",", "root", "exp", "factorial", "power"} {
+		_, exists := grid.findButtonByValue(value)
+		assert.True(t, exists, "expected a %q button in scientific mode", value)
+	}
+
+	action := grid.HandleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{','}})
+	require.NotNil(t, action)
+	assert.Equal(t, ",", action.Value)
+
+	grid.SetScientificMode(false)
+	assert.Equal(t, baseCount, grid.GetButtonCount())
+}
+
+func TestButtonGridWideZero(t *testing.T) {
+	newWideGrid := func() *ButtonGrid {
+		grid := NewButtonGrid()
+		grid.SetWideZero(true)
+		return grid
+	}
+	focusedLabel := func(grid *ButtonGrid) string {
+		button, _ := grid.GetFocusedButton()
+		return button.GetLabel()
+	}
+
+	t.Run("spans the zero over two columns and moves . and = along", func(t *testing.T) {
+		grid := newWideGrid()
+		assert.True(t, grid.IsWideZero())
+		require.NoError(t, ValidateButtonDefinitions(grid.layoutDefinitions()))
+		assert.Empty(t, grid.SelfTest())
+
+		zero, exists := grid.GetButton("button_4_0")
+		require.True(t, exists)
+		assert.Equal(t, "0", zero.GetLabel())
+		assert.Equal(t, 2, zero.GetSpan())
+
+		_, exists = grid.GetButton("button_4_1")
+		assert.False(t, exists, "the zero covers the second column")
+		dot, _ := grid.GetButton("button_4_2")
+		assert.Equal(t, ".", dot.GetLabel())
+		equals, _ := grid.GetButton("button_4_3")
+		assert.Equal(t, "=", equals.GetLabel())
+
+		_, exists = grid.findButtonByValue("negate")
+		assert.False(t, exists, "± gives up its key for the wide zero")
+
+		assert.Contains(t, grid.ExportLayoutASCII(), "|     0     |  .  |  =  |")
+	})
+
+	t.Run("navigates to the zero as a single button", func(t *testing.T) {
+		grid := newWideGrid()
+		require.True(t, grid.FocusValue("0"))
+
+		grid.HandleKeyPress(tea.KeyMsg{Type: tea.KeyRight})
+		assert.Equal(t, ".", focusedLabel(grid), "right from the zero skips the column it covers")
+
+		grid.HandleKeyPress(tea.KeyMsg{Type: tea.KeyLeft})
+		assert.Equal(t, "0", focusedLabel(grid))
+
+		grid.HandleKeyPress(tea.KeyMsg{Type: tea.KeyUp})
+		assert.Equal(t, "1", focusedLabel(grid))
+
+		require.True(t, grid.FocusValue("2"))
+		grid.HandleKeyPress(tea.KeyMsg{Type: tea.KeyDown})
+		assert.Equal(t, "0", focusedLabel(grid), "down from the second column reaches the zero")
+	})
+
+	t.Run("clicks anywhere on the zero reach it", func(t *testing.T) {
+		grid := newWideGrid()
+		cellWidth, _ := grid.grid.CalculateDimensions(80)
+		bounds := grid.ComputeButtonBounds(80)
+		assert.Equal(t, grid.grid.SpannedWidth(cellWidth, 2), bounds["button_4_0"].Width)
+
+		// Under the second column, without and then with registered bounds
+		secondX, _ := grid.grid.GetCellPosition(1, 3, cellWidth)
+		_, zeroY := grid.grid.GetCellPosition(0, 4, cellWidth)
+		click := tea.MouseMsg{Type: tea.MouseLeft, X: secondX + 1, Y: zeroY + 1}
+
+		action := grid.HandleMouse(click)
+		require.NotNil(t, action)
+		assert.Equal(t, "0", action.Value)
+
+		grid.RegisterButtonBounds(bounds)
+		action = grid.HandleMouse(click)
+		require.NotNil(t, action)
+		assert.Equal(t, "0", action.Value)
+	})
+
+	t.Run("keeps the columns aligned at every width", func(t *testing.T) {
+		// column returns the screen column of the first line holding label
+		column := func(lines []string, label string) (int, int) {
+			for i, line := range lines {
+				if index := strings.Index(line, label); index >= 0 {
+					return lipgloss.Width(line[:index]), i
+				}
+			}
+			return -1, -1
+		}
+
+		for _, borderless := range []bool{false, true} {
+			grid := newWideGrid()
+			grid.SetBorderless(borderless)
+
+			for width := 60; width <= 120; width++ {
+				lines := strings.Split(grid.Render(width), "\n")
+				for i, line := range lines {
+					require.Equal(t, lipgloss.Width(lines[0]), lipgloss.Width(line),
+						"width %d borderless %v: line %d is misaligned", width, borderless, i)
+				}
+
+				three, _ := column(lines, "3")
+				dot, _ := column(lines, ".")
+				plus, _ := column(lines, "+")
+				equals, _ := column(lines, "=")
+				assert.Equal(t, three, dot, "width %d borderless %v: '.' is not under '3'", width, borderless)
+				assert.Equal(t, plus, equals, "width %d borderless %v: '=' is not under '+'", width, borderless)
+			}
+		}
+	})
+
+	t.Run("turning it off restores the standard layout", func(t *testing.T) {
+		grid := newWideGrid()
+		grid.SetWideZero(false)
+
+		_, exists := grid.findButtonByValue("negate")
+		assert.True(t, exists)
+		zero, _ := grid.GetButton("button_4_0")
+		assert.Equal(t, 1, zero.GetSpan())
+	})
+}
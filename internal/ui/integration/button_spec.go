@@ -0,0 +1,49 @@
+package integration
+
+import (
+	"fmt"
+
+	"ccpm-demo/internal/ui/components"
+)
+
+// ValidateButtonDefinitions checks that a button set can be laid out: every
+// button must have a known type and a position of its own. The first problem
+// found is returned.
+func ValidateButtonDefinitions(defs []ButtonDefinition) error {
+	occupied := make(map[components.Position]ButtonDefinition)
+
+	for _, def := range defs {
+		switch def.Type {
+		case components.TypeNumber, components.TypeOperator, components.TypeSpecial:
+		default:
+			return fmt.Errorf("button %q has unknown type %d", def.Label, int(def.Type))
+		}
+
+		// A spanned button occupies every column it covers
+		for column := def.Column; column < def.Column+max(def.Span, 1); column++ {
+			position := components.Position{Row: def.Row, Column: column}
+			if other, exists := occupied[position]; exists {
+				return fmt.Errorf("buttons %q and %q are both at row %d, column %d",
+					other.Label, def.Label, def.Row, column)
+			}
+			occupied[position] = def
+		}
+	}
+
+	return nil
+}
+
+// NewButtonGridWithLayout creates a button grid with a custom button set
+// instead of the calculator layout. The set is validated first, and the
+// operator position no longer affects the layout.
+func NewButtonGridWithLayout(defs []ButtonDefinition) (*ButtonGrid, error) {
+	if err := ValidateButtonDefinitions(defs); err != nil {
+		return nil, fmt.Errorf("invalid button layout: %w", err)
+	}
+
+	buttonGrid := NewButtonGrid()
+	buttonGrid.customLayout = append([]ButtonDefinition(nil), defs...)
+	buttonGrid.initializeCalculatorLayout()
+
+	return buttonGrid, nil
+}
@@ -0,0 +1,73 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ccpm-demo/internal/ui/components"
+)
+
+func TestValidateButtonDefinitions(t *testing.T) {
+	t.Run("accepts the built-in layouts", func(t *testing.T) {
+		assert.NoError(t, ValidateButtonDefinitions(rightOperatorLayout()))
+		assert.NoError(t, ValidateButtonDefinitions(bottomOperatorLayout()))
+	})
+
+	t.Run("rejects buttons sharing a position", func(t *testing.T) {
+		defs := []ButtonDefinition{
+			{Label: "1", Value: "1", Type: components.TypeNumber, Row: 0, Column: 0, Width: 3, Height: 1},
+			{Label: "2", Value: "2", Type: components.TypeNumber, Row: 0, Column: 1, Width: 3, Height: 1},
+			{Label: "+", Value: "+", Type: components.TypeOperator, Row: 0, Column: 1, Width: 3, Height: 1},
+		}
+
+		grid, err := NewButtonGridWithLayout(defs)
+		require.Error(t, err)
+		assert.Nil(t, grid)
+		assert.Equal(t, `invalid button layout: buttons "2" and "+" are both at row 0, column 1`, err.Error())
+	})
+
+	t.Run("rejects unknown button types", func(t *testing.T) {
+		defs := []ButtonDefinition{
+			{Label: "?", Value: "?", Type: components.ButtonType(42), Row: 0, Column: 0, Width: 3, Height: 1},
+		}
+
+		err := ValidateButtonDefinitions(defs)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `button "?" has unknown type 42`)
+	})
+
+	t.Run("builds a valid custom layout", func(t *testing.T) {
+		defs := []ButtonDefinition{
+			{Label: "1", Value: "1", Type: components.TypeNumber, Row: 0, Column: 0, Width: 3, Height: 1},
+			{Label: "+", Value: "+", Type: components.TypeOperator, Row: 0, Column: 1, Width: 3, Height: 1},
+		}
+
+		grid, err := NewButtonGridWithLayout(defs)
+		require.NoError(t, err)
+		assert.Equal(t, 2, grid.GetButtonCount())
+
+		// The custom layout survives a change of operator position
+		grid.SetOperatorPosition(OperatorsBottom)
+		assert.Equal(t, 2, grid.GetButtonCount())
+		assert.Empty(t, grid.SelfTest())
+	})
+
+	t.Run("keys pick the first of buttons sharing a value", func(t *testing.T) {
+		defs := []ButtonDefinition{
+			{Label: "1", Value: "1", Type: components.TypeNumber, Row: 0, Column: 0, Width: 3, Height: 1},
+			{Label: "=", Value: "=", Type: components.TypeSpecial, Row: 0, Column: 1, Width: 3, Height: 1},
+			{Label: "2", Value: "2", Type: components.TypeNumber, Row: 1, Column: 0, Width: 3, Height: 1},
+			{Label: "=", Value: "=", Type: components.TypeSpecial, Row: 1, Column: 1, Width: 3, Height: 1},
+		}
+
+		grid, err := NewButtonGridWithLayout(defs)
+		require.NoError(t, err)
+		for i := 0; i < 20; i++ {
+			buttonID, exists := grid.findButtonByValue("=")
+			require.True(t, exists)
+			assert.Equal(t, grid.generateButtonID(0, 1), buttonID)
+		}
+	})
+}
@@ -0,0 +1,53 @@
+package integration
+
+import "ccpm-demo/internal/ui/components"
+
+// calculatorRows is the number of rows in the calculator layouts
+const calculatorRows = 6
+
+// scientificRows is the number of rows scientific mode adds below them
+const scientificRows = 2
+
+// scientificRow returns the extra row of buttons shown in scientific mode,
+// for entering multi-argument functions such as root(27,3)
+func scientificRow(row int) []ButtonDefinition {
+	return []ButtonDefinition{
+		{Label: "(", Value: "(", Type: components.TypeSpecial, Row: row, Column: 0, Width: 3, Height: 1},
+		{Label: ")", Value: ")", Type: components.TypeSpecial, Row: row, Column: 1, Width: 3, Height: 1},
+		{Label: ",", Value: ",", Type: components.TypeSpecial, Row: row, Column: 2, Width: 3, Height: 1},
+		{Label: "ⁿ√", Value: "root", Type: components.TypeSpecial, Row: row, Column: 3, Width: 3, Height: 1},
+	}
+}
+
+// exponentRow returns the second row shown in scientific mode, with a
+// double-width EXP key for entering exponents such as 1.2e3, the factorial
+// and a general power
+func exponentRow(row int) []ButtonDefinition {
+	return []ButtonDefinition{
+		{Label: "EXP", Value: "exp", Type: components.TypeSpecial, Row: row, Column: 0, Span: 2, Width: 3, Height: 1},
+		{Label: "n!", Value: "factorial", Type: components.TypeSpecial, Row: row, Column: 2, Width: 3, Height: 1},
+		{Label: "xʸ", Value: "power", Type: components.TypeSpecial, Row: row, Column: 3, Width: 3, Height: 1},
+	}
+}
+
+// SetScientificMode shows or hides the scientific rows below the calculator
+// layout, with parentheses, the argument separator, the root function, EXP,
+// the factorial and a general power. A custom layout is left as it is.
+func (bg *ButtonGrid) SetScientificMode(enabled bool) {
+	bg.scientific = enabled
+
+	rows := calculatorRows
+	if enabled {
+		rows += scientificRows
+	}
+	if bg.customLayout == nil {
+		bg.dimensions.Rows = rows
+		bg.grid.WithDimensions(bg.dimensions.Columns, rows)
+	}
+	bg.initializeCalculatorLayout()
+}
+
+// IsScientificMode returns whether the scientific rows are shown
+func (bg *ButtonGrid) IsScientificMode() bool {
+	return bg.scientific
+}
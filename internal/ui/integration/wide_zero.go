@@ -0,0 +1,51 @@
+package integration
+
+import "ccpm-demo/internal/ui/components"
+
+// numberBottomRow is the row of the right-operator layout holding 0, . and =
+const numberBottomRow = 4
+
+// wideZeroRow returns the bottom number row with a double-width 0 key like a
+// Casio's, with . and = moved along to make room for it
+func wideZeroRow(row int) []ButtonDefinition {
+	return []ButtonDefinition{
+		{Label: "0", Value: "0", Type: components.TypeNumber, Row: row, Column: 0, Span: 2, Width: 3, Height: 1},
+		{Label: ".", Value: ".", Type: components.TypeNumber, Row: row, Column: 2, Width: 3, Height: 1},
+		{Label: "=", Value: "=", Type: components.TypeSpecial, Row: row, Column: 3, Width: 3, Height: 1},
+	}
+}
+
+// withWideZero replaces the bottom number row of the right-operator layout
+// with the wide zero row
+func withWideZero(defs []ButtonDefinition) []ButtonDefinition {
+	wide := make([]ButtonDefinition, 0, len(defs))
+	for _, def := range defs {
+		if def.Row != numberBottomRow {
+			wide = append(wide, def)
+		}
+	}
+	return append(wide, wideZeroRow(numberBottomRow)...)
+}
+
+// SetWideZero sets whether the 0 key spans two columns. It applies to the
+// right-operator layout, where ± gives up its key to make room; the n key
+// still negates. Focus returns to the top-left button.
+func (bg *ButtonGrid) SetWideZero(enabled bool) {
+	bg.wideZero = enabled
+	bg.initializeCalculatorLayout()
+}
+
+// IsWideZero returns whether the 0 key spans two columns
+func (bg *ButtonGrid) IsWideZero() bool {
+	return bg.wideZero
+}
+
+// buttonIDAt returns the ID of the button covering a grid position, which is
+// that of a spanned button for any of the columns it covers
+func (bg *ButtonGrid) buttonIDAt(col, row int) (string, bool) {
+	cell, err := bg.grid.GetCoveringCell(col, row)
+	if err != nil {
+		return "", false
+	}
+	return bg.generateButtonID(row, cell.Position.Column), true
+}
@@ -0,0 +1,31 @@
+package ui
+
+import "fmt"
+
+// keyCounterStatus is shown in the status bar while the key counter is on
+const keyCounterStatus = "Keys: %d"
+
+// KeyCounterEnabled returns whether the status bar counts key presses
+func (m Model) KeyCounterEnabled() bool {
+	return m.keyCounter
+}
+
+// SetKeyCounter shows or hides the count of key presses in the status bar.
+// Keys are counted whether or not it is shown.
+func (m *Model) SetKeyCounter(enabled bool) {
+	m.keyCounter = enabled
+}
+
+// KeyPresses returns how many keys have been handled this session, since the
+// last hard clear
+func (m Model) KeyPresses() int {
+	return m.keyPresses
+}
+
+// keyCounterText returns the key counter for the status bar, empty when it is off
+func (m Model) keyCounterText() string {
+	if !m.keyCounter {
+		return ""
+	}
+	return fmt.Sprintf(keyCounterStatus, m.keyPresses)
+}
@@ -0,0 +1,52 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"ccpm-demo/internal/calculator"
+)
+
+func TestKeyCounter(t *testing.T) {
+	updated, _ := NewModel(calculator.NewEngine()).Update(tea.WindowSizeMsg{Width: 80, Height: 40})
+	model := updated.(Model)
+	if model.statusText() != "" {
+		t.Errorf("Expected the key counter to be hidden by default, got '%s'", model.statusText())
+	}
+	model.SetKeyCounter(true)
+
+	model = typeKeys(model, "12+3")
+	if model.KeyPresses() != 4 {
+		t.Errorf("Expected 4 key presses, got %d", model.KeyPresses())
+	}
+	model = sendKeys(model, tea.KeyMsg{Type: tea.KeyBackspace}, tea.KeyMsg{Type: tea.KeyLeft})
+	if model.KeyPresses() != 6 {
+		t.Errorf("Expected every handled key to count, got %d", model.KeyPresses())
+	}
+	if model.statusText() != "Keys: 6" {
+		t.Errorf("Expected the count in the status bar, got '%s'", model.statusText())
+	}
+
+	// Mouse events are not key presses
+	updated, _ = model.Update(tea.MouseMsg{X: 1, Y: 1, Type: tea.MouseMotion})
+	if updated.(Model).KeyPresses() != 6 {
+		t.Errorf("Expected mouse events not to count, got %d", updated.(Model).KeyPresses())
+	}
+
+	// A soft clear keeps the count
+	model = typeKeys(model, "c")
+	if model.KeyPresses() != 7 {
+		t.Errorf("Expected a soft clear to keep the count, got %d", model.KeyPresses())
+	}
+
+	// A hard clear resets it
+	model.SetClearMode(ClearAll)
+	model = typeKeys(model, "5c")
+	if model.KeyPresses() != 0 {
+		t.Errorf("Expected a hard clear to reset the count, got %d", model.KeyPresses())
+	}
+	if model.statusText() != "Keys: 0" {
+		t.Errorf("Expected the reset count in the status bar, got '%s'", model.statusText())
+	}
+}
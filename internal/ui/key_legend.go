@@ -0,0 +1,89 @@
+package ui
+
+import "strings"
+
+// legendEntry is a key and what it does, as shown in the key legend
+type legendEntry struct {
+	key    string
+	action string
+}
+
+// String returns the entry as shown in the legend, e.g. "q quit"
+func (e legendEntry) String() string {
+	return e.key + " " + e.action
+}
+
+// Legends for the overlays that capture every key while open
+var (
+	historySearchLegend = []legendEntry{
+		{"type", "filter"}, {"↑/↓", "select"}, {"enter", "use"}, {"esc", "cancel"},
+	}
+	resultsPaletteLegend = []legendEntry{
+		{"1-9", "insert"}, {"↑/↓", "select"}, {"enter", "insert"}, {"esc", "cancel"},
+	}
+	themePreviewLegend = []legendEntry{
+		{"←/→", "cycle"}, {"enter", "apply"}, {"esc", "cancel"},
+	}
+)
+
+// KeyLegendEnabled returns whether a footer lists the keys available in the
+// current mode
+func (m Model) KeyLegendEnabled() bool {
+	return m.keyLegend
+}
+
+// SetKeyLegend shows or hides the footer listing the keys available in the
+// current mode
+func (m *Model) SetKeyLegend(enabled bool) {
+	m.keyLegend = enabled
+}
+
+// KeyLegend returns the keys available in the current mode, following the
+// routing in handleKeyMsg: an open overlay captures every key, otherwise
+// the calculator keys apply.
+func (m Model) KeyLegend() string {
+	var entries []legendEntry
+	switch {
+	case m.search.active:
+		entries = historySearchLegend
+	case m.palette.active:
+		entries = resultsPaletteLegend
+	case m.preview.active:
+		entries = themePreviewLegend
+	default:
+		entries = m.calculatorLegend()
+	}
+
+	parts := make([]string, len(entries))
+	for i, entry := range entries {
+		parts[i] = entry.String()
+	}
+	return strings.Join(parts, "  ")
+}
+
+// calculatorLegend returns the calculator keys that do something right now
+func (m Model) calculatorLegend() []legendEntry {
+	entries := []legendEntry{{"=", "calculate"}, {"c", "clear"}}
+	if m.input == "" && len(m.history) > 0 {
+		entries = append(entries, legendEntry{historySearchKey, "search"})
+	}
+	if len(m.RecentResults()) > 0 {
+		entries = append(entries, legendEntry{"r", "recent"})
+	}
+	if len(m.history) > 0 {
+		entries = append(entries, legendEntry{"U", "undo"})
+	}
+	entries = append(entries, legendEntry{themePreviewKey, "themes"})
+	if m.mouseToggleKey != "" {
+		entries = append(entries, legendEntry{m.mouseToggleKey, "mouse"})
+	}
+	return append(entries, legendEntry{"q", "quit"})
+}
+
+// keyLegendText returns the key legend footer, empty when it is off
+func (m Model) keyLegendText() string {
+	if !m.keyLegend {
+		return ""
+	}
+	return m.KeyLegend()
+}
@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"ccpm-demo/internal/calculator"
+)
+
+func TestKeyLegend(t *testing.T) {
+	updated, _ := NewModel(calculator.NewEngine()).Update(tea.WindowSizeMsg{Width: 80, Height: 40})
+	model := updated.(Model)
+	if strings.Contains(model.View(), "q quit") {
+		t.Error("Expected the key legend to be hidden by default")
+	}
+	model.SetKeyLegend(true)
+
+	legend := model.KeyLegend()
+	if legend != "= calculate  c clear  t themes  M mouse  q quit" {
+		t.Errorf("Unexpected legend in normal mode: '%s'", legend)
+	}
+	if !strings.Contains(model.View(), legend) {
+		t.Error("Expected the legend in the footer")
+	}
+
+	// Keys that need history appear once there is some
+	model = typeKeys(model, "2+3=")
+	model.SetInput("")
+	legend = model.KeyLegend()
+	for _, want := range []string{"/ search", "r recent", "U undo"} {
+		if !strings.Contains(legend, want) {
+			t.Errorf("Expected '%s' in the legend, got '%s'", want, legend)
+		}
+	}
+
+	// Typing into the history search swaps in its own keys
+	model = typeKeys(model, "/")
+	if !model.IsHistorySearchActive() {
+		t.Fatal("Expected '/' to open the history search")
+	}
+	legend = model.KeyLegend()
+	if legend != "type filter  ↑/↓ select  enter use  esc cancel" {
+		t.Errorf("Unexpected legend in search mode: '%s'", legend)
+	}
+	if strings.Contains(legend, "q quit") {
+		t.Error("Expected 'q' to be left out while typing a search")
+	}
+
+	model = sendKeys(model, tea.KeyMsg{Type: tea.KeyEsc})
+	if !strings.Contains(model.KeyLegend(), "q quit") {
+		t.Errorf("Expected the calculator keys back after closing the search, got '%s'", model.KeyLegend())
+	}
+}
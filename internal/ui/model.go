@@ -2,12 +2,16 @@ package ui
 
 import (
 	"fmt"
+	"math"
+	"strconv"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
 	"ccpm-demo/internal/calculator"
 	"ccpm-demo/internal/audio"
+	"ccpm-demo/internal/ui/components"
 	uiintegration "ccpm-demo/internal/ui/integration"
 )
 
@@ -28,22 +32,181 @@ type Model struct {
 	cursorPosition int
 	history        []string
 	historyIndex   int
+	historyOffset  int
+	historyFormat  HistoryEntryFormat
 
 	// UI state
 	ready bool
 	quitting bool
+	displayAlignment DisplayAlignment
+	twoLineDisplay bool
+	displayPlaceholder string
+	noColor bool
+	operatorPreview bool
+	autoFocusEquals bool
+	clearMode ClearMode
 
-	// Button Grid integration
-	buttonGrid *uiintegration.ButtonGrid
+	// Decides which buttons are disabled for the current input
+	disablePolicy DisablePolicy
+
+	// Display digit limit (0 means unlimited) and what to do when it is exceeded
+	maxDisplayDigits  int
+	digitOverflowMode DigitOverflowMode
+
+	// Key presses handled since the last hard clear, shown in the status bar
+	// while keyCounter is on
+	keyCounter bool
+	keyPresses int
+
+	// Footer listing the keys available in the current mode
+	keyLegend bool
+
+	// Asynchronous evaluation and the spinner shown while it is in flight
+	busy busyState
+
+	// Integer digits a number may be entered with (0 means unlimited), and
+	// the hint shown when a digit is rejected
+	maxIntegerDigits int
+	entryHint        string
+
+	// Result precision, calculator.DefaultPrecision for up to six decimal places
+	precision     int
+	precisionMode calculator.PrecisionMode
+
+	// Whether the input line groups integer digits in thousands
+	inputGrouping bool
+
+	// What the input line holds after "="; equation is the "expr = result"
+	// line shown in EqualsShowExpression mode until the input changes
+	equalsMode EqualsMode
+	equation   string
+
+	// History rows shown above the input line (0 disables them)
+	displayHistoryRows int
+
+	// History search overlay
+	search historySearch
+
+	// Theme preview overlay
+	preview themePreview
+
+	// Recent results quick-insert palette
+	palette            resultsPalette
+	recentResultsLimit int
+
+	// Hold-to-repeat for number buttons
+	repeat digitRepeat
+
+	// RPN stack shown above the input line while RPN mode is on
+	rpn       RPNStack
+	stackView *components.StackView
+
+	// History clearing confirmation
+	confirmClearHistory bool
+	pendingClearHistory bool
+
+	// Button Grid integration; boundsHeaderLines is the header height the
+	// registered button bounds were computed for
+	buttonGrid        *uiintegration.ButtonGrid
+	boundsHeaderLines int
+	hover             HoverTracker
+
+	// Custom keymap applied to key presses before they are handled
+	keyRemapper KeyRemapper
+
+	// Mouse support, switched at runtime with mouseToggleKey
+	mouseDisabled  bool
+	mouseSwitch    MouseSwitch
+	mouseToggleKey string
 
 	// Audio integration
 	audioIntegration *audio.Integration
 	audioEventHandler *audio.EventHandler
 
+	// Clipboard used by copy actions; none until the program provides one
+	clipboard Clipboard
+	copyResultOnEquals bool
+
+	// Whether starting a new number while an error is shown clears the error
+	// and the input that caused it
+	autoClearError bool
+
+	// Opt-in screen-reader announcements of activated buttons, spoken with
+	// the labels in spokenLabels
+	announcementsEnabled bool
+	announcements        []string
+	spokenLabels         map[string]string
+
 	// Styling
 	styles styles
 }
 
+// DisplayAlignment controls how values are aligned within the display
+type DisplayAlignment int
+
+const (
+	// DisplayAlignRight right-aligns values like a classic calculator
+	DisplayAlignRight DisplayAlignment = iota
+	// DisplayAlignLeft left-aligns values
+	DisplayAlignLeft
+)
+
+// String returns the string representation of the alignment
+func (a DisplayAlignment) String() string {
+	switch a {
+	case DisplayAlignLeft:
+		return "left"
+	default:
+		return "right"
+	}
+}
+
+// position returns the lipgloss position for the alignment
+func (a DisplayAlignment) position() lipgloss.Position {
+	if a == DisplayAlignLeft {
+		return lipgloss.Left
+	}
+	return lipgloss.Right
+}
+
+// DigitOverflowMode controls what the display shows when a result has more
+// digits than the display digit limit allows
+type DigitOverflowMode int
+
+const (
+	// DigitOverflowScientific switches to scientific notation
+	DigitOverflowScientific DigitOverflowMode = iota
+	// DigitOverflowIndicator shows an overflow indicator instead of the value
+	DigitOverflowIndicator
+)
+
+// digitOverflowIndicator is displayed for results that do not fit the display
+const digitOverflowIndicator = "Overflow"
+
+// HoverTracker follows the mouse over the button grid and reports which button
+// is hovered. It is satisfied by input.HoverManager, which cannot be referenced
+// here because the input package depends on ui.
+type HoverTracker interface {
+	uiintegration.HoverReporter
+	RegisterButton(id string, x, y, width, height int)
+	ClearButtons()
+	ProcessHoverEvent(msg tea.MouseMsg, timestamp int64) []tea.Msg
+}
+
+// RPNStack reports the state of Reverse Polish Notation input. It is satisfied
+// by input.InputSystem, which cannot be referenced here for the same reason.
+type RPNStack interface {
+	IsRPNMode() bool
+	GetStack() []float64
+}
+
+// KeyRemapper translates key presses from a custom keymap into the keys the
+// model handles. It is satisfied by input.KeyRemapper, which cannot be
+// referenced here for the same reason.
+type KeyRemapper interface {
+	RemapKey(msg tea.KeyMsg) tea.KeyMsg
+}
+
 // calculatorState represents the current calculator state
 type calculatorState struct {
 	displayValue string
@@ -83,18 +246,28 @@ func NewModel(engine *calculator.Engine) Model {
 			previousValue: 0,
 			isWaitingForOperand: false,
 		},
-		input:             "",
-		output:            "",
-		error:             "",
-		cursorPosition:    0,
-		history:           []string{},
-		historyIndex:      -1,
-		ready:             false,
-		quitting:          false,
-		buttonGrid:        buttonGrid,
-		audioIntegration:  audioIntegration,
-		audioEventHandler: audioEventHandler,
-		styles:            defaultStyles(),
+		input:              "",
+		output:             "",
+		error:              "",
+		cursorPosition:     0,
+		history:            []string{},
+		historyIndex:       -1,
+		ready:              false,
+		quitting:           false,
+		displayAlignment:   DisplayAlignRight,
+		displayPlaceholder: defaultDisplayPlaceholder,
+		noColor:            NoColorRequested(nil),
+		operatorPreview:    true,
+		recentResultsLimit: defaultRecentResultsLimit,
+		precision:          calculator.DefaultPrecision,
+		repeat:             digitRepeat{config: DefaultRepeatConfig()},
+		stackView:          components.NewStackView(),
+		mouseToggleKey:     defaultMouseToggleKey,
+		buttonGrid:         buttonGrid,
+		audioIntegration:   audioIntegration,
+		audioEventHandler:  audioEventHandler,
+		spokenLabels:       DefaultSpokenLabels(),
+		styles:             defaultStyles(),
 	}
 }
 
@@ -110,6 +283,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // View implements tea.Model
 func (m Model) View() string {
+	if m.noColor {
+		return plainRendering(view(m))
+	}
 	return view(m)
 }
 
@@ -187,11 +363,74 @@ func defaultStyles() styles {
 
 // formatValue formats a float value for display
 func (m Model) formatValue(value float64) string {
-	// Remove trailing .0 for whole numbers
-	if value == float64(int(value)) {
-		return fmt.Sprintf("%.0f", value)
+	var formatted string
+	if m.precision != calculator.DefaultPrecision {
+		// A set precision is shown as the calculator would show it
+		formatted = m.precisionFormatter().Format(value)
+	} else {
+		// Round for display using the engine's rounding mode
+		if m.engine != nil {
+			value = m.engine.RoundForDisplay(value, 6)
+		}
+
+		// Remove trailing .0 for whole numbers
+		if value == float64(int(value)) {
+			formatted = fmt.Sprintf("%.0f", value)
+		} else {
+			formatted = fmt.Sprintf("%.6f", value)
+		}
+	}
+
+	if m.maxDisplayDigits > 0 && countDigits(formatted) > m.maxDisplayDigits {
+		return m.fitDisplayDigits(value)
+	}
+	return formatted
+}
+
+// precisionFormatter returns the formatter for the set precision, rounding
+// with the engine's rounding mode
+func (m Model) precisionFormatter() calculator.Formatter {
+	formatter := calculator.Formatter{Precision: m.precision, Mode: m.precisionMode}
+	if m.engine != nil {
+		formatter.Rounding = m.engine.GetRoundingMode()
 	}
-	return fmt.Sprintf("%.6f", value)
+	return formatter
+}
+
+// fitDisplayDigits formats a value that exceeds the display digit limit. Values whose
+// integer part fits lose decimal places; larger values overflow. Rounding can carry
+// into an extra integer digit (99999999.999 -> 100000000.00), so each attempt is
+// re-checked against the limit.
+func (m Model) fitDisplayDigits(value float64) string {
+	integerDigits := countDigits(fmt.Sprintf("%.0f", math.Trunc(value)))
+	for decimals := m.maxDisplayDigits - integerDigits; decimals >= 0; decimals-- {
+		if formatted := fmt.Sprintf("%.*f", decimals, value); countDigits(formatted) <= m.maxDisplayDigits {
+			return formatted
+		}
+	}
+
+	if m.digitOverflowMode == DigitOverflowIndicator {
+		return digitOverflowIndicator
+	}
+
+	// Scientific notation with as many mantissa digits as the display holds
+	scientific := strconv.FormatFloat(value, 'e', m.maxDisplayDigits-1, 64)
+	mantissa, exponent, _ := strings.Cut(scientific, "e")
+	if strings.Contains(mantissa, ".") {
+		mantissa = strings.TrimRight(strings.TrimRight(mantissa, "0"), ".")
+	}
+	return mantissa + "e" + exponent
+}
+
+// countDigits counts the decimal digits in a formatted number
+func countDigits(formatted string) int {
+	count := 0
+	for _, r := range formatted {
+		if r >= '0' && r <= '9' {
+			count++
+		}
+	}
+	return count
 }
 
 // truncateString truncates a string to fit within a width
@@ -206,6 +445,15 @@ func (m Model) truncateString(str string, width int) string {
 	return str[:width-3] + "..."
 }
 
+// truncateResult truncates a result to fit the display width, marking the cut with an ellipsis
+func (m Model) truncateResult(result string, width int) string {
+	runes := []rune(result)
+	if width <= 0 || len(runes) <= width {
+		return result
+	}
+	return string(runes[:width-1]) + "…"
+}
+
 // addToHistory adds an expression to the history
 func (m *Model) addToHistory(expression string) {
 	m.history = append(m.history, expression)
@@ -213,6 +461,84 @@ func (m *Model) addToHistory(expression string) {
 		m.history = m.history[1:]
 	}
 	m.historyIndex = len(m.history) - 1
+	m.historyOffset = 0
+}
+
+// GetHistory returns the calculation history
+func (m Model) GetHistory() []string {
+	return m.history
+}
+
+// ClearHistory empties the calculation history without touching the current input
+func (m *Model) ClearHistory() {
+	m.history = []string{}
+	m.historyIndex = -1
+	m.historyOffset = 0
+	m.pendingClearHistory = false
+}
+
+// UndoLastCommit removes the most recent history entry and puts its expression
+// back on the input line, as if "=" had not been pressed. The display returns
+// to the result before it. It reports whether there was an entry to remove.
+func (m *Model) UndoLastCommit() bool {
+	if len(m.history) == 0 {
+		return false
+	}
+
+	entry := m.history[len(m.history)-1]
+	m.history = m.history[:len(m.history)-1]
+	m.historyIndex = len(m.history) - 1
+	m.ScrollHistory(0)
+
+	expression := entry
+	if i := strings.LastIndex(entry, " = "); i >= 0 {
+		expression = entry[:i]
+	}
+	m.input = expression
+	m.cursorPosition = len(expression)
+
+	// Show the result committed before the removed one, if any
+	m.output = ""
+	m.calculatorState.displayValue = "0"
+	if len(m.history) > 0 {
+		previous := m.history[len(m.history)-1]
+		if i := strings.LastIndex(previous, " = "); i >= 0 {
+			m.output = previous[i+len(" = "):]
+			m.calculatorState.displayValue = m.output
+		}
+	}
+	m.calculatorState.isWaitingForOperand = false
+
+	return true
+}
+
+// SetConfirmClearHistory sets whether clearing the history requires pressing the key twice
+func (m *Model) SetConfirmClearHistory(confirm bool) {
+	m.confirmClearHistory = confirm
+	m.pendingClearHistory = false
+}
+
+// HistoryText serializes the calculation history, one entry per line
+func (m Model) HistoryText() string {
+	return strings.Join(m.history, "\n")
+}
+
+// clipboardWriteMsg reports the outcome of a clipboard write
+type clipboardWriteMsg struct {
+	err error
+}
+
+// copyToClipboard returns a command that writes text to the configured clipboard.
+// The write runs as a command rather than inside Update so the clipboard's escape
+// sequence is not emitted while a frame is being produced.
+func (m Model) copyToClipboard(text string) tea.Cmd {
+	clipboard := m.clipboard
+	return func() tea.Msg {
+		if clipboard == nil {
+			return clipboardWriteMsg{err: fmt.Errorf("clipboard is not configured")}
+		}
+		return clipboardWriteMsg{err: clipboard.WriteText(text)}
+	}
 }
 
 // clearError clears any error message
@@ -220,6 +546,36 @@ func (m *Model) clearError() {
 	m.error = ""
 }
 
+// clearErrorBefore clears the error before value is handled. Starting a new
+// number while an error is shown begins a fresh input when auto-clear is on,
+// and leaves the error shown when it is off.
+func (m *Model) clearErrorBefore(value string) {
+	if m.error != "" && startsNumber(value) {
+		if !m.autoClearError {
+			return
+		}
+		m.input = ""
+		m.cursorPosition = 0
+	}
+	m.clearError()
+}
+
+// startsNumber reports whether value begins or continues a number
+func startsNumber(value string) bool {
+	return isDigit(value) || value == "." || value == "("
+}
+
+// GetAutoClearError returns whether starting a new number clears an error
+func (m Model) GetAutoClearError() bool {
+	return m.autoClearError
+}
+
+// SetAutoClearError sets whether starting a new number while an error is
+// shown clears the error and starts a fresh input
+func (m *Model) SetAutoClearError(enabled bool) {
+	m.autoClearError = enabled
+}
+
 // setError sets an error message
 func (m *Model) setError(err error) {
 	if err != nil {
@@ -237,6 +593,41 @@ func (m Model) getDisplayWidth() int {
 	return 56
 }
 
+// syncButtonBounds recomputes the button grid's clickable areas for the current
+// width and re-registers them in screen coordinates, replacing any stale bounds.
+// The grid is drawn directly below the header, inside the app border.
+func (m *Model) syncButtonBounds() {
+	header := m.renderHeader(m.updateStyles())
+	m.boundsHeaderLines = strings.Count(header, "\n")
+	originX, originY := 1, 1+m.boundsHeaderLines
+
+	bounds := m.buttonGrid.ComputeButtonBounds(m.width)
+	for buttonID, rect := range bounds {
+		rect.X += originX
+		rect.Y += originY
+		bounds[buttonID] = rect
+	}
+
+	m.buttonGrid.RegisterButtonBounds(bounds)
+
+	// The hover tracker hit-tests against the same areas
+	if m.hover != nil {
+		m.hover.ClearButtons()
+		for buttonID, rect := range bounds {
+			m.hover.RegisterButton(buttonID, rect.X, rect.Y, rect.Width, rect.Height)
+		}
+	}
+}
+
+// refreshButtonBounds re-registers the button bounds if the header above the
+// grid has grown or shrunk, e.g. when an error line appears
+func (m *Model) refreshButtonBounds() {
+	header := m.renderHeader(m.updateStyles())
+	if strings.Count(header, "\n") != m.boundsHeaderLines {
+		m.syncButtonBounds()
+	}
+}
+
 // getDisplayHeight returns the available display height
 func (m Model) getDisplayHeight() int {
 	if m.height > 0 {
@@ -261,6 +652,14 @@ func (m Model) GetOutput() string {
 	return m.output
 }
 
+// CopyResult returns the full, untruncated result for copying
+func (m Model) CopyResult() string {
+	if m.output != "" {
+		return m.output
+	}
+	return m.calculatorState.displayValue
+}
+
 // SetOutput sets the output string
 func (m *Model) SetOutput(output string) {
 	m.output = output
@@ -278,6 +677,60 @@ func (m *Model) SetCursorPosition(pos int) {
 	}
 }
 
+// insertAtCursor inserts text into the input at the cursor position
+func (m *Model) insertAtCursor(text string) {
+	m.input = m.input[:m.cursorPosition] + text + m.input[m.cursorPosition:]
+	m.cursorPosition += len(text)
+}
+
+// JumpToMatchingBracket moves the cursor to the bracket matching the one under
+// the cursor, or just before it. Returns false if there is no bracket or it is unbalanced.
+func (m *Model) JumpToMatchingBracket() bool {
+	pos := m.cursorPosition
+	if pos >= len(m.input) || !isBracket(m.input[pos]) {
+		pos--
+	}
+	if pos < 0 || !isBracket(m.input[pos]) {
+		return false
+	}
+
+	match, ok := matchingBracket(m.input, pos)
+	if !ok {
+		return false
+	}
+
+	m.cursorPosition = match
+	return true
+}
+
+// isBracket reports whether c is a parenthesis
+func isBracket(c byte) bool {
+	return c == '(' || c == ')'
+}
+
+// matchingBracket returns the index of the parenthesis matching the one at pos
+func matchingBracket(input string, pos int) (int, bool) {
+	step, open, close := 1, byte('('), byte(')')
+	if input[pos] == ')' {
+		step, open, close = -1, ')', '('
+	}
+
+	depth := 0
+	for i := pos; i >= 0 && i < len(input); i += step {
+		switch input[i] {
+		case open:
+			depth++
+		case close:
+			depth--
+			if depth == 0 {
+				return i, true
+			}
+		}
+	}
+
+	return 0, false
+}
+
 // GetError returns the current error message
 func (m Model) GetError() string {
 	return m.error
@@ -293,6 +746,326 @@ func (m *Model) ClearError() {
 	m.error = ""
 }
 
+// GetDisplayAlignment returns the current display alignment
+func (m Model) GetDisplayAlignment() DisplayAlignment {
+	return m.displayAlignment
+}
+
+// SetDisplayAlignment sets how values are aligned within the display
+func (m *Model) SetDisplayAlignment(alignment DisplayAlignment) {
+	m.displayAlignment = alignment
+}
+
+// ToggleDisplayAlignment switches the display between right and left alignment
+func (m *Model) ToggleDisplayAlignment() {
+	if m.displayAlignment == DisplayAlignLeft {
+		m.displayAlignment = DisplayAlignRight
+	} else {
+		m.displayAlignment = DisplayAlignLeft
+	}
+}
+
+// GetClipboard returns the clipboard used by copy actions
+func (m Model) GetClipboard() Clipboard {
+	return m.clipboard
+}
+
+// SetClipboard sets the clipboard used by copy actions. A new model has none,
+// so copy actions report an error until one is set.
+func (m *Model) SetClipboard(clipboard Clipboard) {
+	m.clipboard = clipboard
+}
+
+// SetPrecision sets how many digits results are shown with, counted as
+// decimal places or significant digits, using the same formatting as the
+// calculator. calculator.DefaultPrecision restores up to six decimal places.
+func (m *Model) SetPrecision(digits int, mode calculator.PrecisionMode) {
+	if digits < 0 {
+		digits = calculator.DefaultPrecision
+	}
+	m.precision = digits
+	m.precisionMode = mode
+}
+
+// GetPrecision returns how many digits results are shown with and what they count
+func (m Model) GetPrecision() (int, calculator.PrecisionMode) {
+	return m.precision, m.precisionMode
+}
+
+// GetMaxDisplayDigits returns the display digit limit (0 means unlimited)
+func (m Model) GetMaxDisplayDigits() int {
+	return m.maxDisplayDigits
+}
+
+// SetMaxDisplayDigits caps how many digits a result may show, like the 10-digit
+// display of a physical calculator. A limit of 0 disables the cap.
+func (m *Model) SetMaxDisplayDigits(limit int) {
+	if limit < 0 {
+		limit = 0
+	}
+	m.maxDisplayDigits = limit
+}
+
+// GetDigitOverflowMode returns how results beyond the digit limit are shown
+func (m Model) GetDigitOverflowMode() DigitOverflowMode {
+	return m.digitOverflowMode
+}
+
+// SetDigitOverflowMode sets how results beyond the digit limit are shown
+func (m *Model) SetDigitOverflowMode(mode DigitOverflowMode) {
+	m.digitOverflowMode = mode
+}
+
+// GetDisplayHistoryRows returns how many history rows show above the input line
+func (m Model) GetDisplayHistoryRows() int {
+	return m.displayHistoryRows
+}
+
+// SetDisplayHistoryRows sets how many of the latest history entries show above
+// the input line. A count of 0 hides them.
+func (m *Model) SetDisplayHistoryRows(rows int) {
+	if rows < 0 {
+		rows = 0
+	}
+	m.displayHistoryRows = rows
+}
+
+// DisplayHistoryRows returns the history entries shown above the input line, oldest first
+func (m Model) DisplayHistoryRows() []string {
+	start := len(m.history) - m.displayHistoryRows
+	if start < 0 {
+		start = 0
+	}
+	return m.history[start:]
+}
+
+// operandPlaceholder stands in for the next operand in the operator preview
+const operandPlaceholder = "?"
+
+// PendingOperator returns the operator waiting for its next operand, if any
+func (m Model) PendingOperator() (string, bool) {
+	return pendingOperator(m.input)
+}
+
+// pendingOperator returns the operator that input ends with, if any
+func pendingOperator(input string) (string, bool) {
+	trimmed := strings.TrimSpace(input)
+	if len(trimmed) < 2 {
+		return "", false
+	}
+
+	last := trimmed[len(trimmed)-1:]
+	if !strings.Contains("+-*/", last) {
+		return "", false
+	}
+	return last, true
+}
+
+// NegateOperand toggles the sign of the number being entered: "12 + 3"
+// becomes "12 + -3" and back again. It reports whether there was a number to negate.
+func (m *Model) NegateOperand() bool {
+	start := len(m.input)
+	for start > 0 && strings.ContainsRune("0123456789.", rune(m.input[start-1])) {
+		start--
+	}
+	if start == len(m.input) {
+		return false
+	}
+
+	// A minus directly before the number is unary if nothing precedes it but
+	// the start of the input, a space or an opening bracket
+	sign := start - 1
+	if sign >= 0 && m.input[sign] == '-' && (sign == 0 || strings.ContainsRune(" (", rune(m.input[sign-1]))) {
+		m.input = m.input[:sign] + m.input[start:]
+	} else {
+		m.input = m.input[:start] + "-" + m.input[start:]
+	}
+	m.cursorPosition = len(m.input)
+	m.calculatorState.displayValue = m.input
+
+	return true
+}
+
+// GetAutoFocusEquals returns whether completing an operand focuses the equals button
+func (m Model) GetAutoFocusEquals() bool {
+	return m.autoFocusEquals
+}
+
+// SetAutoFocusEquals sets whether typing the operand after an operator moves
+// the grid focus to the equals button, so that Enter evaluates straight away
+func (m *Model) SetAutoFocusEquals(enabled bool) {
+	m.autoFocusEquals = enabled
+}
+
+// operandComplete reports whether the input ends in an operand that follows an operator
+func (m Model) operandComplete() bool {
+	trimmed := strings.TrimSpace(m.input)
+	if trimmed == "" || !strings.ContainsAny(trimmed[len(trimmed)-1:], "0123456789.") {
+		return false
+	}
+
+	// Binary operators are spaced, which tells them apart from a leading minus
+	for _, operator := range []string{" + ", " - ", " * ", " / "} {
+		if strings.Contains(trimmed, operator) {
+			return true
+		}
+	}
+	return false
+}
+
+// DisplayText returns the text shown in the display. With the operator preview
+// enabled, a pending operation is shown with a placeholder for the next operand.
+// An empty display shows the display placeholder.
+func (m Model) DisplayText() string {
+	if m.operatorPreview {
+		if _, pending := m.PendingOperator(); pending {
+			return strings.TrimSpace(m.input) + " " + operandPlaceholder
+		}
+	}
+	if m.displayEmpty() {
+		return m.displayPlaceholder
+	}
+	return m.calculatorState.displayValue
+}
+
+// DisplayLines returns the two lines of the two-line display: the expression
+// being entered, or the one last evaluated, above its result
+func (m Model) DisplayLines() (string, string) {
+	if m.input != "" {
+		return m.input, m.output
+	}
+	if m.output == "" || len(m.history) == 0 {
+		return "", m.DisplayText()
+	}
+
+	expression, _, _ := strings.Cut(m.history[len(m.history)-1], " = ")
+	return expression, m.output
+}
+
+// IsTwoLineDisplay returns whether the display shows the expression above the result
+func (m Model) IsTwoLineDisplay() bool {
+	return m.twoLineDisplay
+}
+
+// SetTwoLineDisplay sets whether the display shows the expression above the
+// result, like a Casio fx model, rather than a single value
+func (m *Model) SetTwoLineDisplay(enabled bool) {
+	m.twoLineDisplay = enabled
+}
+
+// GetOperatorPreview returns whether pending operations are previewed in the display
+func (m Model) GetOperatorPreview() bool {
+	return m.operatorPreview
+}
+
+// SetOperatorPreview sets whether pending operations are previewed in the display
+func (m *Model) SetOperatorPreview(enabled bool) {
+	m.operatorPreview = enabled
+}
+
+// IsNoColor returns whether the view is rendered without ANSI styling
+func (m Model) IsNoColor() bool {
+	return m.noColor
+}
+
+// SetNoColor sets whether the view is rendered as plain text with ASCII borders
+func (m *Model) SetNoColor(noColor bool) {
+	m.noColor = noColor
+}
+
+// GetCopyResultOnEquals returns whether evaluating also copies the result
+func (m Model) GetCopyResultOnEquals() bool {
+	return m.copyResultOnEquals
+}
+
+// SetCopyResultOnEquals sets whether evaluating also copies the result to the clipboard
+func (m *Model) SetCopyResultOnEquals(enabled bool) {
+	m.copyResultOnEquals = enabled
+}
+
+// IsWideZero returns whether the 0 key spans two columns
+func (m Model) IsWideZero() bool {
+	return m.buttonGrid.IsWideZero()
+}
+
+// SetWideZero sets whether the 0 key spans two columns like a Casio's. In the
+// right-operator layout the ± key gives up its place; n still negates.
+func (m *Model) SetWideZero(enabled bool) {
+	m.buttonGrid.SetWideZero(enabled)
+	m.syncButtonBounds()
+}
+
+// IsScientificMode returns whether the button grid shows the scientific rows
+func (m Model) IsScientificMode() bool {
+	return m.buttonGrid.IsScientificMode()
+}
+
+// SetScientificMode shows or hides the scientific rows of the button grid,
+// with parentheses, the "," argument separator, the root function, EXP, the
+// factorial and a general power. The "," key types the separator only while
+// the rows are shown.
+func (m *Model) SetScientificMode(enabled bool) {
+	m.buttonGrid.SetScientificMode(enabled)
+	m.syncButtonBounds()
+}
+
+// IsBorderless returns whether the button grid is drawn without borders
+func (m Model) IsBorderless() bool {
+	return m.buttonGrid.IsBorderless()
+}
+
+// SetBorderless sets whether the button grid is drawn without borders, which
+// saves space on small terminals
+func (m *Model) SetBorderless(borderless bool) {
+	m.buttonGrid.SetBorderless(borderless)
+	m.syncButtonBounds()
+}
+
+// BorderlessRequested reports whether a --borderless argument asked for the
+// borderless button grid
+func BorderlessRequested(args []string) bool {
+	for _, arg := range args {
+		if arg == "--borderless" {
+			return true
+		}
+	}
+	return false
+}
+
+// SetHoverTracker sets the tracker that follows mouse motion over the buttons.
+// Hovered buttons are rendered with the theme's hover style.
+func (m *Model) SetHoverTracker(tracker HoverTracker) {
+	m.hover = tracker
+	m.buttonGrid.SetHoverReporter(tracker)
+	m.syncButtonBounds()
+}
+
+// SetRPNStack sets the source of the RPN stack. While it is in RPN mode the
+// top stack values are shown above the input line.
+func (m *Model) SetRPNStack(stack RPNStack) {
+	m.rpn = stack
+	m.syncStackView()
+	m.refreshButtonBounds()
+}
+
+// syncStackView copies the RPN stack into the stack view while in RPN mode
+func (m *Model) syncStackView() {
+	if m.rpn != nil && m.rpn.IsRPNMode() {
+		m.stackView.SetValues(m.rpn.GetStack())
+	}
+}
+
+// SetKeyRemapper sets the custom keymap key presses are translated through
+// before they are handled; nil restores the default keys
+func (m *Model) SetKeyRemapper(remapper KeyRemapper) {
+	m.keyRemapper = remapper
+}
+
+// GetStackView returns the RPN stack view component
+func (m Model) GetStackView() *components.StackView {
+	return m.stackView
+}
+
 // GetButtonGrid returns the button grid component
 func (m Model) GetButtonGrid() *uiintegration.ButtonGrid {
 	return m.buttonGrid
@@ -303,11 +1076,22 @@ func (m *Model) SetButtonGridTheme(themeName string) error {
 	return m.buttonGrid.SetTheme(themeName)
 }
 
+// LoadThemeFile loads a custom theme from a JSON or YAML file and returns its
+// name, which SetButtonGridTheme then accepts
+func (m *Model) LoadThemeFile(path string) (string, error) {
+	return m.buttonGrid.LoadThemeFile(path)
+}
+
 // GetButtonGridTheme returns the current button grid theme
 func (m Model) GetButtonGridTheme() string {
 	return m.buttonGrid.GetCurrentTheme()
 }
 
+// GetThemeBackground returns the background color of the current button grid theme
+func (m Model) GetThemeBackground() lipgloss.Color {
+	return m.buttonGrid.GetThemeBackground()
+}
+
 // GetAudioIntegration returns the audio integration component
 func (m Model) GetAudioIntegration() *audio.Integration {
 	return m.audioIntegration
@@ -1,13 +1,46 @@
 package ui
 
 import (
+	"strings"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
 
 	"ccpm-demo/internal/calculator"
+	uiintegration "ccpm-demo/internal/ui/integration"
 )
 
+// sendKeys feeds key messages through Update in order
+func sendKeys(m Model, msgs ...tea.KeyMsg) Model {
+	for _, msg := range msgs {
+		updated, _ := m.Update(msg)
+		m = updated.(Model)
+	}
+	return m
+}
+
+// runeKey returns the key message for typing a single character
+func runeKey(r rune) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}
+}
+
+// runCmd runs a command returned by Update and feeds its message back in
+func runCmd(m Model, cmd tea.Cmd) Model {
+	if cmd == nil {
+		return m
+	}
+	updated, _ := m.Update(cmd())
+	return updated.(Model)
+}
+
+// typeKeys types each character of keys through Update
+func typeKeys(m Model, keys string) Model {
+	for _, r := range keys {
+		m = sendKeys(m, runeKey(r))
+	}
+	return m
+}
+
 func TestNewModel(t *testing.T) {
 	engine := calculator.NewEngine()
 	model := NewModel(engine)
@@ -83,6 +116,67 @@ func TestModelUpdateWindowSize(t *testing.T) {
 	}
 }
 
+func TestModelMouseBoundsFollowResize(t *testing.T) {
+	engine := calculator.NewEngine()
+	model := NewModel(engine)
+
+	clickButton := func(m Model, buttonID string) Model {
+		rect, exists := m.buttonGrid.GetButtonBounds()[buttonID]
+		if !exists {
+			t.Fatalf("Expected bounds to be registered for %s", buttonID)
+		}
+		updated, _ := m.Update(tea.MouseMsg{X: rect.X, Y: rect.Y, Type: tea.MouseLeft})
+		return updated.(Model)
+	}
+
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 80, Height: 40})
+	model = clickButton(updated.(Model), "button_2_1")
+	if model.input != "5" {
+		t.Errorf("Expected click at width 80 to hit '5', input is '%s'", model.input)
+	}
+
+	updated, _ = model.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	model = clickButton(updated.(Model), "button_2_1")
+	if model.input != "55" {
+		t.Errorf("Expected click at width 120 to hit '5', input is '%s'", model.input)
+	}
+
+	// Re-registering replaces the old bounds rather than accumulating stale ones
+	if len(model.buttonGrid.GetButtonBounds()) != model.buttonGrid.GetButtonCount() {
+		t.Errorf("Expected %d registered bounds, got %d",
+			model.buttonGrid.GetButtonCount(), len(model.buttonGrid.GetButtonBounds()))
+	}
+
+	// Rendering leaves the registered bounds alone
+	before := model.buttonGrid.GetButtonBounds()["button_2_1"]
+	model.View()
+	if after := model.buttonGrid.GetButtonBounds()["button_2_1"]; after != before {
+		t.Errorf("Expected View to leave the bounds unchanged, %+v became %+v", before, after)
+	}
+}
+
+func TestModelMouseBoundsFollowHeaderHeight(t *testing.T) {
+	updated, _ := NewModel(calculator.NewEngine()).Update(tea.WindowSizeMsg{Width: 80, Height: 40})
+	model := updated.(Model)
+	before := model.buttonGrid.GetButtonBounds()["button_2_1"]
+
+	// An error line above the grid pushes the buttons down
+	model = typeKeys(model, "2+=")
+	if model.GetError() == "" {
+		t.Fatal("Expected evaluating '2 + ' to report an error")
+	}
+
+	after := model.buttonGrid.GetButtonBounds()["button_2_1"]
+	if after.Y <= before.Y {
+		t.Errorf("Expected the bounds to move down with the error line, Y went from %d to %d", before.Y, after.Y)
+	}
+
+	updated, _ = model.Update(tea.MouseMsg{X: after.X, Y: after.Y, Type: tea.MouseLeft})
+	if input := updated.(Model).GetInput(); input != "2 + 5" {
+		t.Errorf("Expected the click to hit '5', input is '%s'", input)
+	}
+}
+
 func TestModelUpdateKeyMessages(t *testing.T) {
 	engine := calculator.NewEngine()
 	model := NewModel(engine)
@@ -167,6 +261,56 @@ func TestModelFormatting(t *testing.T) {
 	}
 }
 
+func TestModelFormattingDirectionalRounding(t *testing.T) {
+	tests := []struct {
+		mode     calculator.RoundingMode
+		value    float64
+		expected string
+	}{
+		{calculator.RoundCeil, 0.1 + 0.2, "0.300000"},
+		{calculator.RoundCeil, 1.1 * 3, "3.300000"},
+		{calculator.RoundFloor, 1.005, "1.005000"},
+	}
+
+	for _, tt := range tests {
+		engine := calculator.NewEngine()
+		engine.SetRoundingMode(tt.mode)
+		model := NewModel(engine)
+
+		if result := model.formatValue(tt.value); result != tt.expected {
+			t.Errorf("formatValue(%v) with %s = '%s', expected '%s'", tt.value, tt.mode, result, tt.expected)
+		}
+	}
+}
+
+func TestModelPrecision(t *testing.T) {
+	updated, _ := NewModel(calculator.NewEngine()).Update(tea.WindowSizeMsg{Width: 80, Height: 40})
+	model := updated.(Model)
+	model.SetPrecision(3, calculator.PrecisionDecimals)
+
+	model = typeKeys(model, "100/3=")
+	if model.GetOutput() != "33.333" {
+		t.Errorf("Expected 100/3 to show '33.333' at 3 decimal places, got '%s'", model.GetOutput())
+	}
+
+	// The display follows the calculator's formatting
+	calc := calculator.NewCalculator()
+	calc.SetPrecision(3)
+	if want := calc.Format(100.0 / 3); model.GetOutput() != want {
+		t.Errorf("Expected the display to match the calculator's '%s', got '%s'", want, model.GetOutput())
+	}
+
+	model.SetPrecision(0, calculator.PrecisionDecimals)
+	if result := model.formatValue(36); result != "36" {
+		t.Errorf("Expected precision 0 to show integers cleanly, got '%s'", result)
+	}
+
+	model.SetPrecision(calculator.DefaultPrecision, calculator.PrecisionDecimals)
+	if result := model.formatValue(1.5); result != "1.500000" {
+		t.Errorf("Expected the default precision to show six decimal places, got '%s'", result)
+	}
+}
+
 func TestModelTruncateString(t *testing.T) {
 	engine := calculator.NewEngine()
 	model := NewModel(engine)
@@ -191,6 +335,642 @@ func TestModelTruncateString(t *testing.T) {
 	}
 }
 
+func TestModelResultTruncation(t *testing.T) {
+	engine := calculator.NewEngine()
+	model := NewModel(engine)
+
+	longResult := "12345678901234567890123456789012345678901234567890123456789012345678901234567890"
+	model.SetOutput(longResult)
+	model.calculatorState.displayValue = longResult
+
+	view := model.View()
+	if contains(view, longResult) {
+		t.Error("View should not contain the full result when it exceeds the display width")
+	}
+	if !contains(view, "…") {
+		t.Error("View should mark a truncated result with an ellipsis")
+	}
+
+	if model.CopyResult() != longResult {
+		t.Errorf("CopyResult() = '%s', expected the full value '%s'", model.CopyResult(), longResult)
+	}
+
+	if result := model.truncateResult("12345", 10); result != "12345" {
+		t.Errorf("truncateResult should leave short results untouched, got '%s'", result)
+	}
+	if result := model.truncateResult("1234567890", 5); result != "1234…" {
+		t.Errorf("truncateResult('1234567890', 5) = '%s', expected '1234…'", result)
+	}
+}
+
+func TestModelDisplayAlignment(t *testing.T) {
+	engine := calculator.NewEngine()
+	model := NewModel(engine)
+	model.output = "42"
+	model.calculatorState.displayValue = "42"
+
+	if model.GetDisplayAlignment() != DisplayAlignRight {
+		t.Errorf("Expected default alignment right, got %s", model.GetDisplayAlignment())
+	}
+
+	firstLine := func(m Model) string {
+		return strings.Split(m.renderDisplay(m.updateStyles()), "\n")[0]
+	}
+
+	line := firstLine(model)
+	if !strings.HasSuffix(line, "42 ") || !strings.HasPrefix(line, "   ") {
+		t.Errorf("Right-aligned display should pad on the left, got '%s'", line)
+	}
+
+	model.SetDisplayAlignment(DisplayAlignLeft)
+	line = firstLine(model)
+	if !strings.HasPrefix(line, " 42") || !strings.HasSuffix(line, "   ") {
+		t.Errorf("Left-aligned display should pad on the right, got '%s'", line)
+	}
+
+	model.ToggleDisplayAlignment()
+	if model.GetDisplayAlignment() != DisplayAlignRight {
+		t.Errorf("Toggling from left should restore right alignment, got %s", model.GetDisplayAlignment())
+	}
+	model = typeKeys(model, "a")
+	if model.GetDisplayAlignment() != DisplayAlignLeft {
+		t.Errorf("Pressing 'a' should toggle to left alignment, got %s", model.GetDisplayAlignment())
+	}
+}
+
+// fakeClipboard records the text written to it
+type fakeClipboard struct {
+	text   string
+	writes int
+}
+
+func (c *fakeClipboard) WriteText(text string) error {
+	c.text = text
+	c.writes++
+	return nil
+}
+
+func TestModelCopyHistory(t *testing.T) {
+	engine := calculator.NewEngine()
+	model := NewModel(engine)
+	clipboard := &fakeClipboard{}
+	model.SetClipboard(clipboard)
+
+	model.addToHistory("1 + 1 = 2")
+	model.addToHistory("2 * 3 = 6")
+	model.addToHistory("10 / 4 = 2.500000")
+
+	updatedModel, cmd := model.Update(runeKey('Y'))
+	if clipboard.writes != 0 {
+		t.Error("Expected the clipboard to be written by the returned command, not during Update")
+	}
+	um := runCmd(updatedModel.(Model), cmd)
+
+	expected := "1 + 1 = 2\n2 * 3 = 6\n10 / 4 = 2.500000"
+	if clipboard.text != expected {
+		t.Errorf("Expected history copied verbatim as '%s', got '%s'", expected, clipboard.text)
+	}
+	if um.error != "" {
+		t.Errorf("Expected no error after copying, got '%s'", um.error)
+	}
+}
+
+func TestModelCopyResult(t *testing.T) {
+	engine := calculator.NewEngine()
+	model := NewModel(engine)
+	clipboard := &fakeClipboard{}
+	model.SetClipboard(clipboard)
+	model.SetOutput("42")
+
+	updated, cmd := model.Update(runeKey('y'))
+	runCmd(updated.(Model), cmd)
+
+	if clipboard.text != "42" {
+		t.Errorf("Expected result '42' to be copied, got '%s'", clipboard.text)
+	}
+}
+
+func TestModelCopyWithoutClipboard(t *testing.T) {
+	model := NewModel(calculator.NewEngine())
+	model.SetClipboard(nil)
+	model.SetOutput("42")
+
+	updated, cmd := model.Update(runeKey('y'))
+	m := runCmd(updated.(Model), cmd)
+
+	if m.GetError() != "clipboard is not configured" {
+		t.Errorf("Expected the copy failure to be reported, got '%s'", m.GetError())
+	}
+}
+
+func TestModelHasNoDefaultClipboard(t *testing.T) {
+	// The program provides the clipboard, so the model never writes to the terminal itself
+	if clipboard := NewModel(calculator.NewEngine()).GetClipboard(); clipboard != nil {
+		t.Errorf("Expected no clipboard until one is set, got %T", clipboard)
+	}
+}
+
+func TestModelCopyResultOnEquals(t *testing.T) {
+	t.Run("copies the result when enabled", func(t *testing.T) {
+		model := NewModel(calculator.NewEngine())
+		clipboard := &fakeClipboard{}
+		model.SetClipboard(clipboard)
+		model.SetCopyResultOnEquals(true)
+
+		updated, cmd := typeKeys(model, "2+3").Update(runeKey('='))
+		m := runCmd(updated.(Model), cmd)
+
+		if m.GetOutput() != "5" {
+			t.Errorf("Expected output '5', got '%s'", m.GetOutput())
+		}
+		if clipboard.text != "5" {
+			t.Errorf("Expected '5' to be copied, got '%s'", clipboard.text)
+		}
+	})
+
+	t.Run("copies nothing by default", func(t *testing.T) {
+		model := NewModel(calculator.NewEngine())
+		clipboard := &fakeClipboard{}
+		model.SetClipboard(clipboard)
+
+		if model.GetCopyResultOnEquals() {
+			t.Error("Expected copy on equals to be off by default")
+		}
+
+		updated, cmd := typeKeys(model, "2+3").Update(runeKey('='))
+		runCmd(updated.(Model), cmd)
+
+		if clipboard.writes != 0 {
+			t.Errorf("Expected no clipboard writes, got %d", clipboard.writes)
+		}
+	})
+}
+
+func TestModelClearHistory(t *testing.T) {
+	engine := calculator.NewEngine()
+	model := NewModel(engine)
+
+	model.addToHistory("1 + 1 = 2")
+	model.addToHistory("2 * 3 = 6")
+	model.SetInput("12 + 3")
+
+	updatedModel, _ := model.Update(ClearHistoryMsg{})
+	um := updatedModel.(Model)
+
+	if len(um.GetHistory()) != 0 {
+		t.Errorf("Expected empty history after clearing, got %d entries", len(um.GetHistory()))
+	}
+	if um.GetInput() != "12 + 3" {
+		t.Errorf("Expected input '12 + 3' to be intact, got '%s'", um.GetInput())
+	}
+}
+
+func TestModelClearHistoryConfirmation(t *testing.T) {
+	engine := calculator.NewEngine()
+	model := NewModel(engine)
+	model.SetConfirmClearHistory(true)
+	model.addToHistory("1 + 1 = 2")
+
+	clearKey := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'X'}}
+
+	updatedModel, _ := model.Update(clearKey)
+	um := updatedModel.(Model)
+	if len(um.GetHistory()) != 1 {
+		t.Error("First press should only ask for confirmation")
+	}
+	if !contains(um.View(), "Press X again to clear history") {
+		t.Error("View should show the confirmation prompt")
+	}
+
+	// Any other key cancels the pending confirmation
+	updatedModel, _ = um.Update(tea.KeyMsg{Type: tea.KeyRight})
+	updatedModel, _ = updatedModel.(Model).Update(clearKey)
+	um = updatedModel.(Model)
+	if len(um.GetHistory()) != 1 {
+		t.Error("Confirmation should be cancelled by other keys")
+	}
+
+	updatedModel, _ = um.Update(clearKey)
+	um = updatedModel.(Model)
+	if len(um.GetHistory()) != 0 {
+		t.Errorf("Second press should clear the history, got %d entries", len(um.GetHistory()))
+	}
+}
+
+func TestModelUndoLastCommit(t *testing.T) {
+	model := typeKeys(NewModel(calculator.NewEngine()), "1+1=2+3=")
+	if len(model.GetHistory()) != 2 {
+		t.Fatalf("Expected 2 history entries, got %d", len(model.GetHistory()))
+	}
+
+	model = typeKeys(model, "U")
+	if len(model.GetHistory()) != 1 || model.GetHistory()[0] != "1 + 1 = 2" {
+		t.Errorf("Expected only the latest entry to be removed, got %v", model.GetHistory())
+	}
+	if model.GetInput() != "2 + 3" {
+		t.Errorf("Expected '2 + 3' back on the input line, got '%s'", model.GetInput())
+	}
+	if model.GetOutput() != "2" || model.DisplayText() != "2" {
+		t.Errorf("Expected the previous result to be shown, got output '%s' and display '%s'", model.GetOutput(), model.DisplayText())
+	}
+
+	// The restored expression can be edited and committed again
+	model = typeKeys(model, "0=")
+	if model.GetOutput() != "32" {
+		t.Errorf("Expected '2 + 30' to evaluate to 32, got '%s'", model.GetOutput())
+	}
+
+	model = typeKeys(model, "UU")
+	if len(model.GetHistory()) != 0 || model.GetInput() != "1 + 1" || model.GetOutput() != "" {
+		t.Errorf("Expected an empty history with '1 + 1' restored, got %v, '%s', '%s'", model.GetHistory(), model.GetInput(), model.GetOutput())
+	}
+	if model.UndoLastCommit() {
+		t.Error("Expected nothing to undo with an empty history")
+	}
+}
+
+func TestModelParentheses(t *testing.T) {
+	engine := calculator.NewEngine()
+	model := NewModel(engine)
+
+	t.Run("typing parentheses inserts them", func(t *testing.T) {
+		m := typeKeys(model, "(2")
+		if m.GetInput() != "(2" {
+			t.Errorf("Expected input '(2', got '%s'", m.GetInput())
+		}
+
+		m = typeKeys(m, ")")
+		if m.GetInput() != "(2)" {
+			t.Errorf("Expected input '(2)', got '%s'", m.GetInput())
+		}
+	})
+
+	t.Run("jumps from opening to closing bracket", func(t *testing.T) {
+		m := model
+		m.SetInput("(1 + (2 * 3))")
+		m.SetCursorPosition(0)
+
+		m = typeKeys(m, "m")
+		if m.GetCursorPosition() != 12 {
+			t.Errorf("Expected cursor at 12, got %d", m.GetCursorPosition())
+		}
+	})
+
+	t.Run("jumps from closing to opening bracket", func(t *testing.T) {
+		m := model
+		m.SetInput("(1 + (2 * 3))")
+		m.SetCursorPosition(11)
+
+		m = typeKeys(m, "m")
+		if m.GetCursorPosition() != 5 {
+			t.Errorf("Expected cursor at 5, got %d", m.GetCursorPosition())
+		}
+	})
+
+	t.Run("uses the bracket just before the cursor", func(t *testing.T) {
+		m := model
+		m.SetInput("(1 + 2)")
+
+		m = typeKeys(m, "m")
+		if m.GetCursorPosition() != 0 {
+			t.Errorf("Expected cursor at 0, got %d", m.GetCursorPosition())
+		}
+	})
+
+	t.Run("leaves the cursor alone when unbalanced", func(t *testing.T) {
+		m := model
+		m.SetInput("(1 + 2")
+		m.SetCursorPosition(0)
+
+		if m.JumpToMatchingBracket() {
+			t.Error("Expected no match for an unbalanced bracket")
+		}
+		if m.GetCursorPosition() != 0 {
+			t.Errorf("Expected cursor to stay at 0, got %d", m.GetCursorPosition())
+		}
+	})
+}
+
+func TestModelDisplayDigitLimit(t *testing.T) {
+	engine := calculator.NewEngine()
+
+	t.Run("long result switches to scientific notation", func(t *testing.T) {
+		model := NewModel(engine)
+		model.SetMaxDisplayDigits(10)
+
+		formatted := model.formatValue(123456789012)
+		if formatted != "1.23456789e+11" {
+			t.Errorf("Expected scientific form '1.23456789e+11', got '%s'", formatted)
+		}
+	})
+
+	t.Run("short result is unaffected", func(t *testing.T) {
+		model := NewModel(engine)
+		model.SetMaxDisplayDigits(10)
+
+		if formatted := model.formatValue(579); formatted != "579" {
+			t.Errorf("Expected '579', got '%s'", formatted)
+		}
+		if formatted := model.formatValue(6.28); formatted != "6.280000" {
+			t.Errorf("Expected '6.280000', got '%s'", formatted)
+		}
+	})
+
+	t.Run("decimals are dropped before overflowing", func(t *testing.T) {
+		model := NewModel(engine)
+		model.SetMaxDisplayDigits(10)
+
+		if formatted := model.formatValue(12345678.5); formatted != "12345678.50" {
+			t.Errorf("Expected '12345678.50', got '%s'", formatted)
+		}
+	})
+
+	t.Run("rounding that carries into an extra digit", func(t *testing.T) {
+		model := NewModel(engine)
+		model.SetMaxDisplayDigits(10)
+
+		if formatted := model.formatValue(99999999.999); formatted != "100000000.0" {
+			t.Errorf("Expected '100000000.0', got '%s'", formatted)
+		}
+		if formatted := model.formatValue(9999999999.7); formatted != "1e+10" {
+			t.Errorf("Expected scientific form '1e+10', got '%s'", formatted)
+		}
+
+		model.SetDigitOverflowMode(DigitOverflowIndicator)
+		if formatted := model.formatValue(9999999999.7); formatted != "Overflow" {
+			t.Errorf("Expected 'Overflow', got '%s'", formatted)
+		}
+	})
+
+	t.Run("overflow indicator sub-option", func(t *testing.T) {
+		model := NewModel(engine)
+		model.SetMaxDisplayDigits(10)
+		model.SetDigitOverflowMode(DigitOverflowIndicator)
+
+		if formatted := model.formatValue(123456789012); formatted != "Overflow" {
+			t.Errorf("Expected 'Overflow', got '%s'", formatted)
+		}
+	})
+
+	t.Run("unlimited by default", func(t *testing.T) {
+		model := NewModel(engine)
+
+		if formatted := model.formatValue(123456789012); formatted != "123456789012" {
+			t.Errorf("Expected '123456789012', got '%s'", formatted)
+		}
+	})
+}
+
+func TestModelOperatorPreview(t *testing.T) {
+	t.Run("pending operator shows a placeholder", func(t *testing.T) {
+		model := NewModel(calculator.NewEngine())
+
+		m := typeKeys(model, "12+")
+
+		if m.DisplayText() != "12 + ?" {
+			t.Errorf("Expected display '12 + ?', got '%s'", m.DisplayText())
+		}
+		if !strings.Contains(m.View(), "12 + ?") {
+			t.Error("Expected the view to show the pending operation")
+		}
+	})
+
+	t.Run("next operand replaces the placeholder", func(t *testing.T) {
+		model := NewModel(calculator.NewEngine())
+
+		m := typeKeys(model, "12+3")
+
+		if m.DisplayText() != "12 + 3" {
+			t.Errorf("Expected display '12 + 3', got '%s'", m.DisplayText())
+		}
+		if strings.Contains(m.View(), "?") {
+			t.Error("Expected the placeholder to be gone after entering an operand")
+		}
+	})
+
+	t.Run("preview can be disabled", func(t *testing.T) {
+		model := NewModel(calculator.NewEngine())
+		model.SetOperatorPreview(false)
+
+		m := typeKeys(model, "12+")
+
+		if m.DisplayText() != "12" {
+			t.Errorf("Expected display '12', got '%s'", m.DisplayText())
+		}
+	})
+}
+
+func TestModelNegateOperand(t *testing.T) {
+	updated, _ := NewModel(calculator.NewEngine()).Update(tea.WindowSizeMsg{Width: 80, Height: 40})
+	model := updated.(Model)
+
+	model = typeKeys(model, "5")
+	model, _ = pressButton(t, model, "negate")
+	if model.GetInput() != "-5" {
+		t.Errorf("Expected ± to turn '5' into '-5', got '%s'", model.GetInput())
+	}
+	model, _ = pressButton(t, model, "negate")
+	if model.GetInput() != "5" {
+		t.Errorf("Expected a second ± to restore '5', got '%s'", model.GetInput())
+	}
+
+	// Only the operand being entered is negated
+	model = typeKeys(NewModel(calculator.NewEngine()), "12+3n")
+	if model.GetInput() != "12 + -3" {
+		t.Errorf("Expected 'n' to negate only the active operand, got '%s'", model.GetInput())
+	}
+	model = typeKeys(model, "=")
+	if model.GetOutput() != "9" {
+		t.Errorf("Expected '12 + -3' to evaluate to 9, got '%s'", model.GetOutput())
+	}
+
+	// A binary minus is left alone
+	model = typeKeys(NewModel(calculator.NewEngine()), "12-3n")
+	if model.GetInput() != "12 - -3" {
+		t.Errorf("Expected the operator to stay, got '%s'", model.GetInput())
+	}
+
+	// With no operand there is nothing to negate
+	model = typeKeys(NewModel(calculator.NewEngine()), "12+n")
+	if model.GetInput() != "12 + " {
+		t.Errorf("Expected the input to be unchanged, got '%s'", model.GetInput())
+	}
+}
+
+func TestModelReciprocal(t *testing.T) {
+	updated, _ := NewModel(calculator.NewEngine()).Update(tea.WindowSizeMsg{Width: 80, Height: 40})
+	model := updated.(Model)
+
+	model = typeKeys(model, "4")
+	model, _ = pressButton(t, model, "reciprocal")
+	if model.GetInput() != "1/(4)" {
+		t.Errorf("Expected 1/x to wrap '4' as '1/(4)', got '%s'", model.GetInput())
+	}
+	model = typeKeys(model, "=")
+	if model.GetOutput() != "0.250000" {
+		t.Errorf("Expected 1/(4) to evaluate to 0.25, got '%s'", model.GetOutput())
+	}
+
+	// Zero is rejected and the input left alone
+	model = typeKeys(NewModel(calculator.NewEngine()), "0i")
+	if model.GetError() == "" {
+		t.Error("Expected the reciprocal of 0 to set an error")
+	}
+	if model.GetInput() != "0" {
+		t.Errorf("Expected the input to stay '0', got '%s'", model.GetInput())
+	}
+
+	// Only the current operand is wrapped, brackets and all
+	model = typeKeys(NewModel(calculator.NewEngine()), "2+(1+1)i")
+	if model.GetInput() != "2 + 1/((1 + 1))" {
+		t.Errorf("Expected the bracketed operand to be wrapped, got '%s'", model.GetInput())
+	}
+	model = typeKeys(model, "=")
+	if model.GetOutput() != "2.500000" {
+		t.Errorf("Expected 2 + 1/(2) to evaluate to 2.5, got '%s'", model.GetOutput())
+	}
+
+	// With nothing typed the last result is inverted
+	model = typeKeys(model, "i")
+	if model.GetInput() != "1/(2.500000)" {
+		t.Errorf("Expected the last result to be wrapped, got '%s'", model.GetInput())
+	}
+}
+
+func TestModelPowers(t *testing.T) {
+	updated, _ := NewModel(calculator.NewEngine()).Update(tea.WindowSizeMsg{Width: 80, Height: 40})
+	start := updated.(Model)
+
+	model := typeKeys(start, "5")
+	model, _ = pressButton(t, model, "square")
+	if model.GetInput() != "5^2" {
+		t.Errorf("Expected x² to turn '5' into '5^2', got '%s'", model.GetInput())
+	}
+	model = typeKeys(model, "=")
+	if model.GetOutput() != "25" {
+		t.Errorf("Expected 5^2 to evaluate to 25, got '%s'", model.GetOutput())
+	}
+
+	model = typeKeys(start, "2")
+	model, _ = pressButton(t, model, "cube")
+	if model.GetInput() != "2^3" {
+		t.Errorf("Expected x³ to turn '2' into '2^3', got '%s'", model.GetInput())
+	}
+	model = typeKeys(model, "=")
+	if model.GetOutput() != "8" {
+		t.Errorf("Expected 2^3 to evaluate to 8, got '%s'", model.GetOutput())
+	}
+
+	// Only the current operand is raised
+	model = typeKeys(start, "1+3")
+	model, _ = pressButton(t, model, "square")
+	model = typeKeys(model, "=")
+	if model.GetOutput() != "10" {
+		t.Errorf("Expected 1 + 3^2 to evaluate to 10, got '%s'", model.GetOutput())
+	}
+
+	// A negative operand is bracketed before it is raised
+	model = typeKeys(start, "5n")
+	model, _ = pressButton(t, model, "square")
+	if model.GetInput() != "(-5)^2" {
+		t.Errorf("Expected x² to turn '-5' into '(-5)^2', got '%s'", model.GetInput())
+	}
+	model = typeKeys(model, "=")
+	if model.GetOutput() != "25" {
+		t.Errorf("Expected (-5)^2 to evaluate to 25, got '%s'", model.GetOutput())
+	}
+
+	model = typeKeys(start, "12+3n")
+	model, _ = pressButton(t, model, "square")
+	model = typeKeys(model, "=")
+	if model.GetOutput() != "21" {
+		t.Errorf("Expected 12 + (-3)^2 to evaluate to 21, got '%s'", model.GetOutput())
+	}
+
+	// A negative last result is bracketed too
+	model = typeKeys(start, "2-5=")
+	model, _ = pressButton(t, model, "square")
+	if model.GetInput() != "(-3)^2" {
+		t.Errorf("Expected x² to turn the last result '-3' into '(-3)^2', got '%s'", model.GetInput())
+	}
+
+	// With no operand there is nothing to raise
+	model = typeKeys(start, "1+")
+	model, _ = pressButton(t, model, "square")
+	if model.GetInput() != "1 + " {
+		t.Errorf("Expected the input to be unchanged, got '%s'", model.GetInput())
+	}
+}
+
+func TestModelFactorial(t *testing.T) {
+	updated, _ := NewModel(calculator.NewEngine()).Update(tea.WindowSizeMsg{Width: 80, Height: 40})
+	start := updated.(Model)
+
+	model := typeKeys(start, "2+5!")
+	if model.GetInput() != "2 + 5!" {
+		t.Errorf("Expected '!' to follow the operand, got '%s'", model.GetInput())
+	}
+	model = typeKeys(model, "=")
+	if model.GetOutput() != "122" {
+		t.Errorf("Expected 2 + 5! to evaluate to 122, got '%s'", model.GetOutput())
+	}
+
+	// With nothing typed the last result is used
+	model = typeKeys(start, "3=!=")
+	if model.GetOutput() != "6" {
+		t.Errorf("Expected 3! to evaluate to 6, got '%s'", model.GetOutput())
+	}
+
+	// With no operand there is nothing to take the factorial of
+	model = typeKeys(start, "1+!")
+	if model.GetInput() != "1 + " {
+		t.Errorf("Expected the input to be unchanged, got '%s'", model.GetInput())
+	}
+
+	model = typeKeys(start, "2.5!=")
+	if !strings.Contains(model.GetError(), "factorial") {
+		t.Errorf("Expected a factorial error for 2.5!, got '%s'", model.GetError())
+	}
+}
+
+func TestModelAutoFocusEquals(t *testing.T) {
+	focusedValue := func(m Model) string {
+		if button, ok := m.GetButtonGrid().GetFocusedButton(); ok {
+			return button.GetValue()
+		}
+		return ""
+	}
+
+	off := typeKeys(NewModel(calculator.NewEngine()), "12+3")
+	if off.GetInput() != "12 + 3" {
+		t.Fatalf("Expected input '12 + 3', got '%s'", off.GetInput())
+	}
+	if focusedValue(off) != "3" {
+		t.Errorf("Expected focus to stay on the last key when off, got '%s'", focusedValue(off))
+	}
+
+	on := NewModel(calculator.NewEngine())
+	on.SetAutoFocusEquals(true)
+	on = typeKeys(on, "12+")
+	if focusedValue(on) == "=" {
+		t.Error("Expected no jump to '=' before the second operand")
+	}
+	on = typeKeys(on, "3")
+	if focusedValue(on) != "=" {
+		t.Errorf("Expected focus on '=' after the operand, got '%s'", focusedValue(on))
+	}
+
+	on = typeKeys(on, "4")
+	if focusedValue(on) != "=" {
+		t.Errorf("Expected focus to stay on '=' while typing the operand, got '%s'", focusedValue(on))
+	}
+
+	on = sendKeys(on, tea.KeyMsg{Type: tea.KeyEnter})
+	if on.GetOutput() != "46" {
+		t.Errorf("Expected Enter on the focused '=' to evaluate 12 + 34, got '%s'", on.GetOutput())
+	}
+}
+
 func TestModelHistory(t *testing.T) {
 	engine := calculator.NewEngine()
 	model := NewModel(engine)
@@ -219,6 +999,84 @@ func TestModelHistory(t *testing.T) {
 	}
 }
 
+func TestModelDisplayHistoryRows(t *testing.T) {
+	updated, _ := NewModel(calculator.NewEngine()).Update(tea.WindowSizeMsg{Width: 80, Height: 40})
+	model := updated.(Model)
+
+	model = typeKeys(model, "10+1=")
+	if header := model.renderHeader(model.updateStyles()); strings.Contains(header, model.history[0]) {
+		t.Error("Expected no history rows above the input by default")
+	}
+
+	model.SetDisplayHistoryRows(3)
+	for _, expr := range []string{"20+1=", "30+1=", "40+1=", "50+1="} {
+		model = typeKeys(model, expr)
+	}
+	model = typeKeys(model, "7")
+
+	rows := model.DisplayHistoryRows()
+	if len(rows) != 3 || rows[0] != model.history[2] || rows[2] != model.history[4] {
+		t.Fatalf("Expected the three most recent entries, got %v", rows)
+	}
+
+	header := model.renderHeader(model.updateStyles())
+	for _, entry := range model.history[:2] {
+		if strings.Contains(header, entry) {
+			t.Errorf("Expected '%s' to have scrolled out of view", entry)
+		}
+	}
+
+	// Oldest first, all above the input line
+	last := -1
+	for _, entry := range rows {
+		index := strings.Index(header, entry)
+		if index <= last {
+			t.Errorf("Expected '%s' to render after the previous row, header:\n%s", entry, header)
+		}
+		last = index
+	}
+	if strings.LastIndex(header, "7") < last {
+		t.Error("Expected the history rows to render above the input line")
+	}
+
+	model.SetDisplayHistoryRows(0)
+	if rows := model.DisplayHistoryRows(); len(rows) != 0 {
+		t.Errorf("Expected 0 rows to disable the history rows, got %v", rows)
+	}
+}
+
+func TestModelInputGrouping(t *testing.T) {
+	updated, _ := NewModel(calculator.NewEngine()).Update(tea.WindowSizeMsg{Width: 80, Height: 40})
+	model := updated.(Model)
+
+	model = typeKeys(model, "1234567")
+	if model.InputDisplayText() != "1234567" {
+		t.Errorf("Expected no grouping by default, got '%s'", model.InputDisplayText())
+	}
+
+	model.SetInputGrouping(true)
+	if model.InputDisplayText() != "1,234,567" {
+		t.Errorf("Expected '1,234,567', got '%s'", model.InputDisplayText())
+	}
+	if model.GetInput() != "1234567" {
+		t.Errorf("Expected the stored input to stay '1234567', got '%s'", model.GetInput())
+	}
+	if header := model.renderHeader(model.updateStyles()); !strings.Contains(header, "1,234,567") {
+		t.Errorf("Expected the input line to show the grouped number, header:\n%s", header)
+	}
+
+	// Each number is grouped; fractional digits are not
+	model = typeKeys(model, "+1000.2345")
+	if model.InputDisplayText() != "1,234,567 + 1,000.2345" {
+		t.Errorf("Expected each integer part to be grouped, got '%s'", model.InputDisplayText())
+	}
+
+	model = typeKeys(model, "=")
+	if !strings.HasPrefix(model.GetOutput(), "1235567.2345") {
+		t.Errorf("Expected the ungrouped input to be evaluated, got '%s'", model.GetOutput())
+	}
+}
+
 func TestModelErrorHandling(t *testing.T) {
 	engine := calculator.NewEngine()
 	model := NewModel(engine)
@@ -294,4 +1152,65 @@ func findSubstring(s, substr string) bool {
 		}
 	}
 	return false
-}
\ No newline at end of file
+}
+func TestModelExponentEntry(t *testing.T) {
+	updated, _ := NewModel(calculator.NewEngine()).Update(tea.WindowSizeMsg{Width: 80, Height: 40})
+	start := updated.(Model)
+
+	model := typeKeys(start, "1.2E3")
+	if model.GetInput() != "1.2e3" {
+		t.Errorf("Expected '1.2 EXP 3' to enter '1.2e3', got '%s'", model.GetInput())
+	}
+	model = typeKeys(model, "=")
+	if model.GetOutput() != "1200" {
+		t.Errorf("Expected 1.2e3 to evaluate to 1200, got '%s'", model.GetOutput())
+	}
+
+	// The grid's EXP action
+	updated, _ = handleButtonGridAction(typeKeys(start, "5"), &uiintegration.ButtonAction{Value: "exp"})
+	model = typeKeys(updated.(Model), "-2")
+	if model.GetInput() != "5e-2" {
+		t.Errorf("Expected '5 EXP -2' to enter '5e-2', got '%s'", model.GetInput())
+	}
+	model = typeKeys(model, "=")
+	if model.GetOutput() != "0.050000" {
+		t.Errorf("Expected 5e-2 to evaluate to 0.05, got '%s'", model.GetOutput())
+	}
+
+	// A bare EXP is rejected
+	model = typeKeys(start, "E")
+	if model.GetInput() != "" || model.GetError() == "" {
+		t.Errorf("Expected a bare EXP to be rejected, got '%s' with error '%s'", model.GetInput(), model.GetError())
+	}
+
+	// After a 0x, 0b or 0o prefix e is a digit, so EXP is refused
+	model = typeKeys(start.WithInput("0x1"), "E")
+	if model.GetInput() != "0x1" || model.GetError() == "" {
+		t.Errorf("Expected EXP after a hex literal to be rejected, got '%s' with error '%s'", model.GetInput(), model.GetError())
+	}
+	model = typeKeys(start.WithInput("0x1e3"), "E")
+	if model.GetInput() != "0x1e3" || model.GetError() == "" {
+		t.Errorf("Expected a hex literal ending in e3 not to count as an exponent, got '%s' with error '%s'", model.GetInput(), model.GetError())
+	}
+
+	// The exponent takes digits only, once
+	model = typeKeys(start, "2E1.E5")
+	if model.GetInput() != "2e15" {
+		t.Errorf("Expected the exponent to ignore '.' and a second EXP, got '%s'", model.GetInput())
+	}
+
+	// A minus after the exponent's digits is subtraction again
+	model = typeKeys(start, "2E1-5=")
+	if model.GetOutput() != "15" {
+		t.Errorf("Expected 2e1 - 5 to evaluate to 15, got '%s'", model.GetOutput())
+	}
+}
+
+func TestBorderlessRequested(t *testing.T) {
+	if BorderlessRequested(nil) {
+		t.Error("Expected borders without --borderless")
+	}
+	if !BorderlessRequested([]string{"--scientific", "--borderless"}) {
+		t.Error("Expected --borderless to drop the borders")
+	}
+}
@@ -0,0 +1,60 @@
+package ui
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// defaultMouseToggleKey switches mouse support on and off
+const defaultMouseToggleKey = "M"
+
+// mouseOffStatus is shown in the status bar while mouse support is off
+const mouseOffStatus = "Mouse off - press %s to enable"
+
+// MouseSwitch is a mouse handler that can be switched on and off. It is
+// satisfied by input.MouseHandler.
+type MouseSwitch interface {
+	SetEnabled(enabled bool)
+}
+
+// MouseEnabled returns whether mouse events are handled
+func (m Model) MouseEnabled() bool {
+	return !m.mouseDisabled
+}
+
+// SetMouseEnabled enables or disables mouse handling. The returned command
+// turns mouse reporting in the terminal on or off to match, so that text can
+// be selected normally while the mouse is disabled.
+func (m *Model) SetMouseEnabled(enabled bool) tea.Cmd {
+	m.mouseDisabled = !enabled
+	if m.mouseSwitch != nil {
+		m.mouseSwitch.SetEnabled(enabled)
+	}
+
+	if !enabled {
+		m.stopDigitRepeat()
+		return tea.DisableMouse
+	}
+	return tea.EnableMouseAllMotion
+}
+
+// ToggleMouse flips mouse handling on or off
+func (m *Model) ToggleMouse() tea.Cmd {
+	return m.SetMouseEnabled(m.mouseDisabled)
+}
+
+// SetMouseSwitch sets a mouse handler that is switched along with the model
+func (m *Model) SetMouseSwitch(mouseSwitch MouseSwitch) {
+	m.mouseSwitch = mouseSwitch
+	if mouseSwitch != nil {
+		mouseSwitch.SetEnabled(!m.mouseDisabled)
+	}
+}
+
+// GetMouseToggleKey returns the key that toggles mouse support
+func (m Model) GetMouseToggleKey() string {
+	return m.mouseToggleKey
+}
+
+// SetMouseToggleKey sets the key that toggles mouse support, as reported by
+// tea.KeyMsg.String(); an empty key disables the toggle
+func (m *Model) SetMouseToggleKey(key string) {
+	m.mouseToggleKey = key
+}
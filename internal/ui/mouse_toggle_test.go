@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"ccpm-demo/internal/calculator"
+)
+
+// fakeMouseSwitch records the enabled state it was last given
+type fakeMouseSwitch struct {
+	enabled bool
+}
+
+func (f *fakeMouseSwitch) SetEnabled(enabled bool) {
+	f.enabled = enabled
+}
+
+func TestMouseToggle(t *testing.T) {
+	updated, _ := NewModel(calculator.NewEngine()).Update(tea.WindowSizeMsg{Width: 80, Height: 40})
+	model := updated.(Model)
+	mouseSwitch := &fakeMouseSwitch{}
+	model.SetMouseSwitch(mouseSwitch)
+
+	if !model.MouseEnabled() || !mouseSwitch.enabled {
+		t.Fatal("Expected the mouse to be enabled by default")
+	}
+
+	// Disabling drops subsequent mouse events
+	model = typeKeys(model, "M")
+	if model.MouseEnabled() || mouseSwitch.enabled {
+		t.Error("Expected the toggle key to disable the mouse")
+	}
+	if !strings.Contains(model.View(), "Mouse off") {
+		t.Error("Expected the status bar to report the mouse is off")
+	}
+	model, _ = pressButton(t, model, "7")
+	if model.GetInput() != "" {
+		t.Errorf("Expected clicks to be ignored while disabled, got '%s'", model.GetInput())
+	}
+
+	// Enabling restores them
+	model = typeKeys(model, "M")
+	if !model.MouseEnabled() || !mouseSwitch.enabled {
+		t.Error("Expected the toggle key to enable the mouse again")
+	}
+	if strings.Contains(model.View(), "Mouse off") {
+		t.Error("Expected the status bar to clear once the mouse is back on")
+	}
+	model, _ = pressButton(t, model, "7")
+	if model.GetInput() != "7" {
+		t.Errorf("Expected clicks to be handled once enabled, got '%s'", model.GetInput())
+	}
+}
+
+func TestMouseToggleKeyConfigurable(t *testing.T) {
+	model := NewModel(calculator.NewEngine())
+	model.SetMouseToggleKey("ctrl+t")
+
+	model = typeKeys(model, "M")
+	if !model.MouseEnabled() {
+		t.Error("Expected the default key to do nothing once rebound")
+	}
+
+	updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyCtrlT})
+	model = updated.(Model)
+	if model.MouseEnabled() {
+		t.Error("Expected the configured key to disable the mouse")
+	}
+	if cmd == nil {
+		t.Error("Expected a command to turn off mouse reporting in the terminal")
+	}
+}
@@ -0,0 +1,44 @@
+package ui
+
+import (
+	"os"
+	"regexp"
+	"strings"
+)
+
+// ansiEscapePattern matches CSI and OSC terminal escape sequences
+var ansiEscapePattern = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]|\x1b\][^\a\x1b]*(\a|\x1b\\)`)
+
+// asciiBorderReplacer maps box-drawing characters to plain ASCII
+var asciiBorderReplacer = strings.NewReplacer(
+	"╭", "+", "╮", "+", "╰", "+", "╯", "+",
+	"┌", "+", "┐", "+", "└", "+", "┘", "+",
+	"┏", "+", "┓", "+", "┗", "+", "┛", "+",
+	"╔", "+", "╗", "+", "╚", "+", "╝", "+",
+	"├", "+", "┤", "+", "┬", "+", "┴", "+", "┼", "+",
+	"─", "-", "━", "-", "═", "-",
+	"│", "|", "┃", "|", "║", "|",
+)
+
+// NoColorRequested reports whether colored output has been disabled, either by
+// the NO_COLOR convention (https://no-color.org) or a --no-color argument
+func NoColorRequested(args []string) bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return true
+	}
+
+	for _, arg := range args {
+		if arg == "--no-color" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// plainRendering strips ANSI styling from a rendering and redraws borders in ASCII,
+// keeping the layout and labels intact
+func plainRendering(rendering string) string {
+	plain := ansiEscapePattern.ReplaceAllString(rendering, "")
+	return asciiBorderReplacer.Replace(plain)
+}
@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"ccpm-demo/internal/calculator"
+)
+
+func TestPlainRendering(t *testing.T) {
+	styled := "\x1b[1;38;5;214m╭───╮\x1b[0m\n\x1b[48;2;10;20;30m│ 7 │\x1b[0m\n╰───╯\x1b]52;c;Zm9v\a"
+
+	plain := plainRendering(styled)
+
+	expected := "+---+\n| 7 |\n+---+"
+	if plain != expected {
+		t.Errorf("Expected %q, got %q", expected, plain)
+	}
+}
+
+func TestNoColorRequested(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	if NoColorRequested(nil) {
+		t.Error("Expected color to be enabled without NO_COLOR or --no-color")
+	}
+	if !NoColorRequested([]string{"--no-color"}) {
+		t.Error("Expected --no-color to disable color")
+	}
+
+	t.Setenv("NO_COLOR", "1")
+	if !NoColorRequested(nil) {
+		t.Error("Expected NO_COLOR to disable color")
+	}
+	if !NewModel(calculator.NewEngine()).IsNoColor() {
+		t.Error("Expected new models to honor NO_COLOR")
+	}
+}
+
+func TestModelNoColorView(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	model := NewModel(calculator.NewEngine())
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 80, Height: 40})
+	m := updated.(Model)
+	m.SetInput("12 + 3")
+	m.SetNoColor(true)
+
+	view := m.View()
+
+	if strings.Contains(view, "\x1b") {
+		t.Errorf("Expected no ANSI escape sequences, got %q", view)
+	}
+	for _, box := range []string{"╭", "╮", "╰", "╯", "─", "│", "┌", "┐", "└", "┘"} {
+		if strings.Contains(view, box) {
+			t.Errorf("Expected ASCII borders, found %q", box)
+		}
+	}
+
+	// Structure is kept: title, input, button labels and ASCII borders
+	for _, want := range []string{"CCPM Calculator", "12 + 3", "CE", "÷", "+-", "|"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected no-color view to contain %q", want)
+		}
+	}
+}
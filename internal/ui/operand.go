@@ -0,0 +1,192 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"ccpm-demo/internal/calculator"
+)
+
+// operandStart returns where the operand at the end of input begins. The
+// operand is a number, with any unary minus, or a bracketed group together
+// with the name of the function it calls. ok is false when input does not end
+// in an operand.
+func operandStart(input string) (start int, ok bool) {
+	start = len(input)
+
+	if strings.HasSuffix(input, ")") {
+		depth := 0
+		for start > 0 {
+			start--
+			switch input[start] {
+			case ')':
+				depth++
+			case '(':
+				depth--
+			}
+			if depth == 0 {
+				break
+			}
+		}
+		if depth != 0 {
+			return 0, false
+		}
+		for start > 0 && isLetterByte(input[start-1]) {
+			start--
+		}
+		return start, true
+	}
+
+	for start > 0 && strings.ContainsRune("0123456789.", rune(input[start-1])) {
+		start--
+	}
+	if start == len(input) {
+		return 0, false
+	}
+
+	// Keep a unary minus with its number, as in "-5", "2 * -5" or "12+-3"
+	if sign := start - 1; sign >= 0 && input[sign] == '-' && (sign == 0 || strings.ContainsRune(" (+-*/^", rune(input[sign-1]))) {
+		start = sign
+	}
+	return start, true
+}
+
+// ApplyReciprocal replaces the current operand with its reciprocal, 1/(x).
+// With nothing typed the last result is used. A zero operand is rejected with
+// an error. It reports whether the input changed.
+func (m *Model) ApplyReciprocal() bool {
+	input := m.input
+	if strings.TrimSpace(input) == "" {
+		input = m.output
+	}
+
+	start, ok := operandStart(input)
+	if !ok {
+		return false
+	}
+
+	operand := input[start:]
+	value, err := m.engine.Evaluate(operand)
+	if err != nil {
+		m.setError(err)
+		return false
+	}
+	if value == 0 {
+		m.setError(calculator.ErrDivisionByZero)
+		return false
+	}
+
+	m.input = input[:start] + "1/(" + operand + ")"
+	m.cursorPosition = len(m.input)
+	m.calculatorState.displayValue = m.input
+	return true
+}
+
+// ApplyPower raises the current operand to exponent by appending "^exponent",
+// so "12 + 5" becomes "12 + 5^2". A negative operand is bracketed first, so
+// "-5" becomes "(-5)^2" rather than -(5^2). With nothing typed the last result
+// is used. It reports whether the input changed.
+func (m *Model) ApplyPower(exponent string) bool {
+	input := m.input
+	if strings.TrimSpace(input) == "" {
+		input = m.output
+	}
+
+	start, ok := operandStart(input)
+	if !ok {
+		return false
+	}
+	if strings.HasPrefix(input[start:], "-") {
+		input = input[:start] + "(" + input[start:] + ")"
+	}
+
+	m.input = input + "^" + exponent
+	m.cursorPosition = len(m.input)
+	m.calculatorState.displayValue = m.input
+	return true
+}
+
+// ApplyFactorial appends a factorial "!" to the current operand, so "2 + 5"
+// becomes "2 + 5!". With nothing typed the last result is used. It reports
+// whether the input changed.
+func (m *Model) ApplyFactorial() bool {
+	input := m.input
+	if strings.TrimSpace(input) == "" {
+		input = m.output
+	}
+
+	if _, ok := operandStart(input); !ok && !strings.HasSuffix(input, "!") {
+		return false
+	}
+
+	m.input = input + "!"
+	m.cursorPosition = len(m.input)
+	m.calculatorState.displayValue = m.input
+	return true
+}
+
+// ApplyExponent starts a scientific exponent on the number being entered by
+// appending "e", so "1.2" followed by 3 reads 1.2e3 = 1200. A decimal number
+// must come first, and may only have one exponent; EXP without one, or after
+// a 0x, 0b or 0o literal where e would be a digit, is rejected with an error.
+// It reports whether the input changed.
+func (m *Model) ApplyExponent() bool {
+	if inExponent(m.input) {
+		return false
+	}
+	if m.input == "" || !isDigitByte(m.input[len(m.input)-1]) {
+		m.setError(fmt.Errorf("%w: EXP needs a number before it", calculator.ErrInvalidNumber))
+		return false
+	}
+	if inPrefixedLiteral(m.input) {
+		m.setError(fmt.Errorf("%w: EXP cannot follow a 0x, 0b or 0o literal", calculator.ErrInvalidNumber))
+		return false
+	}
+
+	m.input += "e"
+	m.cursorPosition = len(m.input)
+	m.calculatorState.displayValue = m.input
+	return true
+}
+
+// appendExponentSign appends operator to an exponent just started with EXP if
+// it is a minus, making the exponent negative. It reports whether it did.
+func (m *Model) appendExponentSign(operator string) bool {
+	if operator != "-" || !strings.HasSuffix(m.input, "e") {
+		return false
+	}
+
+	m.input += "-"
+	m.cursorPosition = len(m.input)
+	m.calculatorState.displayValue = m.input
+	return true
+}
+
+// inExponent reports whether input ends in the exponent of a number, where
+// only digits may follow
+func inExponent(input string) bool {
+	i := len(input)
+	for i > 0 && isDigitByte(input[i-1]) {
+		i--
+	}
+	if i > 0 && input[i-1] == '-' {
+		i--
+	}
+	return i > 1 && input[i-1] == 'e' && strings.ContainsRune("0123456789.", rune(input[i-2])) && !inPrefixedLiteral(input[:i])
+}
+
+// inPrefixedLiteral reports whether input ends in a number written with a
+// base prefix, such as 0x1e, where letters are digits rather than exponents
+func inPrefixedLiteral(input string) bool {
+	i := len(input)
+	for i > 0 && (isDigitByte(input[i-1]) || isLetterByte(input[i-1])) {
+		i--
+	}
+	literal := strings.ToLower(input[i:])
+	return len(literal) >= 2 && literal[0] == '0' && strings.ContainsRune("xbo", rune(literal[1]))
+}
+
+// isLetterByte reports whether b is an ASCII letter
+func isLetterByte(b byte) bool {
+	return (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z')
+}
@@ -0,0 +1,29 @@
+package ui
+
+// defaultDisplayPlaceholder is shown in an empty display
+const defaultDisplayPlaceholder = "0"
+
+// GetDisplayPlaceholder returns the text shown in the display while it is empty
+func (m Model) GetDisplayPlaceholder() string {
+	return m.displayPlaceholder
+}
+
+// SetDisplayPlaceholder sets the text shown in the display while there is no
+// input and no result, "0" by default. An empty placeholder leaves the
+// display blank.
+func (m *Model) SetDisplayPlaceholder(placeholder string) {
+	m.displayPlaceholder = placeholder
+}
+
+// clearedDisplayValue is the display value clearing resets to
+const clearedDisplayValue = "0"
+
+// displayEmpty reports whether there is neither input nor a result to
+// display. Clearing keeps the last result but resets the display value.
+func (m Model) displayEmpty() bool {
+	if m.input != "" {
+		return false
+	}
+	value := m.calculatorState.displayValue
+	return m.output == "" || value == "" || (value == clearedDisplayValue && value != m.output)
+}
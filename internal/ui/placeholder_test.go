@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"ccpm-demo/internal/calculator"
+)
+
+func TestDisplayPlaceholder(t *testing.T) {
+	updated, _ := NewModel(calculator.NewEngine()).Update(tea.WindowSizeMsg{Width: 80, Height: 40})
+	model := updated.(Model)
+	displayed := func(m Model) string {
+		return strings.TrimSpace(m.renderDisplay(m.updateStyles()))
+	}
+
+	if model.GetDisplayPlaceholder() != "0" {
+		t.Errorf("Expected the default placeholder '0', got '%s'", model.GetDisplayPlaceholder())
+	}
+	if displayed(model) != "0" {
+		t.Errorf("Expected an empty display to show '0', got '%s'", displayed(model))
+	}
+
+	model.SetDisplayPlaceholder("—")
+	if displayed(model) != "—" {
+		t.Errorf("Expected an empty display to show the placeholder, got '%s'", displayed(model))
+	}
+
+	// Any input replaces the placeholder
+	model = typeKeys(model, "7")
+	if displayed(model) != "7" {
+		t.Errorf("Expected input to replace the placeholder, got '%s'", displayed(model))
+	}
+
+	// Deleting it all brings the placeholder back
+	model = sendKeys(model, tea.KeyMsg{Type: tea.KeyBackspace})
+	if displayed(model) != "—" {
+		t.Errorf("Expected the placeholder once the input is deleted, got '%s'", displayed(model))
+	}
+
+	// A result of zero is a result, not an empty display
+	model = typeKeys(model, "2-2=")
+	if displayed(model) != "0" {
+		t.Errorf("Expected a zero result to be shown, got '%s'", displayed(model))
+	}
+
+	// Clearing keeps the last result but empties the display
+	model = typeKeys(model, "3+4=")
+	if displayed(model) != "7" {
+		t.Errorf("Expected the result to be shown, got '%s'", displayed(model))
+	}
+	model = typeKeys(model, "c")
+	if displayed(model) != "—" {
+		t.Errorf("Expected the placeholder after clearing, got '%s'", displayed(model))
+	}
+
+	model.SetDisplayPlaceholder("")
+	if displayed(model) != "" {
+		t.Errorf("Expected an empty placeholder to leave the display blank, got '%s'", displayed(model))
+	}
+}
@@ -0,0 +1,42 @@
+package ui
+
+import "fmt"
+
+// WithInput returns a copy of the model with expr on the input line and the
+// cursor after it, as if it had been typed. The display, output and history
+// are left as they were, so it can follow WithResult to show a new entry
+// under an earlier result.
+func (m Model) WithInput(expr string) Model {
+	m.input = expr
+	m.cursorPosition = len(expr)
+	return m
+}
+
+// WithResult returns a copy of the model as if expression had just been
+// evaluated to result: the formatted result is shown in the display and
+// output and recorded in the history, and any error is cleared. The input
+// line is left alone. Unlike pressing "=", it plays no sound, copies nothing
+// and ignores the equals mode.
+func (m Model) WithResult(expression string, result float64) Model {
+	// The copy gets its own history so appending cannot reach the original's
+	m.history = append([]string(nil), m.history...)
+
+	m.output = m.formatValue(result)
+	m.addToHistory(fmt.Sprintf("%s = %s", expression, m.output))
+	m.calculatorState.displayValue = m.output
+	m.calculatorState.isWaitingForOperand = true
+	m.ClearError()
+	return m
+}
+
+// GetDisplayValue returns the value the calculator display holds, before
+// any placeholder is substituted for an empty display
+func (m Model) GetDisplayValue() string {
+	return m.calculatorState.displayValue
+}
+
+// IsWaitingForOperand returns whether a result is shown and the next number
+// starts a new entry
+func (m Model) IsWaitingForOperand() bool {
+	return m.calculatorState.isWaitingForOperand
+}
@@ -0,0 +1,65 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"ccpm-demo/internal/calculator"
+)
+
+func TestModelPresetState(t *testing.T) {
+	updated, _ := NewModel(calculator.NewEngine()).Update(tea.WindowSizeMsg{Width: 100, Height: 50})
+	base := updated.(Model)
+
+	model := base.WithResult("6 * 7", 42).WithInput("1 + 2")
+
+	if model.GetInput() != "1 + 2" || model.GetCursorPosition() != len("1 + 2") {
+		t.Errorf("Expected input '1 + 2' with the cursor at the end, got '%s' at %d",
+			model.GetInput(), model.GetCursorPosition())
+	}
+	if model.GetOutput() != "42" || model.GetDisplayValue() != "42" || model.DisplayText() != "42" {
+		t.Errorf("Expected output and display '42', got '%s', '%s' and '%s'",
+			model.GetOutput(), model.GetDisplayValue(), model.DisplayText())
+	}
+	if history := model.GetHistory(); len(history) != 1 || history[0] != "6 * 7 = 42" {
+		t.Errorf("Expected history ['6 * 7 = 42'], got %v", history)
+	}
+	if !model.IsWaitingForOperand() {
+		t.Error("Expected the model to wait for a new operand after a result")
+	}
+
+	view := model.View()
+	for _, want := range []string{"1 + 2", "42", "6 * 7 = 42"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected the view to show '%s', got:\n%s", want, view)
+		}
+	}
+
+	// The preset state carries on like typed input
+	model = typeKeys(model, "=")
+	if model.GetOutput() != "3" || len(model.GetHistory()) != 2 {
+		t.Errorf("Expected '1 + 2' to evaluate to '3' as a second history entry, got '%s' and %v",
+			model.GetOutput(), model.GetHistory())
+	}
+
+	// The model the presets started from is unchanged
+	if base.GetInput() != "" || base.GetOutput() != "" || len(base.GetHistory()) != 0 {
+		t.Errorf("Expected the original model to be untouched, got input '%s', output '%s', history %v",
+			base.GetInput(), base.GetOutput(), base.GetHistory())
+	}
+}
+
+func TestModelWithResultClearsError(t *testing.T) {
+	model := NewModel(calculator.NewEngine())
+	model.SetError("division by zero")
+
+	model = model.WithResult("1 / 4", 0.25)
+	if model.GetError() != "" {
+		t.Errorf("Expected the result to clear the error, got '%s'", model.GetError())
+	}
+	if want := model.formatValue(0.25); model.GetOutput() != want {
+		t.Errorf("Expected output '%s' in the display precision, got '%s'", want, model.GetOutput())
+	}
+}
@@ -0,0 +1,121 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// defaultRecentResultsLimit is the number of results listed in the palette
+const defaultRecentResultsLimit = 5
+
+// resultsPalette holds the state of the recent results palette
+type resultsPalette struct {
+	active   bool
+	selected int
+}
+
+// RecentResults returns up to the palette limit of the latest results, newest first
+func (m Model) RecentResults() []string {
+	var results []string
+	for i := len(m.history) - 1; i >= 0 && len(results) < m.recentResultsLimit; i-- {
+		if _, result, found := strings.Cut(m.history[i], " = "); found {
+			results = append(results, result)
+		}
+	}
+	return results
+}
+
+// SetRecentResultsLimit sets how many results the palette lists
+func (m *Model) SetRecentResultsLimit(limit int) {
+	if limit > 0 {
+		m.recentResultsLimit = limit
+	}
+}
+
+// OpenResultsPalette shows the recent results palette
+func (m *Model) OpenResultsPalette() {
+	m.palette = resultsPalette{active: true}
+}
+
+// CloseResultsPalette hides the recent results palette
+func (m *Model) CloseResultsPalette() {
+	m.palette = resultsPalette{}
+}
+
+// IsResultsPaletteActive returns whether the recent results palette is open
+func (m Model) IsResultsPaletteActive() bool {
+	return m.palette.active
+}
+
+// InsertRecentResult inserts the result at the given palette index at the cursor
+func (m *Model) InsertRecentResult(index int) bool {
+	results := m.RecentResults()
+	if index < 0 || index >= len(results) {
+		return false
+	}
+
+	m.insertAtCursor(results[index])
+	return true
+}
+
+// InsertLastAnswer inserts the latest result at the cursor. It reports
+// whether there was a result to insert.
+func (m *Model) InsertLastAnswer() bool {
+	return m.InsertRecentResult(0)
+}
+
+// handleResultsPaletteKey processes keys while the recent results palette is open
+func handleResultsPaletteKey(m Model, msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc, tea.KeyCtrlC:
+		m.CloseResultsPalette()
+
+	case tea.KeyEnter:
+		m.InsertRecentResult(m.palette.selected)
+		m.CloseResultsPalette()
+
+	case tea.KeyUp:
+		if m.palette.selected > 0 {
+			m.palette.selected--
+		}
+
+	case tea.KeyDown:
+		if m.palette.selected < len(m.RecentResults())-1 {
+			m.palette.selected++
+		}
+
+	case tea.KeyRunes:
+		// Number keys pick an entry directly
+		char := string(msg.Runes)
+		if len(char) == 1 && char >= "1" && char <= "9" {
+			m.InsertRecentResult(int(char[0] - '1'))
+			m.CloseResultsPalette()
+		}
+	}
+
+	return m, nil
+}
+
+// renderResultsPalette renders the recent results palette in place of the history list
+func (m Model) renderResultsPalette() string {
+	palette := strings.Builder{}
+	palette.WriteString("Recent results:\n")
+
+	results := m.RecentResults()
+	if len(results) == 0 {
+		palette.WriteString("  (no results yet)\n")
+		return palette.String()
+	}
+
+	for i, result := range results {
+		prefix := "  "
+		if i == m.palette.selected {
+			prefix = "→ "
+		}
+		palette.WriteString(fmt.Sprintf("%s%d. %s\n", prefix, i+1, result))
+	}
+
+	return palette.String()
+}
@@ -0,0 +1,106 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"ccpm-demo/internal/calculator"
+)
+
+func newPaletteTestModel() Model {
+	model := NewModel(calculator.NewEngine())
+	model.addToHistory("1 + 1 = 2")
+	model.addToHistory("6 * 7 = 42")
+	model.addToHistory("10 + 5 = 15")
+	return model
+}
+
+func TestRecentResultsPaletteOrder(t *testing.T) {
+	m := sendKeys(newPaletteTestModel(), runeKey('r'))
+
+	if !m.IsResultsPaletteActive() {
+		t.Fatal("Expected 'r' to open the results palette")
+	}
+
+	results := m.RecentResults()
+	expected := []string{"15", "42", "2"}
+	if strings.Join(results, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected results %v, got %v", expected, results)
+	}
+
+	view := m.View()
+	if !strings.Contains(view, "1. 15") || !strings.Contains(view, "2. 42") {
+		t.Error("Expected the palette to list the newest results first")
+	}
+}
+
+func TestRecentResultsPaletteLimit(t *testing.T) {
+	model := newPaletteTestModel()
+	model.SetRecentResultsLimit(2)
+
+	if results := model.RecentResults(); len(results) != 2 || results[1] != "42" {
+		t.Errorf("Expected the two newest results, got %v", results)
+	}
+}
+
+func TestRecentResultsPaletteInsertsAtCursor(t *testing.T) {
+	t.Run("selecting by number", func(t *testing.T) {
+		model := newPaletteTestModel()
+		model.SetInput("3 + ")
+
+		m := sendKeys(model, runeKey('r'), runeKey('2'))
+
+		if m.IsResultsPaletteActive() {
+			t.Error("Expected selecting a result to close the palette")
+		}
+		if m.GetInput() != "3 + 42" {
+			t.Errorf("Expected input '3 + 42', got '%s'", m.GetInput())
+		}
+	})
+
+	t.Run("selecting with arrows in the middle of the input", func(t *testing.T) {
+		model := newPaletteTestModel()
+		model.SetInput("( * 2)")
+		model.SetCursorPosition(1)
+
+		m := sendKeys(model, runeKey('r'), tea.KeyMsg{Type: tea.KeyDown}, tea.KeyMsg{Type: tea.KeyEnter})
+
+		if m.GetInput() != "(42 * 2)" {
+			t.Errorf("Expected input '(42 * 2)', got '%s'", m.GetInput())
+		}
+		if m.GetCursorPosition() != 3 {
+			t.Errorf("Expected cursor after the inserted value at 3, got %d", m.GetCursorPosition())
+		}
+	})
+}
+
+func TestInsertLastAnswer(t *testing.T) {
+	updated, _ := NewModel(calculator.NewEngine()).Update(tea.WindowSizeMsg{Width: 80, Height: 40})
+	start := updated.(Model)
+
+	if typeKeys(start, "A").GetInput() != "" {
+		t.Error("Expected ANS to insert nothing before the first result")
+	}
+
+	model := typeKeys(start, "2+3=")
+	model, _ = pressButton(t, model, "ans")
+	if model.GetInput() != "5" {
+		t.Fatalf("Expected ANS to insert '5', got '%s'", model.GetInput())
+	}
+
+	model = typeKeys(model, "*2")
+	if model.GetInput() != "5 * 2" {
+		t.Errorf("Expected the answer to compose as '5 * 2', got '%s'", model.GetInput())
+	}
+	model = typeKeys(model, "=")
+	if model.GetOutput() != "10" {
+		t.Errorf("Expected 5 * 2 to evaluate to 10, got '%s'", model.GetOutput())
+	}
+
+	// The key inserts the newest result
+	if got := typeKeys(model, "A").GetInput(); got != "10" {
+		t.Errorf("Expected the A key to insert '10', got '%s'", got)
+	}
+}
@@ -0,0 +1,54 @@
+package ui_test
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"ccpm-demo/internal/calculator"
+	"ccpm-demo/internal/ui"
+	"ccpm-demo/internal/ui/input"
+)
+
+func TestModelRendersRPNStack(t *testing.T) {
+	model := ui.NewModel(calculator.NewEngine())
+	system := input.NewInputSystem()
+	model.SetRPNStack(system)
+
+	// Outside RPN mode no stack is shown
+	model, _ = system.ProcessMessage(model, input.NumberInputMsg{Value: "3"})
+	model, _ = system.ProcessMessage(model, input.EqualsInputMsg{})
+	if strings.Contains(model.View(), "1: ") {
+		t.Error("Expected no stack view outside RPN mode")
+	}
+
+	system.SetRPNMode(true)
+	for _, msg := range []tea.Msg{
+		input.NumberInputMsg{Value: "3"},
+		input.EqualsInputMsg{},
+		input.NumberInputMsg{Value: "4"},
+		input.EqualsInputMsg{},
+	} {
+		model, _ = system.ProcessMessage(model, msg)
+	}
+
+	view := model.View()
+	deeper, top := strings.Index(view, "2: 3"), strings.Index(view, "1: 4")
+	if deeper < 0 || top < 0 {
+		t.Fatalf("Expected the stack view to show 3 and 4, got:\n%s", view)
+	}
+	if deeper > top {
+		t.Error("Expected 3 to be drawn above 4, the top of the stack")
+	}
+
+	// A completed operation replaces its operands with the result
+	model, _ = system.ProcessMessage(model, input.OperatorInputMsg{Operator: "+"})
+	view = model.View()
+	if strings.Contains(view, "2: ") || strings.Contains(view, "1: 4") {
+		t.Errorf("Expected the operands to clear from the stack view, got:\n%s", view)
+	}
+	if !strings.Contains(view, "1: 7") {
+		t.Errorf("Expected the stack view to show the result 7, got:\n%s", view)
+	}
+}
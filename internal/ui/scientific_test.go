@@ -0,0 +1,64 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"ccpm-demo/internal/calculator"
+	uiintegration "ccpm-demo/internal/ui/integration"
+)
+
+func TestScientificModeComma(t *testing.T) {
+	updated, _ := NewModel(calculator.NewEngine()).Update(tea.WindowSizeMsg{Width: 80, Height: 40})
+	model := updated.(Model)
+
+	// Without the scientific row the comma key types nothing
+	model = typeKeys(model, "27,")
+	if model.input != "27" {
+		t.Errorf("Expected ',' to be ignored outside scientific mode, got '%s'", model.input)
+	}
+
+	model = sendKeys(model, runeKey('c'))
+	model.SetScientificMode(true)
+	if !model.IsScientificMode() {
+		t.Fatal("Expected scientific mode to be enabled")
+	}
+
+	updated, _ = handleButtonGridAction(model, &uiintegration.ButtonAction{Value: "root"})
+	model = typeKeys(updated.(Model), "27,3)")
+	if model.input != "root(27,3)" {
+		t.Errorf("Expected input 'root(27,3)', got '%s'", model.input)
+	}
+
+	model = typeKeys(model, "=")
+	if model.output != "3" {
+		t.Errorf("Expected root(27,3) to evaluate to '3', got '%s' (error '%s')", model.output, model.error)
+	}
+}
+
+func TestScientificModeExponentRow(t *testing.T) {
+	updated, _ := NewModel(calculator.NewEngine()).Update(tea.WindowSizeMsg{Width: 80, Height: 40})
+	model := updated.(Model)
+	model.SetScientificMode(true)
+
+	// EXP is a button of its own in scientific mode
+	model, _ = pressButton(t, typeKeys(model, "1.2"), "exp")
+	model = typeKeys(releaseMouse(model), "3=")
+	if model.output != "1200" {
+		t.Errorf("Expected 1.2 EXP 3 to evaluate to '1200', got '%s' (error '%s')", model.output, model.error)
+	}
+
+	model, _ = pressButton(t, typeKeys(model, "4"), "factorial")
+	model = typeKeys(releaseMouse(model), "=")
+	if model.output != "24" {
+		t.Errorf("Expected 4! to evaluate to '24', got '%s' (error '%s')", model.output, model.error)
+	}
+
+	// The power key leaves the exponent to be typed
+	model, _ = pressButton(t, typeKeys(model, "2"), "power")
+	model = typeKeys(releaseMouse(model), "10")
+	if model.input != "2^10" {
+		t.Errorf("Expected input '2^10', got '%s'", model.input)
+	}
+}
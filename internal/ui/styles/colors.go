@@ -1,6 +1,11 @@
 package styles
 
 import (
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -21,6 +26,10 @@ type ColorPalette struct {
 	// State-specific colors
 	FocusColors     ButtonStateColors
 	DisabledColors  ButtonStateColors
+
+	// 24-bit #RRGGBB colors used in place of the 256-color codes above on
+	// truecolor terminals. Each code is the nearest one to its hex color.
+	TrueColors      map[lipgloss.Color]lipgloss.Color
 }
 
 // ButtonColorSet defines colors for a button type across different states
@@ -29,6 +38,7 @@ type ButtonColorSet struct {
 	Focused  ButtonStateColors
 	Pressed  ButtonStateColors
 	Disabled ButtonStateColors
+	Hover    ButtonStateColors
 }
 
 // ButtonStateColors defines foreground and background colors for a button state
@@ -63,6 +73,11 @@ func NewColorPalette() *ColorPalette {
 				Background: lipgloss.Color("240"),  // dark gray
 				Border:     lipgloss.Color("244"),  // light gray
 			},
+			Hover: ButtonStateColors{
+				Foreground: lipgloss.Color("15"),   // white
+				Background: lipgloss.Color("244"),  // light gray
+				Border:     lipgloss.Color("39"),   // sky blue highlight
+			},
 		},
 
 		// Operator buttons - classic orange/amber scheme
@@ -87,6 +102,11 @@ func NewColorPalette() *ColorPalette {
 				Background: lipgloss.Color("208"),  // orange (dimmed)
 				Border:     lipgloss.Color("202"),  // bright orange
 			},
+			Hover: ButtonStateColors{
+				Foreground: lipgloss.Color("15"),   // white
+				Background: lipgloss.Color("215"),  // peach
+				Border:     lipgloss.Color("39"),   // sky blue highlight
+			},
 		},
 
 		// Special buttons - classic red scheme
@@ -111,6 +131,11 @@ func NewColorPalette() *ColorPalette {
 				Background: lipgloss.Color("196"),  // red (dimmed)
 				Border:     lipgloss.Color("160"),  // dark red
 			},
+			Hover: ButtonStateColors{
+				Foreground: lipgloss.Color("15"),   // white
+				Background: lipgloss.Color("204"),  // rose
+				Border:     lipgloss.Color("39"),   // sky blue highlight
+			},
 		},
 
 		// General UI colors
@@ -132,9 +157,69 @@ func NewColorPalette() *ColorPalette {
 			Background: lipgloss.Color("240"),  // dark gray
 			Border:     lipgloss.Color("244"),  // light gray
 		},
+
+		TrueColors: retroTrueColors(),
+	}
+}
+
+// retroTrueColors returns the retro Casio palette's colors on truecolor
+// terminals: warmer grays like the plastic case, and richer accents. Each
+// palette gets its own map, so changing one leaves the others alone.
+func retroTrueColors() map[lipgloss.Color]lipgloss.Color {
+	return map[lipgloss.Color]lipgloss.Color{
+		"235": "#292723", // dark background
+		"238": "#474541", // shadow
+		"240": "#5b5955", // dark gray
+		"244": "#83817d", // light gray
+		"246": "#979591", // lighter gray
+		"248": "#aba9a5", // lightest gray
+		"39":  "#00b3ff", // sky blue
+		"62":  "#5a5ae1", // blue
+		"94":  "#8e6100", // amber
+		"160": "#e80000", // dark red
+		"202": "#ff5c00", // bright orange
+		"203": "#ff5959", // light red
+		"204": "#ff5784", // rose
+		"208": "#ff8800", // orange
+		"210": "#ff8282", // pink
+		"214": "#ffb300", // light orange
+		"215": "#ffaf55", // peach
+		"220": "#ffde00", // light amber
 	}
 }
 
+// WithTrueColor returns a copy of the palette with its TrueColors in place of
+// the 256-color codes, for truecolor terminals
+func (cp *ColorPalette) WithTrueColor() *ColorPalette {
+	return cp.mapColors(func(color lipgloss.Color) lipgloss.Color {
+		if hex, ok := cp.TrueColors[color]; ok {
+			return hex
+		}
+		return color
+	})
+}
+
+// mapColors returns a copy of the palette with every color passed through f
+func (cp *ColorPalette) mapColors(f func(lipgloss.Color) lipgloss.Color) *ColorPalette {
+	mapped := *cp
+	state := func(colors *ButtonStateColors) {
+		colors.Foreground = f(colors.Foreground)
+		colors.Background = f(colors.Background)
+		colors.Border = f(colors.Border)
+	}
+	for _, set := range []*ButtonColorSet{&mapped.NumberColors, &mapped.OperatorColors, &mapped.SpecialColors} {
+		for _, colors := range []*ButtonStateColors{&set.Normal, &set.Focused, &set.Pressed, &set.Disabled, &set.Hover} {
+			state(colors)
+		}
+	}
+	state(&mapped.FocusColors)
+	state(&mapped.DisabledColors)
+	for _, color := range []*lipgloss.Color{&mapped.Background, &mapped.Foreground, &mapped.Border, &mapped.Shadow, &mapped.Highlight} {
+		*color = f(*color)
+	}
+	return &mapped
+}
+
 // GetNumberColors returns the color set for number buttons
 func (cp *ColorPalette) GetNumberColors() ButtonColorSet {
 	return cp.NumberColors
@@ -212,6 +297,8 @@ func (cp *ColorPalette) GetStateColors(buttonType, state string) ButtonStateColo
 		return colorSet.Pressed
 	case "disabled", "Disabled":
 		return colorSet.Disabled
+	case "hover", "Hover":
+		return colorSet.Hover
 	default:
 		return colorSet.Normal // default to normal state
 	}
@@ -253,7 +340,7 @@ func (cp *ColorPalette) Validate() bool {
 	// Check button colors
 	buttonSets := []ButtonColorSet{cp.NumberColors, cp.OperatorColors, cp.SpecialColors}
 	for _, set := range buttonSets {
-		states := []ButtonStateColors{set.Normal, set.Focused, set.Pressed, set.Disabled}
+		states := []ButtonStateColors{set.Normal, set.Focused, set.Pressed, set.Disabled, set.Hover}
 		for _, state := range states {
 			if !cp.isValidColor(state.Foreground) || !cp.isValidColor(state.Background) || !cp.isValidColor(state.Border) {
 				return false
@@ -267,4 +354,88 @@ func (cp *ColorPalette) Validate() bool {
 // isValidColor checks if a color is valid
 func (cp *ColorPalette) isValidColor(color lipgloss.Color) bool {
 	return color != "" && string(color) != "0"
-}
\ No newline at end of file
+}
+// ansiBaseColors are the RGB values of the 16 basic ANSI colors, as xterm shows them
+var ansiBaseColors = [16][3]uint8{
+	{0, 0, 0}, {128, 0, 0}, {0, 128, 0}, {128, 128, 0},
+	{0, 0, 128}, {128, 0, 128}, {0, 128, 128}, {192, 192, 192},
+	{128, 128, 128}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{0, 0, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// ansiCubeLevels are the channel values of the 6x6x6 ANSI 256 color cube
+var ansiCubeLevels = [6]uint8{0, 95, 135, 175, 215, 255}
+
+// colorRGB returns the RGB value of an ANSI 256 color number or #rrggbb
+// color, as xterm shows it. It reports false for anything else.
+func colorRGB(color lipgloss.Color) ([3]uint8, bool) {
+	value := string(color)
+	if hexColor.MatchString(value) {
+		rgb, _ := strconv.ParseUint(value[1:], 16, 32)
+		return [3]uint8{uint8(rgb >> 16), uint8(rgb >> 8), uint8(rgb)}, true
+	}
+
+	number, err := strconv.Atoi(value)
+	switch {
+	case err != nil || number < 0 || number > 255:
+		return [3]uint8{}, false
+	case number < 16:
+		return ansiBaseColors[number], true
+	case number < 232:
+		cube := number - 16
+		return [3]uint8{ansiCubeLevels[cube/36], ansiCubeLevels[cube/6%6], ansiCubeLevels[cube%6]}, true
+	default:
+		gray := uint8(8 + 10*(number-232))
+		return [3]uint8{gray, gray, gray}, true
+	}
+}
+
+// TrueColorSupported reports whether the terminal advertises 24-bit color
+// with COLORTERM=truecolor or COLORTERM=24bit
+func TrueColorSupported() bool {
+	colorTerm := strings.ToLower(os.Getenv("COLORTERM"))
+	return colorTerm == "truecolor" || colorTerm == "24bit"
+}
+
+// NearestANSI256 returns the ANSI 256 color code nearest to a #rrggbb color,
+// for terminals without truecolor. The 16 basic colors are left out, since
+// terminals often redefine them. Other colors are returned unchanged.
+func NearestANSI256(color lipgloss.Color) lipgloss.Color {
+	if !hexColor.MatchString(string(color)) {
+		return color
+	}
+
+	rgb, _ := colorRGB(color)
+	nearest, nearestDistance := 16, math.MaxInt
+	for number := 16; number < 256; number++ {
+		candidate, _ := colorRGB(lipgloss.Color(strconv.Itoa(number)))
+		distance := 0
+		for i := range rgb {
+			d := int(rgb[i]) - int(candidate[i])
+			distance += d * d
+		}
+		if distance < nearestDistance {
+			nearest, nearestDistance = number, distance
+		}
+	}
+	return lipgloss.Color(strconv.Itoa(nearest))
+}
+
+// Luminance returns the WCAG relative luminance of an ANSI 256 color number
+// or #rrggbb color, from 0 for black to 1 for white. Colors that differ in
+// luminance stay distinct in grayscale. Other colors give 0.
+func Luminance(color lipgloss.Color) float64 {
+	rgb, ok := colorRGB(color)
+	if !ok {
+		return 0
+	}
+
+	linear := func(channel uint8) float64 {
+		c := float64(channel) / 255
+		if c <= 0.03928 {
+			return c / 12.92
+		}
+		return math.Pow((c+0.055)/1.055, 2.4)
+	}
+	return 0.2126*linear(rgb[0]) + 0.7152*linear(rgb[1]) + 0.0722*linear(rgb[2])
+}
@@ -0,0 +1,435 @@
+package styles
+
+import (
+	"github.com/charmbracelet/lipgloss"
+)
+
+// NewModernPalette creates a clean dark color palette with blue accents
+func NewModernPalette() *ColorPalette {
+	return &ColorPalette{
+		// Number buttons - charcoal keys with light text
+		NumberColors: ButtonColorSet{
+			Normal: ButtonStateColors{
+				Foreground: lipgloss.Color("252"), // off-white
+				Background: lipgloss.Color("237"), // charcoal
+				Border:     lipgloss.Color("239"), // slate
+			},
+			Focused: ButtonStateColors{
+				Foreground: lipgloss.Color("15"),  // white
+				Background: lipgloss.Color("239"), // slate
+				Border:     lipgloss.Color("39"),  // sky blue
+			},
+			Pressed: ButtonStateColors{
+				Foreground: lipgloss.Color("15"),  // white
+				Background: lipgloss.Color("241"), // light slate
+				Border:     lipgloss.Color("45"),  // cyan
+			},
+			Disabled: ButtonStateColors{
+				Foreground: lipgloss.Color("242"), // gray
+				Background: lipgloss.Color("236"), // dark charcoal
+				Border:     lipgloss.Color("237"), // charcoal
+			},
+			Hover: ButtonStateColors{
+				Foreground: lipgloss.Color("15"),  // white
+				Background: lipgloss.Color("238"), // dark slate
+				Border:     lipgloss.Color("39"),  // sky blue
+			},
+		},
+
+		// Operator buttons - blue accent
+		OperatorColors: ButtonColorSet{
+			Normal: ButtonStateColors{
+				Foreground: lipgloss.Color("15"), // white
+				Background: lipgloss.Color("25"), // deep blue
+				Border:     lipgloss.Color("31"), // steel blue
+			},
+			Focused: ButtonStateColors{
+				Foreground: lipgloss.Color("15"), // white
+				Background: lipgloss.Color("33"), // blue
+				Border:     lipgloss.Color("39"), // sky blue
+			},
+			Pressed: ButtonStateColors{
+				Foreground: lipgloss.Color("15"), // white
+				Background: lipgloss.Color("39"), // sky blue
+				Border:     lipgloss.Color("45"), // cyan
+			},
+			Disabled: ButtonStateColors{
+				Foreground: lipgloss.Color("242"), // gray
+				Background: lipgloss.Color("24"),  // navy
+				Border:     lipgloss.Color("24"),  // navy
+			},
+			Hover: ButtonStateColors{
+				Foreground: lipgloss.Color("15"), // white
+				Background: lipgloss.Color("32"), // bright blue
+				Border:     lipgloss.Color("39"), // sky blue
+			},
+		},
+
+		// Special buttons - coral accent
+		SpecialColors: ButtonColorSet{
+			Normal: ButtonStateColors{
+				Foreground: lipgloss.Color("15"),  // white
+				Background: lipgloss.Color("167"), // coral
+				Border:     lipgloss.Color("131"), // brick
+			},
+			Focused: ButtonStateColors{
+				Foreground: lipgloss.Color("15"),  // white
+				Background: lipgloss.Color("203"), // light coral
+				Border:     lipgloss.Color("39"),  // sky blue
+			},
+			Pressed: ButtonStateColors{
+				Foreground: lipgloss.Color("15"),  // white
+				Background: lipgloss.Color("210"), // salmon
+				Border:     lipgloss.Color("45"),  // cyan
+			},
+			Disabled: ButtonStateColors{
+				Foreground: lipgloss.Color("242"), // gray
+				Background: lipgloss.Color("95"),  // muted rose
+				Border:     lipgloss.Color("95"),  // muted rose
+			},
+			Hover: ButtonStateColors{
+				Foreground: lipgloss.Color("15"),  // white
+				Background: lipgloss.Color("174"), // dusty pink
+				Border:     lipgloss.Color("39"),  // sky blue
+			},
+		},
+
+		// General UI colors
+		Background: lipgloss.Color("234"), // near black
+		Foreground: lipgloss.Color("252"), // off-white text
+		Border:     lipgloss.Color("238"), // subtle borders
+		Shadow:     lipgloss.Color("232"), // shadow color
+		Highlight:  lipgloss.Color("39"),  // sky blue highlight
+
+		// State-specific fallback colors
+		FocusColors: ButtonStateColors{
+			Foreground: lipgloss.Color("15"), // white
+			Background: lipgloss.Color("39"), // sky blue
+			Border:     lipgloss.Color("45"), // cyan
+		},
+
+		DisabledColors: ButtonStateColors{
+			Foreground: lipgloss.Color("242"), // gray
+			Background: lipgloss.Color("236"), // dark charcoal
+			Border:     lipgloss.Color("237"), // charcoal
+		},
+	}
+}
+
+// NewMinimalPalette creates a flat, nearly monochrome palette on the terminal's
+// black. Resting buttons have no background of their own; only focus and
+// presses fill them in.
+func NewMinimalPalette() *ColorPalette {
+	return &ColorPalette{
+		// Number buttons - plain light text
+		NumberColors: ButtonColorSet{
+			Normal: ButtonStateColors{
+				Foreground: lipgloss.Color("250"), // light gray
+			},
+			Focused: ButtonStateColors{
+				Foreground: lipgloss.Color("15"),  // white
+				Background: lipgloss.Color("236"), // dark gray
+			},
+			Pressed: ButtonStateColors{
+				Foreground: lipgloss.Color("232"), // black
+				Background: lipgloss.Color("250"), // light gray
+			},
+			Disabled: ButtonStateColors{
+				Foreground: lipgloss.Color("238"), // dim gray
+			},
+			Hover: ButtonStateColors{
+				Foreground: lipgloss.Color("15"),  // white
+				Background: lipgloss.Color("234"), // near black
+			},
+		},
+
+		// Operator buttons - pale blue text
+		OperatorColors: ButtonColorSet{
+			Normal: ButtonStateColors{
+				Foreground: lipgloss.Color("117"), // pale blue
+			},
+			Focused: ButtonStateColors{
+				Foreground: lipgloss.Color("117"), // pale blue
+				Background: lipgloss.Color("236"), // dark gray
+			},
+			Pressed: ButtonStateColors{
+				Foreground: lipgloss.Color("232"), // black
+				Background: lipgloss.Color("117"), // pale blue
+			},
+			Disabled: ButtonStateColors{
+				Foreground: lipgloss.Color("238"), // dim gray
+			},
+			Hover: ButtonStateColors{
+				Foreground: lipgloss.Color("117"), // pale blue
+				Background: lipgloss.Color("234"), // near black
+			},
+		},
+
+		// Special buttons - pale red text
+		SpecialColors: ButtonColorSet{
+			Normal: ButtonStateColors{
+				Foreground: lipgloss.Color("210"), // pale red
+			},
+			Focused: ButtonStateColors{
+				Foreground: lipgloss.Color("210"), // pale red
+				Background: lipgloss.Color("236"), // dark gray
+			},
+			Pressed: ButtonStateColors{
+				Foreground: lipgloss.Color("232"), // black
+				Background: lipgloss.Color("210"), // pale red
+			},
+			Disabled: ButtonStateColors{
+				Foreground: lipgloss.Color("238"), // dim gray
+			},
+			Hover: ButtonStateColors{
+				Foreground: lipgloss.Color("210"), // pale red
+				Background: lipgloss.Color("234"), // near black
+			},
+		},
+
+		// General UI colors; minimal sits on the terminal's plain black
+		Background: lipgloss.Color("0"),   // black
+		Foreground: lipgloss.Color("250"), // light gray text
+		Border:     lipgloss.Color("238"), // dim gray borders
+		Shadow:     lipgloss.Color("232"), // shadow color
+		Highlight:  lipgloss.Color("15"),  // white highlight
+
+		// State-specific fallback colors
+		FocusColors: ButtonStateColors{
+			Foreground: lipgloss.Color("15"),  // white
+			Background: lipgloss.Color("236"), // dark gray
+		},
+
+		DisabledColors: ButtonStateColors{
+			Foreground: lipgloss.Color("238"), // dim gray
+		},
+	}
+}
+
+// NewClassicPalette creates a palette after old desktop calculators: gray keys
+// with navy digits and red operators
+func NewClassicPalette() *ColorPalette {
+	return &ColorPalette{
+		// Number buttons - navy digits on gray keys
+		NumberColors: ButtonColorSet{
+			Normal: ButtonStateColors{
+				Foreground: lipgloss.Color("18"),  // navy
+				Background: lipgloss.Color("252"), // light gray
+				Border:     lipgloss.Color("244"), // mid gray
+			},
+			Focused: ButtonStateColors{
+				Foreground: lipgloss.Color("18"),  // navy
+				Background: lipgloss.Color("255"), // white
+				Border:     lipgloss.Color("16"),  // black
+			},
+			Pressed: ButtonStateColors{
+				Foreground: lipgloss.Color("18"),  // navy
+				Background: lipgloss.Color("246"), // gray
+				Border:     lipgloss.Color("240"), // dark gray
+			},
+			Disabled: ButtonStateColors{
+				Foreground: lipgloss.Color("246"), // gray
+				Background: lipgloss.Color("252"), // light gray
+				Border:     lipgloss.Color("248"), // silver
+			},
+			Hover: ButtonStateColors{
+				Foreground: lipgloss.Color("18"),  // navy
+				Background: lipgloss.Color("254"), // near white
+				Border:     lipgloss.Color("244"), // mid gray
+			},
+		},
+
+		// Operator buttons - dark red symbols on gray keys
+		OperatorColors: ButtonColorSet{
+			Normal: ButtonStateColors{
+				Foreground: lipgloss.Color("124"), // dark red
+				Background: lipgloss.Color("250"), // gray
+				Border:     lipgloss.Color("244"), // mid gray
+			},
+			Focused: ButtonStateColors{
+				Foreground: lipgloss.Color("124"), // dark red
+				Background: lipgloss.Color("255"), // white
+				Border:     lipgloss.Color("16"),  // black
+			},
+			Pressed: ButtonStateColors{
+				Foreground: lipgloss.Color("124"), // dark red
+				Background: lipgloss.Color("244"), // mid gray
+				Border:     lipgloss.Color("240"), // dark gray
+			},
+			Disabled: ButtonStateColors{
+				Foreground: lipgloss.Color("246"), // gray
+				Background: lipgloss.Color("250"), // gray
+				Border:     lipgloss.Color("248"), // silver
+			},
+			Hover: ButtonStateColors{
+				Foreground: lipgloss.Color("124"), // dark red
+				Background: lipgloss.Color("253"), // near white
+				Border:     lipgloss.Color("244"), // mid gray
+			},
+		},
+
+		// Special buttons - bright red labels on darker keys
+		SpecialColors: ButtonColorSet{
+			Normal: ButtonStateColors{
+				Foreground: lipgloss.Color("160"), // red
+				Background: lipgloss.Color("248"), // silver
+				Border:     lipgloss.Color("242"), // dark gray
+			},
+			Focused: ButtonStateColors{
+				Foreground: lipgloss.Color("160"), // red
+				Background: lipgloss.Color("255"), // white
+				Border:     lipgloss.Color("16"),  // black
+			},
+			Pressed: ButtonStateColors{
+				Foreground: lipgloss.Color("160"), // red
+				Background: lipgloss.Color("242"), // dark gray
+				Border:     lipgloss.Color("238"), // charcoal
+			},
+			Disabled: ButtonStateColors{
+				Foreground: lipgloss.Color("246"), // gray
+				Background: lipgloss.Color("248"), // silver
+				Border:     lipgloss.Color("248"), // silver
+			},
+			Hover: ButtonStateColors{
+				Foreground: lipgloss.Color("160"), // red
+				Background: lipgloss.Color("252"), // light gray
+				Border:     lipgloss.Color("242"), // dark gray
+			},
+		},
+
+		// General UI colors
+		Background: lipgloss.Color("250"), // gray case
+		Foreground: lipgloss.Color("16"),  // black text
+		Border:     lipgloss.Color("244"), // mid gray borders
+		Shadow:     lipgloss.Color("240"), // bevel shadow
+		Highlight:  lipgloss.Color("18"),  // navy highlight
+
+		// State-specific fallback colors
+		FocusColors: ButtonStateColors{
+			Foreground: lipgloss.Color("15"), // white
+			Background: lipgloss.Color("18"), // navy
+			Border:     lipgloss.Color("16"), // black
+		},
+
+		DisabledColors: ButtonStateColors{
+			Foreground: lipgloss.Color("246"), // gray
+			Background: lipgloss.Color("252"), // light gray
+			Border:     lipgloss.Color("248"), // silver
+		},
+	}
+}
+
+// NewColorblindPalette creates a variant of the retro Casio palette for
+// red-green color-vision deficiencies. Operators are orange and special keys
+// blue, and the three button types also differ in luminance so they stay
+// apart in grayscale: dark numbers, mid blue specials, bright operators.
+func NewColorblindPalette() *ColorPalette {
+	return &ColorPalette{
+		// Number buttons - dark gray keys with white text
+		NumberColors: ButtonColorSet{
+			Normal: ButtonStateColors{
+				Foreground: lipgloss.Color("15"),  // white
+				Background: lipgloss.Color("238"), // dark gray
+				Border:     lipgloss.Color("242"), // gray
+			},
+			Focused: ButtonStateColors{
+				Foreground: lipgloss.Color("15"),  // white
+				Background: lipgloss.Color("241"), // gray
+				Border:     lipgloss.Color("15"),  // white highlight
+			},
+			Pressed: ButtonStateColors{
+				Foreground: lipgloss.Color("15"),  // white
+				Background: lipgloss.Color("244"), // light gray
+				Border:     lipgloss.Color("226"), // yellow highlight
+			},
+			Disabled: ButtonStateColors{
+				Foreground: lipgloss.Color("8"),   // dark gray
+				Background: lipgloss.Color("238"), // dark gray
+				Border:     lipgloss.Color("242"), // gray
+			},
+			Hover: ButtonStateColors{
+				Foreground: lipgloss.Color("15"),  // white
+				Background: lipgloss.Color("240"), // gray
+				Border:     lipgloss.Color("15"),  // white highlight
+			},
+		},
+
+		// Operator buttons - bright orange keys with black text
+		OperatorColors: ButtonColorSet{
+			Normal: ButtonStateColors{
+				Foreground: lipgloss.Color("16"),  // black
+				Background: lipgloss.Color("214"), // orange
+				Border:     lipgloss.Color("208"), // dark orange
+			},
+			Focused: ButtonStateColors{
+				Foreground: lipgloss.Color("16"),  // black
+				Background: lipgloss.Color("220"), // gold
+				Border:     lipgloss.Color("15"),  // white highlight
+			},
+			Pressed: ButtonStateColors{
+				Foreground: lipgloss.Color("16"),  // black
+				Background: lipgloss.Color("228"), // pale yellow
+				Border:     lipgloss.Color("226"), // yellow highlight
+			},
+			Disabled: ButtonStateColors{
+				Foreground: lipgloss.Color("94"),  // brown
+				Background: lipgloss.Color("214"), // orange
+				Border:     lipgloss.Color("208"), // dark orange
+			},
+			Hover: ButtonStateColors{
+				Foreground: lipgloss.Color("16"),  // black
+				Background: lipgloss.Color("221"), // light orange
+				Border:     lipgloss.Color("15"),  // white highlight
+			},
+		},
+
+		// Special buttons - blue keys with white text
+		SpecialColors: ButtonColorSet{
+			Normal: ButtonStateColors{
+				Foreground: lipgloss.Color("15"), // white
+				Background: lipgloss.Color("33"), // blue
+				Border:     lipgloss.Color("27"), // deep blue
+			},
+			Focused: ButtonStateColors{
+				Foreground: lipgloss.Color("15"), // white
+				Background: lipgloss.Color("39"), // sky blue
+				Border:     lipgloss.Color("15"), // white highlight
+			},
+			Pressed: ButtonStateColors{
+				Foreground: lipgloss.Color("15"),  // white
+				Background: lipgloss.Color("75"),  // light blue
+				Border:     lipgloss.Color("226"), // yellow highlight
+			},
+			Disabled: ButtonStateColors{
+				Foreground: lipgloss.Color("8"),  // dark gray
+				Background: lipgloss.Color("33"), // blue
+				Border:     lipgloss.Color("27"), // deep blue
+			},
+			Hover: ButtonStateColors{
+				Foreground: lipgloss.Color("15"), // white
+				Background: lipgloss.Color("38"), // bright blue
+				Border:     lipgloss.Color("15"), // white highlight
+			},
+		},
+
+		// General UI colors
+		Background: lipgloss.Color("235"), // dark background
+		Foreground: lipgloss.Color("15"),  // white text
+		Border:     lipgloss.Color("242"), // gray borders
+		Shadow:     lipgloss.Color("238"), // shadow color
+		Highlight:  lipgloss.Color("15"),  // white highlight
+
+		// State-specific fallback colors
+		FocusColors: ButtonStateColors{
+			Foreground: lipgloss.Color("16"),  // black
+			Background: lipgloss.Color("15"),  // white
+			Border:     lipgloss.Color("226"), // yellow
+		},
+
+		DisabledColors: ButtonStateColors{
+			Foreground: lipgloss.Color("8"),   // dark gray
+			Background: lipgloss.Color("238"), // dark gray
+			Border:     lipgloss.Color("242"), // gray
+		},
+	}
+}
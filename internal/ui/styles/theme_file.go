@@ -0,0 +1,361 @@
+package styles
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrInvalidTheme is returned for theme files that cannot be used
+var ErrInvalidTheme = errors.New("invalid theme")
+
+// themeFile is the layout of a theme file. Colors are ANSI 256 numbers such
+// as "208" or hex values such as "#ff8700", which are shown as the nearest
+// ANSI 256 color on terminals without truecolor.
+type themeFile struct {
+	Name        string           `json:"name" yaml:"name"`
+	Description string           `json:"description" yaml:"description"`
+	Buttons     themeButtonsFile `json:"buttons" yaml:"buttons"`
+	Grid        themeGridFile    `json:"grid" yaml:"grid"`
+	Display     themeDisplayFile `json:"display" yaml:"display"`
+	Borders     themeBordersFile `json:"borders" yaml:"borders"`
+}
+
+// themeButtonsFile holds the colors of each button type
+type themeButtonsFile struct {
+	Number   themeButtonTypeFile `json:"number" yaml:"number"`
+	Operator themeButtonTypeFile `json:"operator" yaml:"operator"`
+	Special  themeButtonTypeFile `json:"special" yaml:"special"`
+}
+
+// themeButtonTypeFile holds the colors of a button type in each state
+type themeButtonTypeFile struct {
+	Normal   themeStateFile `json:"normal" yaml:"normal"`
+	Focused  themeStateFile `json:"focused" yaml:"focused"`
+	Pressed  themeStateFile `json:"pressed" yaml:"pressed"`
+	Disabled themeStateFile `json:"disabled" yaml:"disabled"`
+	Hover    themeStateFile `json:"hover" yaml:"hover"`
+}
+
+// themeStateFile holds the colors of a button in one state. The border is
+// optional.
+type themeStateFile struct {
+	Foreground string `json:"foreground" yaml:"foreground"`
+	Background string `json:"background" yaml:"background"`
+	Border     string `json:"border" yaml:"border"`
+}
+
+// themeGridFile holds the colors of the grid around the buttons. Pressed
+// defaults to focused.
+type themeGridFile struct {
+	Background string `json:"background" yaml:"background"`
+	Foreground string `json:"foreground" yaml:"foreground"`
+	Border     string `json:"border" yaml:"border"`
+	Focused    string `json:"focused" yaml:"focused"`
+	Pressed    string `json:"pressed" yaml:"pressed"`
+}
+
+// themeDisplayFile holds the colors of the display. Border defaults to the
+// normal border color, error to red and info to the grid's focus color.
+type themeDisplayFile struct {
+	Foreground string `json:"foreground" yaml:"foreground"`
+	Background string `json:"background" yaml:"background"`
+	Border     string `json:"border" yaml:"border"`
+	Error      string `json:"error" yaml:"error"`
+	Info       string `json:"info" yaml:"info"`
+}
+
+// themeBordersFile holds the border style and its colors per state. Style is
+// one of themeBorderStyles and defaults to rounded; pressed defaults to the
+// focused color and disabled to the normal one.
+type themeBordersFile struct {
+	Style    string `json:"style" yaml:"style"`
+	Normal   string `json:"normal" yaml:"normal"`
+	Focused  string `json:"focused" yaml:"focused"`
+	Pressed  string `json:"pressed" yaml:"pressed"`
+	Disabled string `json:"disabled" yaml:"disabled"`
+}
+
+// themeBorderStyles names the border styles a theme file can use
+var themeBorderStyles = map[string]lipgloss.Border{
+	"normal":  lipgloss.NormalBorder(),
+	"rounded": lipgloss.RoundedBorder(),
+	"double":  lipgloss.DoubleBorder(),
+	"thick":   lipgloss.ThickBorder(),
+	"hidden":  lipgloss.HiddenBorder(),
+}
+
+// hexColor matches #rrggbb colors
+var hexColor = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// themeColor is a color field of a theme file, named by its key path
+type themeColor struct {
+	key      string
+	value    *string
+	required bool
+}
+
+// LoadThemeFromFile reads a theme from a JSON (.json) or YAML (.yaml, .yml)
+// file and registers it under its name, replacing an earlier custom theme of
+// the same name, so SetTheme and GetTheme can use it. A file with missing
+// keys gives an error listing all of them.
+func (tm *ThemeManager) LoadThemeFromFile(path string) (*UITheme, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read theme: %w", err)
+	}
+
+	var file themeFile
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(data, &file)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &file)
+	default:
+		return nil, fmt.Errorf("%w: %s is not a .json, .yaml or .yml file", ErrInvalidTheme, path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTheme, err)
+	}
+
+	if err := file.validate(); err != nil {
+		return nil, err
+	}
+	if isBuiltInTheme(file.Name) {
+		return nil, fmt.Errorf("%w: %q is a built-in theme", ErrInvalidTheme, file.Name)
+	}
+
+	// Hex colors fall back to the nearest 256-color code without truecolor
+	if !tm.trueColor {
+		for _, color := range file.colors() {
+			*color.value = string(NearestANSI256(lipgloss.Color(*color.value)))
+		}
+	}
+
+	theme := tm.createFileTheme(&file)
+	tm.themes[theme.Name] = theme
+	return theme, nil
+}
+
+// isBuiltInTheme reports whether name is one of the themes every ThemeManager has
+func isBuiltInTheme(name string) bool {
+	switch name {
+	case "retro-casio", "retro-casio-cb", "modern", "minimal", "classic":
+		return true
+	default:
+		return false
+	}
+}
+
+// colors returns every color field of the file
+func (f *themeFile) colors() []themeColor {
+	var colors []themeColor
+	buttonTypes := []struct {
+		name   string
+		colors *themeButtonTypeFile
+	}{
+		{"number", &f.Buttons.Number},
+		{"operator", &f.Buttons.Operator},
+		{"special", &f.Buttons.Special},
+	}
+	for _, buttonType := range buttonTypes {
+		states := []struct {
+			name   string
+			colors *themeStateFile
+		}{
+			{"normal", &buttonType.colors.Normal},
+			{"focused", &buttonType.colors.Focused},
+			{"pressed", &buttonType.colors.Pressed},
+			{"disabled", &buttonType.colors.Disabled},
+			{"hover", &buttonType.colors.Hover},
+		}
+		for _, state := range states {
+			prefix := "buttons." + buttonType.name + "." + state.name + "."
+			colors = append(colors,
+				themeColor{prefix + "foreground", &state.colors.Foreground, true},
+				themeColor{prefix + "background", &state.colors.Background, true},
+				themeColor{prefix + "border", &state.colors.Border, false},
+			)
+		}
+	}
+
+	return append(colors,
+		themeColor{"grid.background", &f.Grid.Background, true},
+		themeColor{"grid.foreground", &f.Grid.Foreground, true},
+		themeColor{"grid.border", &f.Grid.Border, true},
+		themeColor{"grid.focused", &f.Grid.Focused, true},
+		themeColor{"grid.pressed", &f.Grid.Pressed, false},
+		themeColor{"display.foreground", &f.Display.Foreground, true},
+		themeColor{"display.background", &f.Display.Background, true},
+		themeColor{"display.border", &f.Display.Border, false},
+		themeColor{"display.error", &f.Display.Error, false},
+		themeColor{"display.info", &f.Display.Info, false},
+		themeColor{"borders.normal", &f.Borders.Normal, true},
+		themeColor{"borders.focused", &f.Borders.Focused, true},
+		themeColor{"borders.pressed", &f.Borders.Pressed, false},
+		themeColor{"borders.disabled", &f.Borders.Disabled, false},
+	)
+}
+
+// validate checks that every required key is present and every color and
+// the border style are valid, then fills in the optional keys
+func (f *themeFile) validate() error {
+	var missing []string
+	if strings.TrimSpace(f.Name) == "" {
+		missing = append(missing, "name")
+	}
+	for _, color := range f.colors() {
+		if color.required && *color.value == "" {
+			missing = append(missing, color.key)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: missing %s", ErrInvalidTheme, strings.Join(missing, ", "))
+	}
+
+	for _, color := range f.colors() {
+		if *color.value != "" && !isThemeColor(*color.value) {
+			return fmt.Errorf("%w: %s is %q, expected an ANSI color number or #rrggbb", ErrInvalidTheme, color.key, *color.value)
+		}
+	}
+	if f.Borders.Style == "" {
+		f.Borders.Style = "rounded"
+	}
+	if _, ok := themeBorderStyles[f.Borders.Style]; !ok {
+		return fmt.Errorf("%w: unknown border style %q, expected normal, rounded, double, thick or hidden", ErrInvalidTheme, f.Borders.Style)
+	}
+
+	f.Name = strings.TrimSpace(f.Name)
+	defaultColor(&f.Grid.Pressed, f.Grid.Focused)
+	defaultColor(&f.Display.Border, f.Borders.Normal)
+	defaultColor(&f.Display.Error, "196")
+	defaultColor(&f.Display.Info, f.Grid.Focused)
+	defaultColor(&f.Borders.Pressed, f.Borders.Focused)
+	defaultColor(&f.Borders.Disabled, f.Borders.Normal)
+	return nil
+}
+
+// defaultColor sets an unset color to fallback
+func defaultColor(color *string, fallback string) {
+	if *color == "" {
+		*color = fallback
+	}
+}
+
+// isThemeColor reports whether value is an ANSI 256 color number or #rrggbb
+func isThemeColor(value string) bool {
+	if hexColor.MatchString(value) {
+		return true
+	}
+	number, err := strconv.Atoi(value)
+	return err == nil && number >= 0 && number <= 255
+}
+
+// colorSet converts the colors of a button type into a ButtonColorSet
+func (f themeButtonTypeFile) colorSet() ButtonColorSet {
+	state := func(s themeStateFile) ButtonStateColors {
+		return ButtonStateColors{
+			Foreground: lipgloss.Color(s.Foreground),
+			Background: lipgloss.Color(s.Background),
+			Border:     lipgloss.Color(s.Border),
+		}
+	}
+	return ButtonColorSet{
+		Normal:   state(f.Normal),
+		Focused:  state(f.Focused),
+		Pressed:  state(f.Pressed),
+		Disabled: state(f.Disabled),
+		Hover:    state(f.Hover),
+	}
+}
+
+// createFileTheme creates a theme from a validated theme file
+func (tm *ThemeManager) createFileTheme(f *themeFile) *UITheme {
+	border := themeBorderStyles[f.Borders.Style]
+	palette := &ColorPalette{
+		NumberColors:   f.Buttons.Number.colorSet(),
+		OperatorColors: f.Buttons.Operator.colorSet(),
+		SpecialColors:  f.Buttons.Special.colorSet(),
+		Background:     lipgloss.Color(f.Grid.Background),
+		Foreground:     lipgloss.Color(f.Grid.Foreground),
+		Border:         lipgloss.Color(f.Grid.Border),
+		Shadow:         lipgloss.Color(f.Borders.Disabled),
+		Highlight:      lipgloss.Color(f.Grid.Focused),
+		FocusColors: ButtonStateColors{
+			Foreground: lipgloss.Color(f.Buttons.Number.Focused.Foreground),
+			Background: lipgloss.Color(f.Grid.Pressed),
+			Border:     lipgloss.Color(f.Borders.Focused),
+		},
+		DisabledColors: f.Buttons.Number.colorSet().Disabled,
+	}
+
+	button := tm.createPaletteButtonTheme(palette, lipgloss.NewStyle().
+		Border(border, false).
+		Padding(0, 1))
+	display := DisplayTheme{
+		Main: lipgloss.NewStyle().
+			Background(lipgloss.Color(f.Display.Background)).
+			Foreground(lipgloss.Color(f.Display.Foreground)).
+			Border(border).
+			BorderForeground(lipgloss.Color(f.Display.Border)).
+			Padding(1, 2).
+			Align(lipgloss.Right, lipgloss.Center),
+		Secondary: lipgloss.NewStyle().
+			Background(palette.GetBackground()).
+			Foreground(palette.GetForeground()).
+			Align(lipgloss.Left, lipgloss.Center),
+		Error: lipgloss.NewStyle().
+			Background(palette.GetBackground()).
+			Foreground(lipgloss.Color(f.Display.Error)).
+			Bold(true).
+			Align(lipgloss.Center, lipgloss.Center),
+		Info: lipgloss.NewStyle().
+			Background(palette.GetBackground()).
+			Foreground(lipgloss.Color(f.Display.Info)).
+			Align(lipgloss.Center, lipgloss.Center),
+	}
+
+	return &UITheme{
+		Name:        f.Name,
+		Description: f.Description,
+		Colors:      palette,
+		IsRetro:     false,
+		Styles: &ThemeStyles{
+			Button:  button,
+			Grid:    tm.createPaletteGridTheme(palette, border),
+			Display: display,
+			Text: tm.createPaletteTextTheme(palette, textColors{
+				Muted:   lipgloss.Color(f.Borders.Disabled),
+				Error:   lipgloss.Color(f.Display.Error),
+				Success: lipgloss.Color("46"),
+				Warning: lipgloss.Color("226"),
+			}),
+			Border: BorderTheme{
+				Normal:   border,
+				Focused:  border,
+				Pressed:  border,
+				Disabled: lipgloss.HiddenBorder(),
+				Colors: BorderColors{
+					Normal:   lipgloss.Color(f.Borders.Normal),
+					Focused:  lipgloss.Color(f.Borders.Focused),
+					Pressed:  lipgloss.Color(f.Borders.Pressed),
+					Disabled: lipgloss.Color(f.Borders.Disabled),
+				},
+			},
+			Animation: tm.createPaletteAnimationTheme(palette, button, display, []lipgloss.Color{
+				lipgloss.Color(f.Borders.Disabled),
+				lipgloss.Color(f.Grid.Border),
+				lipgloss.Color(f.Grid.Foreground),
+				lipgloss.Color(f.Grid.Focused),
+			}),
+		},
+	}
+}
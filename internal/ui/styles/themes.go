@@ -9,6 +9,9 @@ type ThemeManager struct {
 	retroStyler  *RetroStyler
 	currentTheme string
 	themes       map[string]*UITheme
+
+	// Whether themes use 24-bit colors rather than 256-color codes
+	trueColor bool
 }
 
 // UITheme represents a complete UI theme
@@ -28,6 +31,33 @@ type ThemeStyles struct {
 	Text         TextTheme
 	Border       BorderTheme
 	Animation    AnimationTheme
+	FocusRing    FocusRingTheme
+}
+
+// FocusRingTheme defines the high-visibility ring drawn around the focused button
+type FocusRingTheme struct {
+	Left  string
+	Right string
+	Style lipgloss.Style
+}
+
+// IsEmpty reports whether the focus ring has no markers
+func (fr FocusRingTheme) IsEmpty() bool {
+	return fr.Left == "" && fr.Right == ""
+}
+
+// Wrap surrounds a label with the focus ring markers
+func (fr FocusRingTheme) Wrap(label string) string {
+	return fr.Left + label + fr.Right
+}
+
+// DefaultFocusRing returns a bracketed focus ring that stays visible without color
+func DefaultFocusRing() FocusRingTheme {
+	return FocusRingTheme{
+		Left:  "[",
+		Right: "]",
+		Style: lipgloss.NewStyle().Bold(true).Reverse(true),
+	}
 }
 
 // ButtonTheme defines styling for all button types and states
@@ -43,6 +73,7 @@ type ButtonTypeTheme struct {
 	Focused  lipgloss.Style
 	Pressed  lipgloss.Style
 	Disabled lipgloss.Style
+	Hover    lipgloss.Style
 }
 
 // GridTheme defines styling for grid components
@@ -109,6 +140,7 @@ func NewThemeManager() *ThemeManager {
 		retroStyler:  retroStyler,
 		currentTheme: "retro-casio",
 		themes:       make(map[string]*UITheme),
+		trueColor:    TrueColorSupported(),
 	}
 
 	// Initialize with default themes
@@ -122,6 +154,9 @@ func (tm *ThemeManager) initializeDefaultThemes() {
 	// Retro Casio theme
 	tm.themes["retro-casio"] = tm.createRetroCasioTheme()
 
+	// Retro Casio theme for color-vision deficiencies
+	tm.themes["retro-casio-cb"] = tm.createRetroCasioColorblindTheme()
+
 	// Modern theme
 	tm.themes["modern"] = tm.createModernTheme()
 
@@ -134,11 +169,25 @@ func (tm *ThemeManager) initializeDefaultThemes() {
 
 // createRetroCasioTheme creates the retro Casio calculator theme
 func (tm *ThemeManager) createRetroCasioTheme() *UITheme {
-	palette := NewColorPalette()
+	return tm.createRetroPaletteTheme("retro-casio", "Classic retro Casio calculator styling", NewColorPalette())
+}
+
+// createRetroCasioColorblindTheme creates the retro Casio theme in colors that
+// stay distinct with red-green color-vision deficiencies
+func (tm *ThemeManager) createRetroCasioColorblindTheme() *UITheme {
+	return tm.createRetroPaletteTheme("retro-casio-cb", "Retro Casio styling in colorblind-friendly blue and orange", NewColorblindPalette())
+}
+
+// createRetroPaletteTheme creates a retro Casio styled theme in the colors of
+// palette, or its true colors on a truecolor terminal
+func (tm *ThemeManager) createRetroPaletteTheme(name, description string, palette *ColorPalette) *UITheme {
+	if tm.trueColor {
+		palette = palette.WithTrueColor()
+	}
 
 	return &UITheme{
-		Name:        "retro-casio",
-		Description: "Classic retro Casio calculator styling",
+		Name:        name,
+		Description: description,
 		Colors:      palette,
 		IsRetro:     true,
 		Styles: &ThemeStyles{
@@ -148,6 +197,13 @@ func (tm *ThemeManager) createRetroCasioTheme() *UITheme {
 			Text:    tm.createRetroTextTheme(palette),
 			Border:  tm.createRetroBorderTheme(palette),
 			Animation: tm.createRetroAnimationTheme(palette),
+			FocusRing: FocusRingTheme{
+				Left:  "▶",
+				Right: "◀",
+				Style: lipgloss.NewStyle().
+					Bold(true).
+					Foreground(palette.GetHighlight()),
+			},
 		},
 	}
 }
@@ -184,6 +240,13 @@ func (tm *ThemeManager) createRetroButtonTheme(palette *ColorPalette) ButtonThem
 				BorderForeground(palette.GetNumberColors().Disabled.Border).
 				Align(lipgloss.Center, lipgloss.Center).
 				Padding(0, 1),
+			Hover: lipgloss.NewStyle().
+				Foreground(palette.GetNumberColors().Hover.Foreground).
+				Background(palette.GetNumberColors().Hover.Background).
+				Border(lipgloss.NormalBorder(), false).
+				BorderForeground(palette.GetNumberColors().Hover.Border).
+				Align(lipgloss.Center, lipgloss.Center).
+				Padding(0, 1),
 		},
 		Operator: ButtonTypeTheme{
 			Normal: lipgloss.NewStyle().
@@ -214,6 +277,13 @@ func (tm *ThemeManager) createRetroButtonTheme(palette *ColorPalette) ButtonThem
 				BorderForeground(palette.GetOperatorColors().Disabled.Border).
 				Align(lipgloss.Center, lipgloss.Center).
 				Padding(0, 1),
+			Hover: lipgloss.NewStyle().
+				Foreground(palette.GetOperatorColors().Hover.Foreground).
+				Background(palette.GetOperatorColors().Hover.Background).
+				Border(lipgloss.NormalBorder(), false).
+				BorderForeground(palette.GetOperatorColors().Hover.Border).
+				Align(lipgloss.Center, lipgloss.Center).
+				Padding(0, 1),
 		},
 		Special: ButtonTypeTheme{
 			Normal: lipgloss.NewStyle().
@@ -244,6 +314,13 @@ func (tm *ThemeManager) createRetroButtonTheme(palette *ColorPalette) ButtonThem
 				BorderForeground(palette.GetSpecialColors().Disabled.Border).
 				Align(lipgloss.Center, lipgloss.Center).
 				Padding(0, 1),
+			Hover: lipgloss.NewStyle().
+				Foreground(palette.GetSpecialColors().Hover.Foreground).
+				Background(palette.GetSpecialColors().Hover.Background).
+				Border(lipgloss.NormalBorder(), false).
+				BorderForeground(palette.GetSpecialColors().Hover.Border).
+				Align(lipgloss.Center, lipgloss.Center).
+				Padding(0, 1),
 		},
 	}
 }
@@ -380,39 +457,325 @@ func (tm *ThemeManager) createRetroAnimationTheme(palette *ColorPalette) Animati
 	}
 }
 
-// createModernTheme creates a modern theme (fallback)
+// createModernTheme creates the clean dark modern theme
 func (tm *ThemeManager) createModernTheme() *UITheme {
-	palette := NewColorPalette()
+	palette := NewModernPalette()
+	button := tm.createPaletteButtonTheme(palette, lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder(), false).
+		Padding(0, 1))
+	display := DisplayTheme{
+		Main: lipgloss.NewStyle().
+			Background(lipgloss.Color("236")).
+			Foreground(lipgloss.Color("15")).
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(palette.GetHighlight()).
+			Padding(1, 2).
+			Align(lipgloss.Right, lipgloss.Center),
+		Secondary: lipgloss.NewStyle().
+			Background(palette.GetBackground()).
+			Foreground(lipgloss.Color("245")).
+			Align(lipgloss.Left, lipgloss.Center),
+		Error: lipgloss.NewStyle().
+			Background(palette.GetBackground()).
+			Foreground(lipgloss.Color("203")). // coral text
+			Bold(true).
+			Align(lipgloss.Center, lipgloss.Center),
+		Info: lipgloss.NewStyle().
+			Background(palette.GetBackground()).
+			Foreground(palette.GetHighlight()).
+			Align(lipgloss.Center, lipgloss.Center),
+	}
+
 	return &UITheme{
 		Name:        "modern",
-		Description: "Modern clean styling",
+		Description: "Modern clean dark styling",
 		Colors:      palette,
 		IsRetro:     false,
-		Styles:      &ThemeStyles{}, // Simplified for brevity
+		Styles: &ThemeStyles{
+			Button:  button,
+			Grid:    tm.createPaletteGridTheme(palette, lipgloss.RoundedBorder()),
+			Display: display,
+			Text: tm.createPaletteTextTheme(palette, textColors{
+				Muted:   lipgloss.Color("245"),
+				Error:   lipgloss.Color("203"),
+				Success: lipgloss.Color("78"),
+				Warning: lipgloss.Color("221"),
+			}),
+			Border: BorderTheme{
+				Normal:   lipgloss.RoundedBorder(),
+				Focused:  lipgloss.RoundedBorder(),
+				Pressed:  lipgloss.ThickBorder(),
+				Disabled: lipgloss.HiddenBorder(),
+				Colors: BorderColors{
+					Normal:   palette.GetBorder(),
+					Focused:  palette.GetHighlight(),
+					Pressed:  palette.GetFocusColors().Border,
+					Disabled: palette.GetDisabledColors().Border,
+				},
+			},
+			Animation: tm.createPaletteAnimationTheme(palette, button, display, []lipgloss.Color{"238", "24", "31", "39"}),
+		},
 	}
 }
 
-// createMinimalTheme creates a minimal theme (fallback)
+// createMinimalTheme creates the minimal theme: flat buttons without borders
+// or resting backgrounds on plain black
 func (tm *ThemeManager) createMinimalTheme() *UITheme {
-	palette := NewColorPalette()
+	palette := NewMinimalPalette()
+	button := tm.createPaletteButtonTheme(palette, lipgloss.NewStyle().Padding(0, 1))
+	display := DisplayTheme{
+		Main: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("15")).
+			Bold(true).
+			Padding(0, 1).
+			Align(lipgloss.Right, lipgloss.Center),
+		Secondary: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("244")).
+			Align(lipgloss.Left, lipgloss.Center),
+		Error: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("210")). // pale red text
+			Align(lipgloss.Center, lipgloss.Center),
+		Info: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("117")). // pale blue text
+			Align(lipgloss.Center, lipgloss.Center),
+	}
+
 	return &UITheme{
 		Name:        "minimal",
-		Description: "Minimal styling",
+		Description: "Minimal flat styling",
 		Colors:      palette,
 		IsRetro:     false,
-		Styles:      &ThemeStyles{}, // Simplified for brevity
+		Styles: &ThemeStyles{
+			Button:  button,
+			Grid:    tm.createPaletteGridTheme(palette, lipgloss.HiddenBorder()),
+			Display: display,
+			Text: tm.createPaletteTextTheme(palette, textColors{
+				Muted:   lipgloss.Color("244"),
+				Error:   lipgloss.Color("210"),
+				Success: lipgloss.Color("151"),
+				Warning: lipgloss.Color("229"),
+			}),
+			Border: BorderTheme{
+				Normal:   lipgloss.HiddenBorder(),
+				Focused:  lipgloss.HiddenBorder(),
+				Pressed:  lipgloss.HiddenBorder(),
+				Disabled: lipgloss.HiddenBorder(),
+				Colors: BorderColors{
+					Normal:   palette.GetBorder(),
+					Focused:  palette.GetHighlight(),
+					Pressed:  palette.GetHighlight(),
+					Disabled: palette.GetBorder(),
+				},
+			},
+			Animation: tm.createPaletteAnimationTheme(palette, button, display, []lipgloss.Color{"236", "240", "246", "15"}),
+			// Minimal keeps a plain ASCII ring so focus survives without color
+			FocusRing: FocusRingTheme{
+				Left:  ">",
+				Right: "<",
+				Style: lipgloss.NewStyle().Bold(true).Underline(true),
+			},
+		},
 	}
 }
 
-// createClassicTheme creates a classic theme (fallback)
+// createClassicTheme creates the classic theme, after old desktop calculators
+// with gray keys and a white display
 func (tm *ThemeManager) createClassicTheme() *UITheme {
-	palette := NewColorPalette()
+	palette := NewClassicPalette()
+	button := tm.createPaletteButtonTheme(palette, lipgloss.NewStyle().
+		Border(lipgloss.NormalBorder(), false).
+		Bold(true).
+		Padding(0, 1))
+	display := DisplayTheme{
+		Main: lipgloss.NewStyle().
+			Background(lipgloss.Color("15")). // white background
+			Foreground(lipgloss.Color("16")). // black text
+			Border(lipgloss.NormalBorder()).
+			BorderForeground(palette.GetShadow()).
+			Padding(0, 1).
+			Align(lipgloss.Right, lipgloss.Center),
+		Secondary: lipgloss.NewStyle().
+			Background(palette.GetBackground()).
+			Foreground(lipgloss.Color("240")).
+			Align(lipgloss.Left, lipgloss.Center),
+		Error: lipgloss.NewStyle().
+			Background(lipgloss.Color("160")). // red background
+			Foreground(lipgloss.Color("15")).  // white text
+			Align(lipgloss.Center, lipgloss.Center),
+		Info: lipgloss.NewStyle().
+			Background(palette.GetBackground()).
+			Foreground(palette.GetHighlight()).
+			Align(lipgloss.Center, lipgloss.Center),
+	}
+
 	return &UITheme{
 		Name:        "classic",
-		Description: "Classic styling",
+		Description: "Classic desktop calculator styling",
 		Colors:      palette,
 		IsRetro:     false,
-		Styles:      &ThemeStyles{}, // Simplified for brevity
+		Styles: &ThemeStyles{
+			Button:  button,
+			Grid:    tm.createPaletteGridTheme(palette, lipgloss.NormalBorder()),
+			Display: display,
+			Text: tm.createPaletteTextTheme(palette, textColors{
+				Muted:   lipgloss.Color("240"),
+				Error:   lipgloss.Color("160"),
+				Success: lipgloss.Color("22"),
+				Warning: lipgloss.Color("130"),
+			}),
+			Border: BorderTheme{
+				Normal:   lipgloss.NormalBorder(),
+				Focused:  lipgloss.DoubleBorder(),
+				Pressed:  lipgloss.NormalBorder(),
+				Disabled: lipgloss.HiddenBorder(),
+				Colors: BorderColors{
+					Normal:   palette.GetBorder(),
+					Focused:  palette.GetHighlight(),
+					Pressed:  palette.GetShadow(),
+					Disabled: palette.GetDisabledColors().Border,
+				},
+			},
+			Animation: tm.createPaletteAnimationTheme(palette, button, display, []lipgloss.Color{"248", "244", "240", "18"}),
+		},
+	}
+}
+
+// textColors are the colors of a theme's text that its palette does not cover
+type textColors struct {
+	Muted   lipgloss.Color
+	Error   lipgloss.Color
+	Success lipgloss.Color
+	Warning lipgloss.Color
+}
+
+// createPaletteButtonTheme creates button styling from a palette, each state
+// taking its colors on top of base
+func (tm *ThemeManager) createPaletteButtonTheme(palette *ColorPalette, base lipgloss.Style) ButtonTheme {
+	return ButtonTheme{
+		Number:   paletteButtonTypeTheme(palette.GetNumberColors(), base),
+		Operator: paletteButtonTypeTheme(palette.GetOperatorColors(), base),
+		Special:  paletteButtonTypeTheme(palette.GetSpecialColors(), base),
+	}
+}
+
+// paletteButtonTypeTheme styles each state of a button type with its colors.
+// A state without a background or border color leaves it unset, so the
+// button stays flat.
+func paletteButtonTypeTheme(colors ButtonColorSet, base lipgloss.Style) ButtonTypeTheme {
+	state := func(c ButtonStateColors) lipgloss.Style {
+		style := base.
+			Foreground(c.Foreground).
+			Align(lipgloss.Center, lipgloss.Center)
+		if c.Background != "" {
+			style = style.Background(c.Background)
+		}
+		if c.Border != "" {
+			style = style.BorderForeground(c.Border)
+		}
+		return style
+	}
+
+	return ButtonTypeTheme{
+		Normal:   state(colors.Normal),
+		Focused:  state(colors.Focused),
+		Pressed:  state(colors.Pressed),
+		Disabled: state(colors.Disabled),
+		Hover:    state(colors.Hover),
+	}
+}
+
+// createPaletteGridTheme creates grid styling from a palette with the given
+// cell border
+func (tm *ThemeManager) createPaletteGridTheme(palette *ColorPalette, border lipgloss.Border) GridTheme {
+	return GridTheme{
+		Container: lipgloss.NewStyle().
+			Background(palette.GetBackground()).
+			Border(border).
+			BorderForeground(palette.GetBorder()).
+			Padding(1).
+			Margin(0, 1),
+		Cell: lipgloss.NewStyle().
+			Background(palette.GetBackground()).
+			Border(border).
+			BorderForeground(palette.GetBorder()).
+			Align(lipgloss.Center, lipgloss.Center),
+		CellFocused: lipgloss.NewStyle().
+			Background(palette.GetBackground()).
+			Border(border).
+			BorderForeground(palette.GetHighlight()).
+			Align(lipgloss.Center, lipgloss.Center),
+		CellPressed: lipgloss.NewStyle().
+			Background(palette.GetBackground()).
+			Border(border).
+			BorderForeground(palette.GetFocusColors().Background).
+			Align(lipgloss.Center, lipgloss.Center),
+		CellDisabled: lipgloss.NewStyle().
+			Background(palette.GetBackground()).
+			Border(lipgloss.HiddenBorder()).
+			Align(lipgloss.Center, lipgloss.Center),
+		Spacing: 1,
+		Padding: 1,
+	}
+}
+
+// createPaletteTextTheme creates text styling from a palette and the theme's
+// text colors
+func (tm *ThemeManager) createPaletteTextTheme(palette *ColorPalette, colors textColors) TextTheme {
+	return TextTheme{
+		Title: lipgloss.NewStyle().
+			Foreground(palette.GetForeground()).
+			Background(palette.GetBackground()).
+			Bold(true).
+			Align(lipgloss.Center),
+		Subtitle: lipgloss.NewStyle().
+			Foreground(colors.Muted).
+			Background(palette.GetBackground()).
+			Align(lipgloss.Center),
+		Body: lipgloss.NewStyle().
+			Foreground(palette.GetForeground()).
+			Background(palette.GetBackground()),
+		Caption: lipgloss.NewStyle().
+			Foreground(colors.Muted).
+			Background(palette.GetBackground()).
+			Italic(true).
+			Align(lipgloss.Center),
+		Error: lipgloss.NewStyle().
+			Foreground(colors.Error).
+			Background(palette.GetBackground()).
+			Bold(true),
+		Success: lipgloss.NewStyle().
+			Foreground(colors.Success).
+			Background(palette.GetBackground()).
+			Bold(true),
+		Warning: lipgloss.NewStyle().
+			Foreground(colors.Warning).
+			Background(palette.GetBackground()).
+			Bold(true),
+	}
+}
+
+// createPaletteAnimationTheme creates animation styling from a theme's button
+// and display styles, with a loader fading through loaderColors
+func (tm *ThemeManager) createPaletteAnimationTheme(palette *ColorPalette, button ButtonTheme, display DisplayTheme, loaderColors []lipgloss.Color) AnimationTheme {
+	loader := make([]lipgloss.Style, len(loaderColors))
+	for i, color := range loaderColors {
+		loader[i] = lipgloss.NewStyle().Foreground(color)
+	}
+
+	return AnimationTheme{
+		ButtonPress: []lipgloss.Style{
+			button.Number.Normal,
+			button.Number.Focused,
+			button.Number.Pressed,
+		},
+		DisplayBlink: []lipgloss.Style{
+			display.Main,
+			display.Main.Foreground(palette.GetDisabledColors().Foreground),
+		},
+		Loader: loader,
+		Highlight: lipgloss.NewStyle().
+			Background(palette.GetHighlight()).
+			Foreground(palette.GetFocusColors().Foreground),
 	}
 }
 
@@ -439,6 +802,12 @@ func (tm *ThemeManager) SetTheme(name string) error {
 	return nil
 }
 
+// IsTrueColor returns whether themes use 24-bit colors, which is decided by
+// TrueColorSupported when the manager is created
+func (tm *ThemeManager) IsTrueColor() bool {
+	return tm.trueColor
+}
+
 // ListThemes returns a list of available theme names
 func (tm *ThemeManager) ListThemes() []string {
 	var names []string
@@ -453,6 +822,16 @@ func (tm *ThemeManager) GetButtonTheme() ButtonTheme {
 	return tm.GetCurrentTheme().Styles.Button
 }
 
+// GetFocusRing returns the focus ring for the current theme, falling back to
+// DefaultFocusRing for themes that do not define one
+func (tm *ThemeManager) GetFocusRing() FocusRingTheme {
+	theme := tm.GetCurrentTheme()
+	if theme == nil || theme.Styles == nil || theme.Styles.FocusRing.IsEmpty() {
+		return DefaultFocusRing()
+	}
+	return theme.Styles.FocusRing
+}
+
 // GetButtonStyle returns a button style for the specified type and state
 func (tm *ThemeManager) GetButtonStyle(buttonType, state string) lipgloss.Style {
 	buttonTheme := tm.GetButtonTheme()
@@ -480,6 +859,8 @@ func (tm *ThemeManager) getButtonTypeStyle(theme ButtonTypeTheme, state string)
 		return theme.Pressed
 	case "disabled", "Disabled":
 		return theme.Disabled
+	case "hover", "Hover":
+		return theme.Hover
 	default:
 		return theme.Normal
 	}
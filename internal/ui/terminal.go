@@ -6,17 +6,21 @@ import (
 	"strings"
 
 	"golang.org/x/term"
+
+	uistyles "ccpm-demo/internal/ui/styles"
 )
 
-// IsTerminalCompatible checks if the current terminal supports required features
-func IsTerminalCompatible() bool {
+// IsTerminalCompatible checks if the current terminal supports required
+// features, and reports whether it also supports 24-bit truecolor, which the
+// themes use when it is available
+func IsTerminalCompatible() (compatible, trueColor bool) {
 	// Check if we're running in a terminal
 	if !term.IsTerminal(int(os.Stdout.Fd())) {
-		return false
+		return false, false
 	}
 
 	// Check for basic terminal capabilities
-	return checkTerminalCapabilities()
+	return checkTerminalCapabilities(), uistyles.TrueColorSupported()
 }
 
 // checkTerminalCapabilities performs basic terminal capability checks
@@ -189,6 +193,7 @@ func GetTerminalInfo() map[string]string {
 
 	// Environment flags
 	info["COLOR_SUPPORT"] = formatBool(hasColorSupport())
+	info["TRUECOLOR_SUPPORT"] = formatBool(uistyles.TrueColorSupported())
 	info["MOUSE_SUPPORT"] = formatBool(hasMouseSupport())
 	info["IN_DOCKER"] = formatBool(IsRunningInDocker())
 	info["IN_CI"] = formatBool(IsRunningInCI())
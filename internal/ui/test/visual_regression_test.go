@@ -3,6 +3,7 @@ package test
 import (
 	"bytes"
 	"fmt"
+	"math"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -18,6 +19,7 @@ import (
 	"ccpm-demo/internal/calculator"
 	"ccpm-demo/internal/ui"
 	"ccpm-demo/internal/ui/integration"
+	"ccpm-demo/internal/ui/styles"
 	visualpkg "ccpm-demo/internal/visual"
 	visualtesting "ccpm-demo/internal/testing/visual"
 )
@@ -248,6 +250,25 @@ func TestButtonAccessibility(t *testing.T) {
 		// This is basic - in a real test you'd analyze the actual styling
 		assert.NotEmpty(t, rendering)
 	})
+
+	// Operators and numbers must differ in luminance, not just hue, so they
+	// stay apart in grayscale and for color-vision deficiencies
+	for _, theme := range []string{"retro-casio", "retro-casio-cb"} {
+		t.Run(fmt.Sprintf("theme_%s_luminance", theme), func(t *testing.T) {
+			tm := styles.NewThemeManager()
+			require.NoError(t, tm.SetTheme(theme))
+			buttons := tm.GetButtonTheme()
+
+			number, ok := buttons.Number.Normal.GetBackground().(lipgloss.Color)
+			require.True(t, ok)
+			operator, ok := buttons.Operator.Normal.GetBackground().(lipgloss.Color)
+			require.True(t, ok)
+
+			assert.NotEqual(t, number, operator)
+			assert.Greater(t, math.Abs(styles.Luminance(operator)-styles.Luminance(number)), 0.2,
+				"operator %s and number %s backgrounds should differ in luminance", operator, number)
+		})
+	}
 }
 
 // TestResponsiveRendering tests responsive behavior
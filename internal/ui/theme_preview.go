@@ -0,0 +1,104 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// themePreviewKey opens the theme preview
+const themePreviewKey = "t"
+
+// themePreview holds the state of the theme preview overlay
+type themePreview struct {
+	active   bool
+	original string
+	themes   []string
+	selected int
+}
+
+// OpenThemePreview shows the theme preview, starting from the active theme.
+// The grid is drawn in each candidate theme until the preview is committed
+// or cancelled.
+func (m *Model) OpenThemePreview() {
+	themes := m.buttonGrid.ListThemes()
+	sort.Strings(themes)
+
+	original := m.buttonGrid.GetCurrentTheme()
+	selected := 0
+	for i, name := range themes {
+		if name == original {
+			selected = i
+		}
+	}
+
+	m.preview = themePreview{
+		active:   true,
+		original: original,
+		themes:   themes,
+		selected: selected,
+	}
+}
+
+// IsThemePreviewActive returns whether the theme preview is open
+func (m Model) IsThemePreviewActive() bool {
+	return m.preview.active
+}
+
+// PreviewedTheme returns the theme currently shown in the preview
+func (m Model) PreviewedTheme() string {
+	if !m.preview.active || len(m.preview.themes) == 0 {
+		return ""
+	}
+	return m.preview.themes[m.preview.selected]
+}
+
+// CycleThemePreview shows the next (delta > 0) or previous theme, wrapping around
+func (m *Model) CycleThemePreview(delta int) {
+	count := len(m.preview.themes)
+	if !m.preview.active || count == 0 {
+		return
+	}
+
+	m.preview.selected = ((m.preview.selected+delta)%count + count) % count
+	m.buttonGrid.SetTheme(m.PreviewedTheme())
+}
+
+// CommitThemePreview keeps the previewed theme and closes the preview
+func (m *Model) CommitThemePreview() {
+	m.preview = themePreview{}
+}
+
+// CancelThemePreview restores the theme that was active before the preview
+func (m *Model) CancelThemePreview() {
+	if m.preview.active {
+		m.buttonGrid.SetTheme(m.preview.original)
+	}
+	m.preview = themePreview{}
+}
+
+// handleThemePreviewKey processes keys while the theme preview is open
+func handleThemePreviewKey(m Model, msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc, tea.KeyCtrlC:
+		m.CancelThemePreview()
+
+	case tea.KeyEnter:
+		m.CommitThemePreview()
+
+	case tea.KeyLeft, tea.KeyUp, tea.KeyShiftTab:
+		m.CycleThemePreview(-1)
+
+	case tea.KeyRight, tea.KeyDown, tea.KeyTab:
+		m.CycleThemePreview(1)
+	}
+
+	return m, nil
+}
+
+// renderThemePreview renders the theme preview bar in place of the history list
+func (m Model) renderThemePreview() string {
+	return fmt.Sprintf("Theme preview: %s (%d/%d)\n←/→ cycle, Enter apply, Esc cancel\n",
+		m.PreviewedTheme(), m.preview.selected+1, len(m.preview.themes))
+}
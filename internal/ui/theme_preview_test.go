@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"ccpm-demo/internal/calculator"
+)
+
+// previewNextTheme opens the theme preview and moves to the next theme
+func previewNextTheme(t *testing.T, m Model) Model {
+	t.Helper()
+	m = typeKeys(m, "t")
+	if !m.IsThemePreviewActive() {
+		t.Fatal("Expected 't' to open the theme preview")
+	}
+	m = sendKeys(m, tea.KeyMsg{Type: tea.KeyRight})
+	if m.PreviewedTheme() == "" || m.GetButtonGrid().GetCurrentTheme() != m.PreviewedTheme() {
+		t.Fatalf("Expected the grid to be drawn in the previewed theme '%s'", m.PreviewedTheme())
+	}
+	return m
+}
+
+func TestThemePreviewCancel(t *testing.T) {
+	model := NewModel(calculator.NewEngine())
+	original := model.GetButtonGrid().GetCurrentTheme()
+
+	model = previewNextTheme(t, model)
+	if model.PreviewedTheme() == original {
+		t.Fatal("Expected cycling to preview a different theme")
+	}
+	if !strings.Contains(model.View(), "Theme preview: "+model.PreviewedTheme()) {
+		t.Error("Expected the view to show the theme preview")
+	}
+
+	model = sendKeys(model, tea.KeyMsg{Type: tea.KeyEsc})
+	if model.IsThemePreviewActive() || model.quitting {
+		t.Error("Expected Esc to close the preview without quitting")
+	}
+	if model.GetButtonGrid().GetCurrentTheme() != original {
+		t.Errorf("Expected the theme to revert to '%s', got '%s'", original, model.GetButtonGrid().GetCurrentTheme())
+	}
+}
+
+func TestThemePreviewCommit(t *testing.T) {
+	model := previewNextTheme(t, NewModel(calculator.NewEngine()))
+	previewed := model.PreviewedTheme()
+
+	model = sendKeys(model, tea.KeyMsg{Type: tea.KeyEnter})
+	if model.IsThemePreviewActive() {
+		t.Error("Expected Enter to close the preview")
+	}
+	if model.GetButtonGrid().GetCurrentTheme() != previewed {
+		t.Errorf("Expected '%s' to be applied, got '%s'", previewed, model.GetButtonGrid().GetCurrentTheme())
+	}
+	if model.GetInput() != "" {
+		t.Errorf("Expected Enter not to reach the calculator, got input '%s'", model.GetInput())
+	}
+}
+
+func TestThemePreviewCyclesAllThemes(t *testing.T) {
+	model := typeKeys(NewModel(calculator.NewEngine()), "t")
+	start := model.PreviewedTheme()
+
+	seen := map[string]bool{}
+	for i := 0; i < len(model.GetButtonGrid().ListThemes()); i++ {
+		seen[model.PreviewedTheme()] = true
+		model = sendKeys(model, tea.KeyMsg{Type: tea.KeyRight})
+	}
+	if len(seen) != len(model.GetButtonGrid().ListThemes()) || model.PreviewedTheme() != start {
+		t.Errorf("Expected to cycle through every theme back to '%s', saw %v", start, seen)
+	}
+}
@@ -0,0 +1,56 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"ccpm-demo/internal/calculator"
+)
+
+// displayLines returns the non-blank lines of the rendered display
+func displayLines(m Model) []string {
+	var lines []string
+	for _, line := range strings.Split(plainRendering(m.renderDisplay(m.updateStyles())), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+func TestTwoLineDisplay(t *testing.T) {
+	updated, _ := NewModel(calculator.NewEngine()).Update(tea.WindowSizeMsg{Width: 80, Height: 40})
+	model := typeKeys(updated.(Model), "12*3=")
+
+	if model.IsTwoLineDisplay() {
+		t.Error("Expected the single-line display by default")
+	}
+	if got := displayLines(model); len(got) != 1 || got[0] != "36" {
+		t.Errorf("Expected the single-line display to show only '36', got %q", got)
+	}
+
+	model.SetTwoLineDisplay(true)
+	if got := displayLines(model); len(got) != 2 || got[0] != "12 * 3" || got[1] != "36" {
+		t.Errorf("Expected the expression above the result, got %q", got)
+	}
+	model.SetNoColor(true)
+	viewLines := strings.Split(model.View(), "\n")
+	found := false
+	for i := 0; i+1 < len(viewLines); i++ {
+		if strings.Contains(viewLines[i], "12 * 3") && !strings.Contains(viewLines[i], "=") && strings.Contains(viewLines[i+1], "36") {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected the view to show the expression on its own line above the result")
+	}
+	model.SetNoColor(false)
+
+	// A new expression replaces the top line while the last result stays below
+	model = typeKeys(model, "7+")
+	if got := displayLines(model); len(got) != 2 || got[0] != "7 +" || got[1] != "36" {
+		t.Errorf("Expected the new expression above the last result, got %q", got)
+	}
+}
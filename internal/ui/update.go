@@ -4,15 +4,75 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	uiintegration "ccpm-demo/internal/ui/integration"
 )
 
-// update handles all incoming messages and updates the model state
+// ClearHistoryMsg requests that the calculation history be emptied
+type ClearHistoryMsg struct{}
+
+// RPNStackChangedMsg reports that the RPN stack may have changed, so the
+// stack view is brought up to date with it
+type RPNStackChangedMsg struct{}
+
+// update handles all incoming messages and updates the model state, keeping
+// the button bounds in step with the layout
 func update(m Model, msg tea.Msg) (tea.Model, tea.Cmd) {
+	updated, cmd := dispatch(m, msg)
+	if um, ok := updated.(Model); ok {
+		// Only a change to the input moves the focus, so navigating away sticks
+		if um.autoFocusEquals && um.input != m.input && um.operandComplete() {
+			um.buttonGrid.FocusValue("=")
+		}
+		// The "expr = result" line stays until something else changes the input
+		if um.equation == m.equation && (um.input != m.input || um.calculatorState.displayValue != m.calculatorState.displayValue) {
+			um.equation = ""
+		}
+		// An entry hint lasts until the input changes
+		if um.entryHint == m.entryHint && um.input != m.input {
+			um.entryHint = ""
+		}
+		um.applyDisablePolicy()
+		um.syncStackView()
+		um.refreshButtonBounds()
+		return um, cmd
+	}
+	return updated, cmd
+}
+
+// dispatch routes a message to its handler
+func dispatch(m Model, msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case ClearHistoryMsg:
+		m.ClearHistory()
+		return m, nil
+
+	case RPNStackChangedMsg:
+		return m, nil
+
+	case ScrollHistoryMsg:
+		m.ScrollHistory(msg.Delta)
+		return m, nil
+
+	case clipboardWriteMsg:
+		m.setError(msg.err)
+		return m, nil
+
+	case digitRepeatMsg:
+		return handleDigitRepeatMsg(m, msg)
+
+	case spinnerTickMsg:
+		return handleSpinnerTick(m, msg)
+
+	case evaluationDoneMsg:
+		return handleEvaluationDone(m, msg)
+
 	case tea.KeyMsg:
+		if m.keyRemapper != nil {
+			msg = m.keyRemapper.RemapKey(msg)
+		}
 		return handleKeyMsg(m, msg)
 
 	case tea.MouseMsg:
@@ -31,8 +91,44 @@ func update(m Model, msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // handleKeyMsg processes keyboard input
 func handleKeyMsg(m Model, msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.keyPresses++
+
 	// Clear any existing errors
-	m.clearError()
+	m.clearErrorBefore(msg.String())
+
+	// New input abandons an evaluation still in flight
+	m.cancelEvaluation()
+
+	// Any key other than a repeated clear-history key cancels a pending confirmation
+	if m.pendingClearHistory && msg.String() != "X" {
+		m.pendingClearHistory = false
+	}
+
+	// The history search overlay captures all keys while open
+	if m.search.active {
+		return handleHistorySearchKey(m, msg)
+	}
+
+	// So does the recent results palette
+	if m.palette.active {
+		return handleResultsPaletteKey(m, msg)
+	}
+
+	// And the theme preview
+	if m.preview.active {
+		return handleThemePreviewKey(m, msg)
+	}
+
+	// Toggle mouse support before the button grid can claim the key
+	if m.mouseToggleKey != "" && msg.String() == m.mouseToggleKey {
+		return m, m.ToggleMouse()
+	}
+
+	// "/" opens the history search when there is no operand to divide
+	if msg.String() == historySearchKey && m.input == "" && len(m.history) > 0 {
+		m.OpenHistorySearch()
+		return m, nil
+	}
 
 	// First, handle special keys that should always work
 	switch msg.Type {
@@ -90,30 +186,74 @@ func handleKeyMsg(m Model, msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // handleMouseMsg processes mouse events
 func handleMouseMsg(m Model, msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	// Events still in flight after the mouse was switched off are dropped
+	if m.mouseDisabled {
+		return m, nil
+	}
+
 	switch msg.Type {
 	case tea.MouseLeft:
 		// Handle button grid clicks first
 		if action := m.buttonGrid.HandleMouse(msg); action != nil {
-			return handleButtonGridAction(m, action)
+			return handleButtonGridPress(m, action)
 		}
 		return handleMouseClick(m, msg)
 
-	case tea.MouseWheelUp:
-		return handleMouseWheelUp(m)
+	case tea.MouseRelease:
+		m.stopDigitRepeat()
+		return m, nil
 
-	case tea.MouseWheelDown:
+	case tea.MouseWheelUp, tea.MouseWheelDown:
+		// Over the history panel the wheel scrolls it instead of the input
+		if scrolled, ok := handleHistoryPanelWheel(m, msg); ok {
+			return scrolled, nil
+		}
+
+		// Recalling history replaces the input, so it abandons an evaluation in flight
+		m.cancelEvaluation()
+		if msg.Type == tea.MouseWheelUp {
+			return handleMouseWheelUp(m)
+		}
 		return handleMouseWheelDown(m)
 
+	case tea.MouseMotion:
+		return handleMouseMotion(m, msg)
+
 	default:
 		return m, nil
 	}
 }
 
+// handleButtonGridPress activates a clicked button and, for number buttons,
+// starts repeating it while the button is held
+func handleButtonGridPress(m Model, action *uiintegration.ButtonAction) (tea.Model, tea.Cmd) {
+	updated, cmd := handleButtonGridAction(m, action)
+	um, ok := updated.(Model)
+	if !ok {
+		return updated, cmd
+	}
+
+	repeat := um.startDigitRepeat(action.Value)
+	return um, tea.Batch(cmd, repeat)
+}
+
+// handleMouseMotion updates which button is hovered
+func handleMouseMotion(m Model, msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if m.hover != nil {
+		m.hover.ProcessHoverEvent(msg, time.Now().UnixNano())
+	}
+	return m, nil
+}
+
 // handleWindowSizeMsg handles terminal resize events
 func handleWindowSizeMsg(m Model, msg tea.WindowSizeMsg) (tea.Model, tea.Cmd) {
 	m.width = msg.Width
 	m.height = msg.Height
 	m.ready = true
+
+	// Re-register button bounds so clicks follow the new layout
+	m.syncButtonBounds()
+
 	return m, nil
 }
 
@@ -125,12 +265,22 @@ func handleQuitMsg(m Model) (tea.Model, tea.Cmd) {
 
 // handleEnterKey processes Enter key press
 func handleEnterKey(m Model) (tea.Model, tea.Cmd) {
-	if m.input == "" {
+	if m.input == "" || m.busy.active {
 		return m, nil
 	}
 
+	// Long evaluations can run in the background behind a spinner
+	if m.busy.async {
+		return m, m.startEvaluation(m.input)
+	}
+
 	// Try to evaluate the input expression
 	result, err := m.engine.Evaluate(m.input)
+	return finishEvaluation(m, m.input, result, err)
+}
+
+// finishEvaluation shows the outcome of evaluating expression
+func finishEvaluation(m Model, expression string, result float64, err error) (tea.Model, tea.Cmd) {
 	if err != nil {
 		m.setError(err)
 		// Handle error audio feedback
@@ -140,20 +290,27 @@ func handleEnterKey(m Model) (tea.Model, tea.Cmd) {
 
 	// Update output and history
 	m.output = m.formatValue(result)
-	m.addToHistory(fmt.Sprintf("%s = %s", m.input, m.output))
+	m.addToHistory(fmt.Sprintf("%s = %s", expression, m.output))
 
 	// Handle success audio feedback
 	m.HandleCalculationAudio(m.output, false)
 
-	// Reset input
+	// Optionally copy the result straight to the clipboard
+	var cmd tea.Cmd
+	if m.copyResultOnEquals {
+		cmd = m.copyToClipboard(m.output)
+	}
+
+	// Reset input, then fill it as the equals mode asks
 	m.input = ""
 	m.cursorPosition = 0
+	m.applyEqualsMode(expression)
 
 	// Update calculator state
 	m.calculatorState.displayValue = m.output
 	m.calculatorState.isWaitingForOperand = true
 
-	return m, nil
+	return m, cmd
 }
 
 // handleBackspaceKey processes Backspace key
@@ -234,20 +391,99 @@ func handleRunes(m Model, msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case "c":
-		// Clear input
+		// Clear input, and more in ClearAll mode
 		m.input = ""
 		m.cursorPosition = 0
-		m.calculatorState.displayValue = "0"
+		m.calculatorState.displayValue = clearedDisplayValue
+		m.clearAll()
+		return m, nil
+
+	case "y":
+		// Copy the full current result
+		return m, m.copyToClipboard(m.CopyResult())
+
+	case "Y":
+		// Copy the whole calculation history
+		if len(m.history) > 0 {
+			return m, m.copyToClipboard(m.HistoryText())
+		}
+		return m, nil
+
+	case "X":
+		// Clear the calculation history, asking for confirmation if enabled
+		if m.confirmClearHistory && !m.pendingClearHistory {
+			m.pendingClearHistory = true
+			return m, nil
+		}
+		m.ClearHistory()
+		return m, nil
+
+	case "U":
+		// Take back the last "=", restoring its expression to the input line
+		m.UndoLastCommit()
+		return m, nil
+
+	case "n":
+		// Toggle the sign of the number being entered
+		m.NegateOperand()
+		return m, nil
+
+	case "i":
+		// Invert the number being entered, or the last result
+		m.ApplyReciprocal()
+		return m, nil
+
+	case "!":
+		// Take the factorial of the number being entered, or the last result
+		m.ApplyFactorial()
+		return m, nil
+
+	case "(", ")":
+		// Insert parentheses at the cursor
+		m.insertAtCursor(char)
+		return m, nil
+
+	case "r":
+		// Open the recent results palette
+		m.OpenResultsPalette()
+		return m, nil
+
+	case themePreviewKey:
+		// Preview the themes without committing to one
+		m.OpenThemePreview()
+		return m, nil
+
+	case "a":
+		// Switch the display between right and left alignment
+		m.ToggleDisplayAlignment()
+		return m, nil
+
+	case "A":
+		// Insert the last result at the cursor
+		m.InsertLastAnswer()
+		return m, nil
+
+	case "m":
+		// Jump to the bracket matching the one at the cursor
+		m.JumpToMatchingBracket()
 		return m, nil
 
 	case "+", "-", "*", "/":
-		// Handle operators
+		// Handle operators; a minus straight after EXP is the exponent's sign
+		if m.appendExponentSign(char) {
+			return m, nil
+		}
 		if m.input != "" {
 			m.input += " " + char + " "
 			m.cursorPosition = len(m.input)
 		}
 		return m, nil
 
+	case "E":
+		// Start a scientific exponent on the current number
+		m.ApplyExponent()
+		return m, nil
+
 	case "×":
 		// Handle multiplication symbol
 		if m.input != "" {
@@ -277,7 +513,7 @@ func handleRunes(m Model, msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			// Check if last character is a digit
 			if len(m.input) > 0 {
 				lastChar := m.input[len(m.input)-1]
-				if lastChar >= '0' && lastChar <= '9' {
+				if lastChar >= '0' && lastChar <= '9' && !inExponent(m.input) {
 					m.input += "."
 					m.cursorPosition++
 				}
@@ -288,8 +524,7 @@ func handleRunes(m Model, msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	default:
 		// Handle numbers and other valid characters
 		if char >= "0" && char <= "9" {
-			m.input += char
-			m.cursorPosition++
+			m.appendDigit(char)
 		} else if char == " " {
 			// Allow spaces for formatting
 			m.input += char
@@ -322,12 +557,11 @@ func handleMouseWheelDown(m Model) (tea.Model, tea.Cmd) {
 
 // handleCalculatorButton processes calculator button clicks
 func handleCalculatorButton(m Model, button string) (tea.Model, tea.Cmd) {
-	m.clearError()
+	m.clearErrorBefore(button)
 
 	switch button {
 	case "0", "1", "2", "3", "4", "5", "6", "7", "8", "9":
-		m.input += button
-		m.cursorPosition++
+		m.appendDigit(button)
 
 	case ".":
 		if m.input == "" {
@@ -337,7 +571,7 @@ func handleCalculatorButton(m Model, button string) (tea.Model, tea.Cmd) {
 			// Check if last character is a digit
 			if len(m.input) > 0 {
 				lastChar := m.input[len(m.input)-1]
-				if lastChar >= '0' && lastChar <= '9' {
+				if lastChar >= '0' && lastChar <= '9' && !inExponent(m.input) {
 					m.input += "."
 					m.cursorPosition++
 				}
@@ -345,6 +579,9 @@ func handleCalculatorButton(m Model, button string) (tea.Model, tea.Cmd) {
 		}
 
 	case "+", "-", "*", "/":
+		if m.appendExponentSign(button) {
+			break
+		}
 		if m.input != "" {
 			m.input += " " + button + " "
 			m.cursorPosition = len(m.input)
@@ -368,16 +605,11 @@ func handleCalculatorButton(m Model, button string) (tea.Model, tea.Cmd) {
 	case "C":
 		m.input = ""
 		m.cursorPosition = 0
-		m.calculatorState.displayValue = "0"
+		m.calculatorState.displayValue = clearedDisplayValue
+		m.clearAll()
 
 	case "±":
-		if m.input != "" {
-			// Try to parse as number and negate
-			if num, err := strconv.ParseFloat(m.input, 64); err == nil {
-				m.input = fmt.Sprintf("%g", -num)
-				m.cursorPosition = len(m.input)
-			}
-		}
+		m.NegateOperand()
 
 	case "%":
 		if m.input != "" {
@@ -397,10 +629,15 @@ func handleCalculatorButton(m Model, button string) (tea.Model, tea.Cmd) {
 
 // handleButtonGridAction processes actions from the button grid
 func handleButtonGridAction(m Model, action *uiintegration.ButtonAction) (tea.Model, tea.Cmd) {
-	m.clearError()
+	m.clearErrorBefore(action.Value)
+
+	// A button, clicked or activated from the keyboard, is new input too and
+	// abandons an evaluation still in flight
+	m.cancelEvaluation()
 
 	// Handle audio feedback for button press
 	m.HandleButtonAudio(action)
+	m.announceButton(action.Value)
 
 	// Process the button action based on its value
 	switch action.Value {
@@ -409,29 +646,65 @@ func handleButtonGridAction(m Model, action *uiintegration.ButtonAction) (tea.Mo
 		m.input = ""
 		m.output = ""
 		m.cursorPosition = 0
-		m.calculatorState.displayValue = "0"
+		m.calculatorState.displayValue = clearedDisplayValue
 		m.calculatorState.operator = ""
 		m.calculatorState.previousValue = 0
 		m.calculatorState.isWaitingForOperand = false
+		m.clearAll()
 		m.HandleClearAudio("clear")
 
 	case "clear_entry":
 		// Clear current input only
 		m.input = ""
 		m.cursorPosition = 0
-		m.calculatorState.displayValue = "0"
+		m.calculatorState.displayValue = clearedDisplayValue
 		m.HandleClearAudio("clear_entry")
 
 	case "backspace":
 		return handleBackspaceKey(m)
 
+	case "negate":
+		m.NegateOperand()
+
+	case "reciprocal":
+		m.ApplyReciprocal()
+
+	case "square":
+		m.ApplyPower("2")
+
+	case "cube":
+		m.ApplyPower("3")
+
+	case "ans":
+		m.InsertLastAnswer()
+
+	case "(", ")", ",":
+		// Scientific row: brackets and the argument separator go in at the cursor
+		m.insertAtCursor(action.Value)
+
+	case "root":
+		m.insertAtCursor("root(")
+
 	case "+", "-", "*", "/":
-		// Handle operators
+		// Handle operators; a minus straight after EXP is the exponent's sign
+		if m.appendExponentSign(action.Value) {
+			break
+		}
 		if m.input != "" {
 			m.input += " " + action.Value + " "
 			m.cursorPosition = len(m.input)
 		}
 
+	case "exp":
+		m.ApplyExponent()
+
+	case "factorial":
+		m.ApplyFactorial()
+
+	case "power":
+		// The exponent is typed next, as in 2^10
+		m.ApplyPower("")
+
 	case "=":
 		return handleEnterKey(m)
 
@@ -444,7 +717,7 @@ func handleButtonGridAction(m Model, action *uiintegration.ButtonAction) (tea.Mo
 			// Check if last character is a digit
 			if len(m.input) > 0 {
 				lastChar := m.input[len(m.input)-1]
-				if lastChar >= '0' && lastChar <= '9' {
+				if lastChar >= '0' && lastChar <= '9' && !inExponent(m.input) {
 					m.input += "."
 					m.cursorPosition++
 				}
@@ -454,8 +727,7 @@ func handleButtonGridAction(m Model, action *uiintegration.ButtonAction) (tea.Mo
 	default:
 		// Handle numbers (0-9)
 		if len(action.Value) == 1 && action.Value >= "0" && action.Value <= "9" {
-			m.input += action.Value
-			m.cursorPosition++
+			m.appendDigit(action.Value)
 
 			// Update calculator state display
 			m.calculatorState.displayValue = m.input
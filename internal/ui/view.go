@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
@@ -18,27 +19,97 @@ func view(m Model) string {
 	// Build the main layout
 	content := strings.Builder{}
 
+	content.WriteString(m.renderHeader(styles))
+
+	// Button layout using ButtonGrid
+	m.buttonGrid.RenderTo(&content, m.width)
+
+	// History search, results palette or theme preview overlay, or the
+	// history itself (if any)
+	if m.preview.active {
+		content.WriteString("\n")
+		content.WriteString(m.renderThemePreview())
+	} else if m.search.active {
+		content.WriteString("\n")
+		content.WriteString(m.renderHistorySearch(styles))
+	} else if m.palette.active {
+		content.WriteString("\n")
+		content.WriteString(m.renderResultsPalette())
+	} else if len(m.history) > 0 {
+		content.WriteString("\n")
+		content.WriteString(m.renderHistory(styles))
+	}
+
+	// Status bar
+	if status := m.statusText(); status != "" {
+		content.WriteString("\n")
+		content.WriteString(styles.input.Faint(true).Render(status))
+	}
+
+	// Key legend footer
+	if legend := m.keyLegendText(); legend != "" {
+		content.WriteString("\n")
+		content.WriteString(styles.input.Faint(true).Render(legend))
+	}
+
+	// Wrap everything in the main container
+	return styles.app.Render(content.String())
+}
+
+// statusText returns the status bar text, empty when there is nothing to report
+func (m Model) statusText() string {
+	var parts []string
+	if m.entryHint != "" {
+		parts = append(parts, m.entryHint)
+	}
+	if m.mouseDisabled && m.mouseToggleKey != "" {
+		parts = append(parts, fmt.Sprintf(mouseOffStatus, m.mouseToggleKey))
+	} else if m.mouseDisabled {
+		parts = append(parts, "Mouse off")
+	}
+	if counter := m.keyCounterText(); counter != "" {
+		parts = append(parts, counter)
+	}
+	return strings.Join(parts, " | ")
+}
+
+// renderHeader renders everything above the button grid
+func (m Model) renderHeader(styles styles) string {
+	content := strings.Builder{}
+
 	// Title
 	content.WriteString(styles.title.Render("CCPM Calculator"))
 	content.WriteString("\n\n")
 
 	// Display area (current calculator state)
-	content.WriteString(styles.display.Render(m.calculatorState.displayValue))
+	resultWidth := styles.display.GetWidth() - styles.display.GetHorizontalPadding()
+	content.WriteString(m.renderDisplay(styles))
 	content.WriteString("\n")
 
+	// Latest history entries scroll above the input line
+	for _, entry := range m.DisplayHistoryRows() {
+		content.WriteString(styles.input.Faint(true).Render(m.truncateResult(m.historyFormat.format(entry), resultWidth)))
+		content.WriteString("\n")
+	}
+
+	// RPN stack, top value closest to the input line
+	if m.rpn != nil && m.rpn.IsRPNMode() {
+		if stack := m.stackView.Render(); stack != "" {
+			content.WriteString(stack)
+			content.WriteString("\n")
+		}
+	}
+
 	// Input area
-	inputText := m.input
-	if m.cursorPosition >= 0 && m.cursorPosition < len(m.input) {
-		// Show cursor position
-		before := m.input[:m.cursorPosition]
-		after := m.input[m.cursorPosition:]
-		inputText = before + "█" + after
-	}
-	content.WriteString(styles.input.Render(inputText))
+	content.WriteString(styles.input.Render(m.inputLineText()))
 	content.WriteString("\n")
 
-	// Output area (results)
-	content.WriteString(styles.output.Render(m.output))
+	// Output area (results), or the spinner while a result is on its way
+	if m.busy.active {
+		content.WriteString(styles.output.Render(m.renderSpinner()))
+	} else {
+		content.WriteString(styles.output.Render(m.truncateResult(m.output, resultWidth)))
+	}
 	content.WriteString("\n")
 
 	// Error area
@@ -47,18 +118,25 @@ func view(m Model) string {
 		content.WriteString("\n")
 	}
 
-	// Button layout using ButtonGrid
-	content.WriteString("\n")
-	content.WriteString(m.buttonGrid.Render(m.width))
-
-	// History (if any)
-	if len(m.history) > 0 {
+	// Clear history confirmation prompt
+	if m.pendingClearHistory {
+		content.WriteString(styles.error.Render("Press X again to clear history"))
 		content.WriteString("\n")
-		content.WriteString(m.renderHistory(styles))
 	}
 
-	// Wrap everything in the main container
-	return styles.app.Render(content.String())
+	content.WriteString("\n")
+
+	return content.String()
+}
+
+// renderDisplay renders the current display value using the display style
+func (m Model) renderDisplay(styles styles) string {
+	width := styles.display.GetWidth() - styles.display.GetHorizontalPadding()
+	if m.twoLineDisplay {
+		expression, result := m.DisplayLines()
+		return styles.display.Render(m.truncateResult(expression, width) + "\n" + m.truncateResult(result, width))
+	}
+	return styles.display.Render(m.truncateResult(m.DisplayText(), width))
 }
 
 // renderButtons creates the calculator button layout
@@ -104,18 +182,14 @@ func (m Model) renderHistory(styles styles) string {
 	history := strings.Builder{}
 	history.WriteString("History:\n")
 
-	// Show last 5 entries
-	start := 0
-	if len(m.history) > 5 {
-		start = len(m.history) - 5
-	}
-
-	for i := start; i < len(m.history); i++ {
+	// Show the entries in the panel's scroll window
+	start, end := m.historyWindow()
+	for i := start; i < end; i++ {
 		prefix := "  "
 		if i == m.historyIndex {
 			prefix = "→ "
 		}
-		history.WriteString(prefix + m.history[i] + "\n")
+		history.WriteString(prefix + m.historyFormat.format(m.history[i]) + "\n")
 	}
 
 	return history.String()
@@ -143,7 +217,7 @@ func (m Model) updateStyles() styles {
 
 	// Update styles with new dimensions
 	styles.app = styles.app.Width(appWidth).Height(appHeight)
-	styles.display = styles.display.Width(appWidth - 4)
+	styles.display = styles.display.Width(appWidth - 4).Align(m.displayAlignment.position())
 	styles.input = styles.input.Width(appWidth - 4)
 	styles.output = styles.output.Width(appWidth - 4)
 	styles.error = styles.error.Width(appWidth - 4)
@@ -197,9 +271,15 @@ func (m Model) renderHelpScreen() string {
 	helpContent := `Calculator:
   0-9, .  - Numbers and decimal point
   +, -, ×, ÷ - Basic operations
+  (, )     - Parentheses
+  ,        - Separate function arguments (scientific mode)
   =        - Calculate result
   C        - Clear
-  ±        - Toggle sign
+  ±, n     - Toggle sign of the current number
+  1/x, i   - Reciprocal of the current number
+  x², x³   - Square or cube the current number
+  ANS, A   - Insert the last result
+  EXP, E   - Exponent (1.2 E 3 = 1200)
   %        - Percentage
   ⌫        - Backspace
 
@@ -207,6 +287,16 @@ Navigation:
   q, Esc   - Quit
   h        - Toggle help
   ↑, ↓     - Navigate history
+  /        - Search history (when input is empty)
+  r        - Insert a recent result
+  t        - Preview themes
+  m        - Jump to matching bracket
+  a        - Toggle display alignment
+  y        - Copy result
+  Y        - Copy history
+  X        - Clear history
+  U        - Undo last result
+  M        - Toggle mouse support
   Enter    - Execute calculation
 
 Mouse:
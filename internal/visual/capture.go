@@ -9,6 +9,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/charmbracelet/lipgloss"
 	"golang.org/x/image/font"
 	"golang.org/x/image/font/basicfont"
 	"golang.org/x/image/math/fixed"
@@ -22,6 +23,9 @@ type TerminalConfig struct {
 	FontSize   int
 	Foreground color.Color
 	Background color.Color
+
+	// CellWidth and CellHeight are the size in pixels of one terminal cell
+	// and so set the capture resolution
 	CellWidth  int
 	CellHeight int
 }
@@ -58,8 +62,18 @@ func NewDefaultConfig() TerminalConfig {
 	}
 }
 
-// CaptureTerminal captures a terminal screenshot from text content
+// CaptureTerminal captures a terminal screenshot from text content. The image
+// is Width*CellWidth pixels wide and Height*CellHeight pixels high; cell sizes
+// left at zero use the default 7x13 cell.
 func CaptureTerminal(content string, config TerminalConfig) (*Screenshot, error) {
+	defaults := NewDefaultConfig()
+	if config.CellWidth <= 0 {
+		config.CellWidth = defaults.CellWidth
+	}
+	if config.CellHeight <= 0 {
+		config.CellHeight = defaults.CellHeight
+	}
+
 	// Create image with appropriate dimensions
 	imgWidth := config.Width * config.CellWidth
 	imgHeight := config.Height * config.CellHeight
@@ -132,21 +146,53 @@ func stripANSICodes(text string) string {
 	return result
 }
 
-// renderLine renders a single line of text to the image
+// renderLine renders a single line of text to the image, one character per
+// terminal cell, so larger cells spread the text out with the image
 func renderLine(img *image.RGBA, line string, lineNum int, config TerminalConfig) {
 	drawer := &font.Drawer{
 		Dst:  img,
 		Src:  image.NewUniform(config.Foreground),
 		Face: config.FontFace,
-		Dot:  fixed.P(0, (lineNum+1)*config.CellHeight-2),
 	}
 
-	// Handle text overflow
-	if len(line) > config.Width {
-		line = line[:config.Width]
+	col := 0
+	for _, char := range line {
+		// Handle text overflow
+		if col >= config.Width {
+			break
+		}
+
+		drawer.Dot = fixed.P(col*config.CellWidth, (lineNum+1)*config.CellHeight-2)
+		drawer.DrawString(string(char))
+		col++
 	}
+}
 
-	drawer.DrawString(line)
+// Annotate draws text on a solid band over the first or last terminal row
+func (s *Screenshot) Annotate(text string, caption CaptionConfig) {
+	row := s.Config.Height - 1
+	if caption.Position == CaptionTop {
+		row = 0
+	}
+
+	// Unset colors fall back to the terminal colors
+	captionConfig := s.Config
+	if caption.Foreground != nil {
+		captionConfig.Foreground = caption.Foreground
+	}
+	if caption.Background != nil {
+		captionConfig.Background = caption.Background
+	}
+
+	bounds := s.Image.Bounds()
+	top := row * s.Config.CellHeight
+	for y := top; y < top+s.Config.CellHeight && y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			s.Image.Set(x, y, captionConfig.Background)
+		}
+	}
+
+	renderLine(s.Image, text, row, captionConfig)
 }
 
 // Save saves the screenshot to a file
@@ -215,6 +261,16 @@ func NewScreenshotFromModel(model interface{}, config TerminalConfig) (*Screensh
 		return nil, fmt.Errorf("model does not implement View() method")
 	}
 
+	// Fill the background with the active theme's color rather than the
+	// capture default, so screenshots match what the theme looks like
+	if themed, ok := model.(interface{ GetThemeBackground() lipgloss.Color }); ok {
+		background, err := TerminalColor(themed.GetThemeBackground())
+		if err != nil {
+			return nil, fmt.Errorf("theme background: %w", err)
+		}
+		config.Background = background
+	}
+
 	return CaptureWithStyling(view, config)
 }
 
@@ -0,0 +1,66 @@
+package visual
+
+import (
+	"image"
+	"image/draw"
+)
+
+// CaptureBeforeAfter captures two model states and stacks them vertically,
+// before on top of after, with a divider row showing label between them
+func CaptureBeforeAfter(before, after interface{}, label string, config TerminalConfig) (*Screenshot, error) {
+	top, err := NewScreenshotFromModel(before, config)
+	if err != nil {
+		return nil, err
+	}
+	bottom, err := NewScreenshotFromModel(after, config)
+	if err != nil {
+		return nil, err
+	}
+	return ComposeVertical(top, bottom, label), nil
+}
+
+// ComposeVertical stacks top over bottom with a one-row divider showing label
+// between them. The divider uses the caption colors and the top screenshot's
+// cell size; the composite is as wide as the wider screenshot.
+func ComposeVertical(top, bottom *Screenshot, label string) *Screenshot {
+	topBounds := top.Image.Bounds()
+	bottomBounds := bottom.Image.Bounds()
+
+	width := topBounds.Dx()
+	if bottomBounds.Dx() > width {
+		width = bottomBounds.Dx()
+	}
+	dividerHeight := top.Config.CellHeight
+	height := topBounds.Dy() + dividerHeight + bottomBounds.Dy()
+
+	config := top.Config
+	config.Width = width / config.CellWidth
+	config.Height = top.Config.Height + 1 + bottom.Config.Height
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(top.Config.Background), image.Point{}, draw.Src)
+	draw.Draw(img, image.Rect(0, 0, topBounds.Dx(), topBounds.Dy()), top.Image, topBounds.Min, draw.Src)
+
+	// Divider band with the label, drawn like a caption over its own row
+	caption := NewDefaultCaptionConfig()
+	dividerConfig := config
+	dividerConfig.Foreground = caption.Foreground
+	dividerConfig.Background = caption.Background
+	divider := image.NewRGBA(image.Rect(0, 0, width, dividerHeight))
+	draw.Draw(divider, divider.Bounds(), image.NewUniform(caption.Background), image.Point{}, draw.Src)
+	renderLine(divider, label, 0, dividerConfig)
+	draw.Draw(img, image.Rect(0, topBounds.Dy(), width, topBounds.Dy()+dividerHeight), divider, image.Point{}, draw.Src)
+
+	bottomTop := topBounds.Dy() + dividerHeight
+	draw.Draw(img, image.Rect(0, bottomTop, bottomBounds.Dx(), bottomTop+bottomBounds.Dy()), bottom.Image, bottomBounds.Min, draw.Src)
+
+	metadata := top.Metadata
+	metadata.Width = config.Width
+	metadata.Height = config.Height
+
+	return &Screenshot{
+		Image:    img,
+		Config:   config,
+		Metadata: metadata,
+	}
+}
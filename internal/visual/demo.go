@@ -3,6 +3,7 @@ package visual
 import (
 	"encoding/json"
 	"fmt"
+	"image/color"
 	"os"
 	"path/filepath"
 	"strings"
@@ -19,6 +20,7 @@ type DemoAction struct {
 	KeyPress    tea.KeyMsg   `json:"keyPress,omitempty"`
 	MouseClick  MouseClick   `json:"mouseClick,omitempty"`
 	Screenshot  *Screenshot  `json:"screenshot,omitempty"`
+	Content     string       `json:"content,omitempty"`
 }
 
 // MouseClick represents a mouse click action
@@ -33,9 +35,41 @@ type DemoSequence struct {
 	Description string        `json:"description"`
 	Actions     []DemoAction  `json:"actions"`
 	Duration    time.Duration `json:"duration"`
+	FrameDelay  time.Duration `json:"frameDelay"`
 	CreatedAt   time.Time     `json:"createdAt"`
 }
 
+// defaultFrameDelay is how long each captured frame is shown on playback
+const defaultFrameDelay = time.Second
+
+// CaptionPosition controls where a caption is drawn on a frame
+type CaptionPosition int
+
+const (
+	// CaptionBottom draws the caption over the last terminal row
+	CaptionBottom CaptionPosition = iota
+	// CaptionTop draws the caption over the first terminal row
+	CaptionTop
+)
+
+// CaptionConfig configures the caption overlay burned into demo frames
+type CaptionConfig struct {
+	Enabled    bool
+	Position   CaptionPosition
+	Foreground color.Color
+	Background color.Color
+}
+
+// NewDefaultCaptionConfig creates an enabled caption configuration
+func NewDefaultCaptionConfig() CaptionConfig {
+	return CaptionConfig{
+		Enabled:    true,
+		Position:   CaptionBottom,
+		Foreground: color.Black,
+		Background: color.RGBA{255, 215, 0, 255},
+	}
+}
+
 // DemoGenerator generates automated demos for calculator operations
 type DemoGenerator struct {
 	Model         interface{}
@@ -44,15 +78,24 @@ type DemoGenerator struct {
 	CurrentFrame  int
 	Recording     bool
 	Sequence      *DemoSequence
+	Captions      CaptionConfig
+	FrameDelay    time.Duration
+
+	// ReplayTolerance is the diff ratio a replayed frame may differ by
+	ReplayTolerance float64
+
+	// AutoCapture captures a frame after every key press, described by the key
+	AutoCapture bool
 }
 
 // NewDemoGenerator creates a new demo generator
 func NewDemoGenerator(model interface{}, config TerminalConfig, outputDir string) *DemoGenerator {
 	return &DemoGenerator{
-		Model:     model,
-		Config:    config,
-		OutputDir: outputDir,
-		Recording: false,
+		Model:      model,
+		Config:     config,
+		OutputDir:  outputDir,
+		Recording:  false,
+		FrameDelay: defaultFrameDelay,
 	}
 }
 
@@ -66,6 +109,7 @@ func (dg *DemoGenerator) StartRecording(name, description string) error {
 		Name:        name,
 		Description: description,
 		Actions:     []DemoAction{},
+		FrameDelay:  dg.FrameDelay,
 		CreatedAt:   time.Now(),
 	}
 	dg.Recording = true
@@ -92,8 +136,15 @@ func (dg *DemoGenerator) StopRecording() error {
 	return dg.saveDemoMetadata()
 }
 
-// CaptureFrame captures a frame with optional description
+// CaptureFrame captures a frame with optional description, shown for the
+// generator's frame delay on playback
 func (dg *DemoGenerator) CaptureFrame(description string) error {
+	return dg.CaptureFrameWithDelay(description, 0)
+}
+
+// CaptureFrameWithDelay captures a frame shown for delay on playback; a zero
+// delay uses the generator's frame delay
+func (dg *DemoGenerator) CaptureFrameWithDelay(description string, delay time.Duration) error {
 	if !dg.Recording {
 		return fmt.Errorf("not recording")
 	}
@@ -103,6 +154,15 @@ func (dg *DemoGenerator) CaptureFrame(description string) error {
 		return err
 	}
 
+	if delay <= 0 {
+		delay = dg.Sequence.FrameDelay
+	}
+
+	// Burn the description into the frame
+	if dg.Captions.Enabled && description != "" {
+		screenshot.Annotate(description, dg.Captions)
+	}
+
 	// Save screenshot
 	filename := fmt.Sprintf("frame_%04d.png", dg.CurrentFrame)
 	screenshotPath := filepath.Join(dg.OutputDir, filename)
@@ -114,8 +174,9 @@ func (dg *DemoGenerator) CaptureFrame(description string) error {
 	action := DemoAction{
 		Type:        "screenshot",
 		Description: description,
-		Delay:       0, // Will be calculated later
+		Delay:       delay,
 		Screenshot:  screenshot,
+		Content:     frameContent(dg.Model),
 	}
 
 	dg.Sequence.Actions = append(dg.Sequence.Actions, action)
@@ -138,9 +199,23 @@ func (dg *DemoGenerator) AddKeyPress(key tea.KeyMsg, description string) error {
 	}
 
 	dg.Sequence.Actions = append(dg.Sequence.Actions, action)
+	dg.applyToModel(key)
+
+	if dg.AutoCapture {
+		return dg.CaptureFrame(keyPressDescription(key, description))
+	}
 	return nil
 }
 
+// keyPressDescription describes the frame auto-captured after a key press
+func keyPressDescription(key tea.KeyMsg, description string) string {
+	frameDescription := fmt.Sprintf("After '%s'", keyToString(key))
+	if description != "" {
+		frameDescription += ": " + description
+	}
+	return frameDescription
+}
+
 // AddMouseClick adds a mouse click action to the demo
 func (dg *DemoGenerator) AddMouseClick(x, y int, description string) error {
 	if !dg.Recording {
@@ -155,9 +230,22 @@ func (dg *DemoGenerator) AddMouseClick(x, y int, description string) error {
 	}
 
 	dg.Sequence.Actions = append(dg.Sequence.Actions, action)
+	dg.applyToModel(action.MouseClick.toMsg())
 	return nil
 }
 
+// applyToModel sends msg to the model being recorded so later frames show its effect
+func (dg *DemoGenerator) applyToModel(msg tea.Msg) {
+	if model, ok := dg.Model.(tea.Model); ok {
+		dg.Model, _ = model.Update(msg)
+	}
+}
+
+// toMsg converts a recorded click to the mouse message it stands for
+func (mc MouseClick) toMsg() tea.MouseMsg {
+	return tea.MouseMsg{X: mc.X, Y: mc.Y, Type: tea.MouseLeft}
+}
+
 // saveDemoMetadata saves the demo metadata to JSON
 func (dg *DemoGenerator) saveDemoMetadata() error {
 	metadataPath := filepath.Join(dg.OutputDir, "demo.json")
@@ -357,6 +445,58 @@ func (dg *DemoGenerator) GenerateAllDemos() error {
 	return nil
 }
 
+// Replay re-applies the recorded input to model, which should be freshly
+// created, and verifies that every recorded frame still matches within the
+// replay tolerance
+func (dg *DemoGenerator) Replay(model tea.Model) error {
+	if dg.Sequence == nil {
+		return fmt.Errorf("no demo recorded")
+	}
+
+	frame := 0
+	for _, action := range dg.Sequence.Actions {
+		switch action.Type {
+		case "keypress":
+			model, _ = model.Update(action.KeyPress)
+		case "mouseclick":
+			model, _ = model.Update(action.MouseClick.toMsg())
+		case "screenshot":
+			if err := dg.verifyFrame(model, frame, action); err != nil {
+				return err
+			}
+			frame++
+		}
+	}
+
+	return nil
+}
+
+// verifyFrame compares model's current rendering against a recorded frame
+func (dg *DemoGenerator) verifyFrame(model tea.Model, frame int, action DemoAction) error {
+	if action.Screenshot == nil {
+		return fmt.Errorf("frame %d (%s) has no recorded screenshot", frame, action.Description)
+	}
+
+	current, err := NewScreenshotFromModel(model, dg.Config)
+	if err != nil {
+		return fmt.Errorf("frame %d (%s): %w", frame, action.Description, err)
+	}
+	if dg.Captions.Enabled && action.Description != "" {
+		current.Annotate(action.Description, dg.Captions)
+	}
+
+	result, err := CompareScreenshots(action.Screenshot, current, NewDefaultCompareConfig())
+	if err != nil {
+		return fmt.Errorf("frame %d (%s): %w", frame, action.Description, err)
+	}
+	if result.DiffRatio > dg.ReplayTolerance {
+		return fmt.Errorf("frame %d (%s) differs from the recording: %.2f%% of pixels changed",
+			frame, action.Description, result.DiffRatio*100)
+	}
+
+	return nil
+}
+
 // RenderDemoScript renders a demo script for playback
 func (dg *DemoGenerator) RenderDemoScript(sequence *DemoSequence) (string, error) {
 	var script strings.Builder
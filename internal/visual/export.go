@@ -0,0 +1,124 @@
+package visual
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color/palette"
+	"image/draw"
+	"image/gif"
+	"os"
+	"strings"
+	"time"
+)
+
+// castHeader is the header line of an asciicast v2 recording
+type castHeader struct {
+	Version   int    `json:"version"`
+	Width     int    `json:"width"`
+	Height    int    `json:"height"`
+	Timestamp int64  `json:"timestamp"`
+	Title     string `json:"title,omitempty"`
+}
+
+// frameContent returns the rendered view of a model, if it has one
+func frameContent(model interface{}) string {
+	if m, ok := model.(interface{ View() string }); ok {
+		return m.View()
+	}
+	return ""
+}
+
+// frames returns the screenshot actions of the recorded sequence
+func (dg *DemoGenerator) frames() ([]DemoAction, error) {
+	if dg.Sequence == nil {
+		return nil, fmt.Errorf("no demo recorded")
+	}
+
+	var frames []DemoAction
+	for _, action := range dg.Sequence.Actions {
+		if action.Type == "screenshot" {
+			frames = append(frames, action)
+		}
+	}
+	if len(frames) == 0 {
+		return nil, fmt.Errorf("demo has no frames")
+	}
+
+	return frames, nil
+}
+
+// ExportCast writes the recorded frames as an asciicast v2 file, each frame
+// starting once the previous one has been shown for its delay
+func (dg *DemoGenerator) ExportCast(filename string) error {
+	frames, err := dg.frames()
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+	encoder := json.NewEncoder(writer)
+
+	header := castHeader{
+		Version:   2,
+		Width:     dg.Config.Width,
+		Height:    dg.Config.Height,
+		Timestamp: dg.Sequence.CreatedAt.Unix(),
+		Title:     dg.Sequence.Name,
+	}
+	if err := encoder.Encode(header); err != nil {
+		return err
+	}
+
+	var elapsed time.Duration
+	for _, frame := range frames {
+		// Clear the screen and redraw the frame from the top left
+		output := "\x1b[2J\x1b[H" + strings.ReplaceAll(frame.Content, "\n", "\r\n")
+		event := []interface{}{elapsed.Seconds(), "o", output}
+		if err := encoder.Encode(event); err != nil {
+			return err
+		}
+		elapsed += frame.Delay
+	}
+
+	return writer.Flush()
+}
+
+// ExportGIF writes the recorded frames as an animated GIF, showing each frame
+// for its delay
+func (dg *DemoGenerator) ExportGIF(filename string) error {
+	frames, err := dg.frames()
+	if err != nil {
+		return err
+	}
+
+	animation := &gif.GIF{}
+	for _, frame := range frames {
+		if frame.Screenshot == nil {
+			return fmt.Errorf("frame %q has no screenshot", frame.Description)
+		}
+
+		bounds := frame.Screenshot.Image.Bounds()
+		paletted := image.NewPaletted(bounds, palette.Plan9)
+		draw.Draw(paletted, bounds, frame.Screenshot.Image, bounds.Min, draw.Src)
+
+		animation.Image = append(animation.Image, paletted)
+		// GIF delays are in hundredths of a second
+		animation.Delay = append(animation.Delay, int(frame.Delay/(10*time.Millisecond)))
+	}
+
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return gif.EncodeAll(file, animation)
+}
@@ -0,0 +1,52 @@
+package visual
+
+import (
+	"fmt"
+	"image/color"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ansiBaseColors are the xterm values of the 16 basic ANSI colors
+var ansiBaseColors = [16]color.RGBA{
+	{0x00, 0x00, 0x00, 0xff}, {0x80, 0x00, 0x00, 0xff}, {0x00, 0x80, 0x00, 0xff}, {0x80, 0x80, 0x00, 0xff},
+	{0x00, 0x00, 0x80, 0xff}, {0x80, 0x00, 0x80, 0xff}, {0x00, 0x80, 0x80, 0xff}, {0xc0, 0xc0, 0xc0, 0xff},
+	{0x80, 0x80, 0x80, 0xff}, {0xff, 0x00, 0x00, 0xff}, {0x00, 0xff, 0x00, 0xff}, {0xff, 0xff, 0x00, 0xff},
+	{0x00, 0x00, 0xff, 0xff}, {0xff, 0x00, 0xff, 0xff}, {0x00, 0xff, 0xff, 0xff}, {0xff, 0xff, 0xff, 0xff},
+}
+
+// ansiCubeLevels are the channel values of the 6x6x6 color cube
+var ansiCubeLevels = [6]uint8{0x00, 0x5f, 0x87, 0xaf, 0xd7, 0xff}
+
+// TerminalColor converts a lipgloss color, either "#rrggbb" or an ANSI 256
+// color number, to the RGB value an xterm-compatible terminal shows for it
+func TerminalColor(c lipgloss.Color) (color.RGBA, error) {
+	value := string(c)
+
+	if strings.HasPrefix(value, "#") {
+		var rgb color.RGBA
+		if _, err := fmt.Sscanf(value, "#%02x%02x%02x", &rgb.R, &rgb.G, &rgb.B); err != nil || len(value) != 7 {
+			return color.RGBA{}, fmt.Errorf("invalid hex color %q", value)
+		}
+		rgb.A = 0xff
+		return rgb, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 || n > 255 {
+		return color.RGBA{}, fmt.Errorf("invalid ANSI color %q", value)
+	}
+
+	switch {
+	case n < 16:
+		return ansiBaseColors[n], nil
+	case n < 232:
+		n -= 16
+		return color.RGBA{ansiCubeLevels[n/36], ansiCubeLevels[n/6%6], ansiCubeLevels[n%6], 0xff}, nil
+	default:
+		gray := uint8(8 + 10*(n-232))
+		return color.RGBA{gray, gray, gray, 0xff}, nil
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+
+	"ccpm-demo/internal/calculator"
+)
+
+// jsonResult is the outcome of one evaluation as written with --json
+type jsonResult struct {
+	Expression string       `json:"expression"`
+	Result     *json.Number `json:"result"`
+	Error      *string      `json:"error"`
+}
+
+// evalExpressionJSON evaluates expr and writes the outcome to out as JSON,
+// reporting whether the evaluation succeeded and was written
+func evalExpressionJSON(out io.Writer, calc *calculator.Calculator, expr string) bool {
+	value, err := calc.Evaluate(expr)
+	if writeErr := writeJSONResult(out, calc, expr, value, err); writeErr != nil {
+		return false
+	}
+	return err == nil
+}
+
+// writeJSONResult writes the outcome of evaluating expression as one line of
+// JSON. The result is a JSON number in decimal, rounded to the calculator's
+// precision, and null when err is set. It returns any error writing to out.
+func writeJSONResult(out io.Writer, calc *calculator.Calculator, expression string, value float64, err error) error {
+	entry := jsonResult{Expression: expression}
+	if err != nil {
+		message := err.Error()
+		entry.Error = &message
+	} else {
+		formatter := calc.Formatter()
+		formatter.Base = calculator.BaseDecimal
+		number := json.Number(formatter.Format(value))
+		entry.Result = &number
+	}
+
+	// Operators such as << are left as they are rather than escaped
+	encoder := json.NewEncoder(out)
+	encoder.SetEscapeHTML(false)
+	return encoder.Encode(entry)
+}
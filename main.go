@@ -1,13 +1,17 @@
 package main
 
 import (
-	"bufio"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"runtime"
+	"sort"
 	"strconv"
 	"strings"
 
+	"github.com/charmbracelet/lipgloss"
+
 	"ccpm-demo/internal/calculator"
 )
 
@@ -18,10 +22,47 @@ var (
 	CommitHash = "unknown"
 )
 
+// defaultPrompt is the REPL prompt used when none is configured
+const defaultPrompt = "> "
+
 func main() {
+	// Output is plain text apart from the prompt, which --no-color leaves uncolored
+	args := removeFlag(os.Args[1:], "--no-color")
+	useColor := len(args) == len(os.Args[1:]) && os.Getenv("NO_COLOR") == ""
+
+	args, prompt := takeFlagValue(args, "--prompt")
+	args, promptColor := takeFlagValue(args, "--prompt-color")
+
+	// Results are shown in the shortest exact form unless --precision says otherwise
+	args, precisionFlag := takeFlagValue(args, "--precision")
+	precision := calculator.DefaultPrecision
+	if precisionFlag != "" {
+		var err error
+		if precision, err = parsePrecision(precisionFlag); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	// Whole-number results are shown in decimal unless --base says otherwise
+	args, baseFlag := takeFlagValue(args, "--base")
+	base := calculator.BaseDecimal
+	if baseFlag != "" {
+		var err error
+		if base, err = calculator.ParseNumberBase(baseFlag); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	// --eval and --batch write JSON instead of text with --json
+	withoutJSON := removeFlag(args, "--json")
+	jsonOutput := len(withoutJSON) != len(args)
+	args = withoutJSON
+
 	// Handle command line arguments
-	if len(os.Args) > 1 {
-		switch os.Args[1] {
+	if len(args) > 0 {
+		switch args[0] {
 		case "--version", "-v":
 			printVersion()
 			return
@@ -29,11 +70,24 @@ func main() {
 			printHelp()
 			return
 		case "--eval":
-			if len(os.Args) < 3 {
+			if len(args) < 2 {
 				fmt.Println("Error: --eval requires an expression")
 				os.Exit(1)
 			}
-			evalExpression(strings.Join(os.Args[2:], " "))
+			expr := strings.Join(args[1:], " ")
+			if jsonOutput {
+				if !evalExpressionJSON(os.Stdout, newCalculator(precision, base), expr) {
+					os.Exit(1)
+				}
+				return
+			}
+			evalExpression(expr, precision, base)
+			return
+		case "--batch":
+			calc := newCalculator(precision, base)
+			if failed := runBatch(calc, os.Stdin, os.Stdout, os.Stderr, jsonOutput); failed > 0 {
+				os.Exit(1)
+			}
 			return
 		}
 	}
@@ -42,12 +96,12 @@ func main() {
 	fmt.Printf("CCPM Calculator v%s\n", Version)
 	fmt.Printf("Type 'help' for commands, 'quit' to exit\n\n")
 
-	calc := calculator.NewCalculator()
-	reader := bufio.NewReader(os.Stdin)
+	calc := newCalculator(precision, base)
+	watches := newWatchList(os.Stdout)
+	reader := newLineReader(calc, renderPrompt(prompt, promptColor, useColor))
 
 	for {
-		fmt.Print("> ")
-		input, err := reader.ReadString('\n')
+		input, err := reader.ReadLine()
 		if err != nil {
 			fmt.Printf("Error reading input: %v\n", err)
 			break
@@ -68,12 +122,42 @@ func main() {
 			printVersion()
 		case "vars":
 			printVariables(calc)
+		case "history":
+			printHistory(calc)
 		case "clear":
 			calc.ClearVariables()
-			fmt.Println("Variables cleared")
+			fmt.Println("Variables cleared (constants kept)")
+		case "clear all":
+			calc.ClearVariables()
+			calc.ClearHistory()
+			fmt.Println("Variables and history cleared (constants kept)")
+		case "mr":
+			fmt.Printf("M = %s\n", calc.Format(calc.MemoryRecall()))
+		case "mc":
+			calc.MemoryClear()
+			fmt.Println("Memory cleared")
+			watches.Changed(calc, calculator.MemoryName)
 		default:
-			if strings.HasPrefix(input, "set ") {
-				handleVariableSet(calc, input[4:])
+			if isMemoryUpdate(input) {
+				if handleMemoryUpdate(calc, input) {
+					watches.Changed(calc, calculator.MemoryName)
+				}
+			} else if strings.HasPrefix(input, "set ") {
+				if name, ok := handleVariableSet(calc, input[4:]); ok {
+					watches.Changed(calc, name)
+				}
+			} else if strings.HasPrefix(input, "const ") {
+				if name, ok := handleConstantSet(calc, input[6:]); ok {
+					watches.Changed(calc, name)
+				}
+			} else if input == "precision" || strings.HasPrefix(input, "precision ") {
+				handlePrecision(calc, strings.TrimSpace(input[len("precision"):]))
+			} else if input == "base" || strings.HasPrefix(input, "base ") {
+				handleBase(calc, strings.TrimSpace(input[len("base"):]))
+			} else if strings.HasPrefix(input, "watch ") {
+				if err := watches.Add(calc, input[6:]); err != nil {
+					fmt.Printf("Error: %v\n", err)
+				}
 			} else {
 				evalExpressionWithCalc(calc, input)
 			}
@@ -81,25 +165,237 @@ func main() {
 	}
 }
 
-func evalExpression(expr string) {
+// removeFlag returns args without any occurrence of flag
+func removeFlag(args []string, flag string) []string {
+	var filtered []string
+	for _, arg := range args {
+		if arg != flag {
+			filtered = append(filtered, arg)
+		}
+	}
+	return filtered
+}
+
+// takeFlagValue removes flag and its value, given either as "flag value" or
+// "flag=value", from args and returns the remaining args and the value
+func takeFlagValue(args []string, flag string) ([]string, string) {
+	var filtered []string
+	var value string
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == flag && i+1 < len(args):
+			value = args[i+1]
+			i++
+		case strings.HasPrefix(args[i], flag+"="):
+			value = strings.TrimPrefix(args[i], flag+"=")
+		default:
+			filtered = append(filtered, args[i])
+		}
+	}
+	return filtered, value
+}
+
+// renderPrompt returns the REPL prompt: text, or the default prompt when text
+// is empty, drawn in color when one is given and color output is enabled
+func renderPrompt(text, color string, useColor bool) string {
+	if text == "" {
+		text = defaultPrompt
+	}
+	if color == "" || !useColor {
+		return text
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Render(text)
+}
+
+// newCalculator creates a calculator showing results with the given
+// precision and base
+func newCalculator(precision int, base calculator.NumberBase) *calculator.Calculator {
 	calc := calculator.NewCalculator()
-	evalExpressionWithCalc(calc, expr)
+	calc.SetPrecision(precision)
+	calc.SetBase(base)
+	return calc
+}
+
+func evalExpression(expr string, precision int, base calculator.NumberBase) {
+	evalExpressionWithCalc(newCalculator(precision, base), expr)
 }
 
 func evalExpressionWithCalc(calc *calculator.Calculator, expr string) {
+	if err := writeEvaluation(os.Stdout, os.Stdout, calc, expr); err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+}
+
+// writeEvaluation evaluates expr and writes its result to out, and to warnOut
+// a warning when the result cannot be shown in the selected base
+func writeEvaluation(out, warnOut io.Writer, calc *calculator.Calculator, expr string) error {
 	result, err := calc.Evaluate(expr)
 	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "= %s\n", calc.Format(result))
+	if warning := baseWarning(calc, result); warning != "" {
+		fmt.Fprintln(warnOut, warning)
+	}
+	return nil
+}
+
+// baseWarning returns a warning when result cannot be shown in the selected
+// non-decimal base and was shown in decimal instead, or an empty string
+func baseWarning(calc *calculator.Calculator, result float64) string {
+	base := calc.GetBase()
+	if base == calculator.BaseDecimal {
+		return ""
+	}
+	if _, err := calculator.FormatInteger(result, base); err != nil {
+		return fmt.Sprintf("Warning: %v, shown in decimal instead of %s", err, base)
+	}
+	return ""
+}
+
+// parsePrecision parses a number of decimal places, or "auto" for the
+// shortest exact form
+func parsePrecision(value string) (int, error) {
+	if value == "auto" {
+		return calculator.DefaultPrecision, nil
+	}
+	digits, err := strconv.Atoi(value)
+	if err != nil || digits < 0 {
+		return 0, fmt.Errorf("precision must be a number of decimal places or auto, got %q", value)
+	}
+	return digits, nil
+}
+
+// handlePrecision sets the number of decimal places results are shown with,
+// or prints it when no value is given
+func handlePrecision(calc *calculator.Calculator, value string) {
+	if value != "" {
+		digits, err := parsePrecision(value)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		calc.SetPrecision(digits)
+	}
+
+	if precision := calc.GetPrecision(); precision == calculator.DefaultPrecision {
+		fmt.Println("Precision: auto")
+	} else {
+		fmt.Printf("Precision: %d decimal places\n", precision)
+	}
+}
+
+// handleBase sets the base whole-number results are shown in, or prints it
+// when no base is given
+func handleBase(calc *calculator.Calculator, value string) {
+	if value != "" {
+		base, err := calculator.ParseNumberBase(value)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		calc.SetBase(base)
+	}
+	fmt.Printf("Base: %s\n", calc.GetBase())
+}
+
+// handleVariableSet sets a variable and returns its name, reporting whether it was set
+func handleVariableSet(calc *calculator.Calculator, input string) (string, bool) {
+	varName, value, ok := parseAssignment(calc, input, "Usage: set variable = value")
+	if !ok {
+		return "", false
+	}
+
+	if err := calc.SetVariable(varName, value); err != nil {
 		fmt.Printf("Error: %v\n", err)
-		return
+		return "", false
+	}
+	fmt.Printf("Set %s = %s\n", varName, calc.Format(value))
+	return varName, true
+}
+
+// handleConstantSet defines a constant, a variable that clear keeps, and
+// returns its name, reporting whether it was defined
+func handleConstantSet(calc *calculator.Calculator, input string) (string, bool) {
+	name, value, ok := parseAssignment(calc, input, "Usage: const name = value")
+	if !ok {
+		return "", false
+	}
+
+	if err := calc.SetConstant(name, value); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return "", false
 	}
-	fmt.Printf("= %g\n", result)
+	fmt.Printf("Constant %s = %s\n", name, calc.Format(value))
+	return name, true
 }
 
-func handleVariableSet(calc *calculator.Calculator, input string) {
+// isMemoryUpdate reports whether input is an "m+" or "m-" command
+func isMemoryUpdate(input string) bool {
+	for _, command := range []string{"m+", "m-"} {
+		if input == command || strings.HasPrefix(input, command+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+// handleMemoryUpdate adds the value given to an "m+" command to the memory,
+// or subtracts that of an "m-" command, and reports whether it did. Without
+// a value the last result is used.
+func handleMemoryUpdate(calc *calculator.Calculator, input string) bool {
+	operand := strings.TrimSpace(input[2:])
+
+	var value float64
+	if operand == "" {
+		results := calc.GetResults()
+		if len(results) == 0 {
+			fmt.Printf("Error: no result yet, use %s value\n", input[:2])
+			return false
+		}
+		value = results[0]
+	} else {
+		result, err := calc.Evaluate(operand)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return false
+		}
+		value = result
+	}
+
+	if input[1] == '+' {
+		calc.MemoryAdd(value)
+	} else {
+		calc.MemorySubtract(value)
+	}
+	fmt.Printf("M = %s\n", calc.Format(calc.MemoryRecall()))
+	return true
+}
+
+// parseAssignment parses "name = value", where value may be an expression,
+// printing usage or the error when it cannot
+func parseAssignment(calc *calculator.Calculator, input, usage string) (string, float64, bool) {
+	varName, value, err := splitAssignment(calc, input)
+	if err == errNoAssignment {
+		fmt.Println(usage)
+		return "", 0, false
+	}
+	if err != nil {
+		fmt.Printf("Error parsing value: %v\n", err)
+		return "", 0, false
+	}
+	return varName, value, true
+}
+
+// errNoAssignment is returned by splitAssignment for input without an "="
+var errNoAssignment = errors.New("expected name = value")
+
+// splitAssignment splits "name = value" input, evaluating the value if it is
+// an expression rather than a number
+func splitAssignment(calc *calculator.Calculator, input string) (string, float64, error) {
 	parts := strings.SplitN(input, "=", 2)
 	if len(parts) != 2 {
-		fmt.Println("Usage: set variable = value")
-		return
+		return "", 0, errNoAssignment
 	}
 
 	varName := strings.TrimSpace(parts[0])
@@ -110,27 +406,69 @@ func handleVariableSet(calc *calculator.Calculator, input string) {
 		// Try to evaluate as expression
 		result, evalErr := calc.Evaluate(valueStr)
 		if evalErr != nil {
-			fmt.Printf("Error parsing value: %v\n", err)
-			return
+			return "", 0, evalErr
 		}
 		value = result
 	}
 
-	calc.SetVariable(varName, value)
-	fmt.Printf("Set %s = %g\n", varName, value)
+	return varName, value, nil
 }
 
 func printVariables(calc *calculator.Calculator) {
-	vars := calc.GetVariables()
-	if len(vars) == 0 {
+	lines := variableLines(calc)
+	if len(lines) == 0 {
 		fmt.Println("No variables defined")
 		return
 	}
 
 	fmt.Println("Variables:")
-	for name, value := range vars {
-		fmt.Printf("  %s = %g\n", name, value)
+	for _, line := range lines {
+		fmt.Printf("  %s\n", line)
+	}
+}
+
+// variableLines returns one "name = value" line per variable in name order,
+// marking constants
+func variableLines(calc *calculator.Calculator) []string {
+	vars := calc.GetVariables()
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	lines := make([]string, len(names))
+	for i, name := range names {
+		lines[i] = fmt.Sprintf("%s = %s", name, calc.Format(vars[name]))
+		if calc.IsConstant(name) {
+			lines[i] += " (const)"
+		}
+	}
+	return lines
+}
+
+func printHistory(calc *calculator.Calculator) {
+	lines := historyLines(calc)
+	if len(lines) == 0 {
+		fmt.Println("No history")
+		return
+	}
+
+	fmt.Println("History:")
+	for _, line := range lines {
+		fmt.Printf("  %s\n", line)
+	}
+}
+
+// historyLines returns one "expression = result" line per kept evaluation,
+// oldest first, numbered by the ans name of its result
+func historyLines(calc *calculator.Calculator) []string {
+	history := calc.History()
+	lines := make([]string, len(history))
+	for i, entry := range history {
+		lines[i] = fmt.Sprintf("ans%d: %s = %s", len(history)-i, entry.Expression, calc.Format(entry.Result))
 	}
+	return lines
 }
 
 func printVersion() {
@@ -147,14 +485,29 @@ func printHelp() {
 	fmt.Printf("Options:\n")
 	fmt.Printf("  -v, --version    Show version information\n")
 	fmt.Printf("  -h, --help       Show this help message\n")
-	fmt.Printf("  --eval EXPR      Evaluate expression and exit\n\n")
+	fmt.Printf("  --eval EXPR      Evaluate expression and exit\n")
+	fmt.Printf("  --batch          Evaluate each line of stdin and exit\n")
+	fmt.Printf("  --json           Write --eval and --batch results as JSON\n")
+	fmt.Printf("  --prompt TEXT    Set the interactive prompt (default \"> \")\n")
+	fmt.Printf("  --prompt-color C Color the prompt (ANSI number or #rrggbb)\n")
+	fmt.Printf("  --precision N    Show results with N decimal places (default auto)\n")
+	fmt.Printf("  --base B         Show whole-number results in hex, dec, oct or bin\n")
+	fmt.Printf("  --no-color       Disable colored output (also honors NO_COLOR)\n\n")
 	fmt.Printf("Interactive Commands:\n")
 	fmt.Printf("  help, h          Show interactive help\n")
 	fmt.Printf("  version, v       Show version\n")
 	fmt.Printf("  quit, exit, q    Exit calculator\n")
 	fmt.Printf("  vars             Show all variables\n")
-	fmt.Printf("  clear            Clear all variables\n")
+	fmt.Printf("  history          Show recent calculations\n")
+	fmt.Printf("  clear            Clear all variables except constants\n")
+	fmt.Printf("  clear all        Clear the history too\n")
 	fmt.Printf("  set var = value  Set variable\n")
+	fmt.Printf("  const c = value  Define a constant that clear keeps\n")
+	fmt.Printf("  watch w = expr   Print expr whenever a variable in it is set\n")
+	fmt.Printf("  m+, m- [expr]    Add to or subtract from memory (default: last result)\n")
+	fmt.Printf("  mr, mc           Recall or clear memory\n")
+	fmt.Printf("  precision [N]    Show or set the decimal places of results (auto to reset)\n")
+	fmt.Printf("  base [B]         Show or set the base of results (hex, dec, oct, bin)\n")
 }
 
 func printInteractiveHelp() {
@@ -163,14 +516,30 @@ func printInteractiveHelp() {
 	fmt.Println("  version, v       Show version")
 	fmt.Println("  quit, exit, q    Exit calculator")
 	fmt.Println("  vars             Show all variables")
-	fmt.Println("  clear            Clear all variables")
+	fmt.Println("  history          Show recent calculations")
+	fmt.Println("  clear            Clear all variables except constants")
+	fmt.Println("  clear all        Clear the history too")
 	fmt.Println("  set var = value  Set variable")
+	fmt.Println("  const c = value  Define a constant that clear keeps")
+	fmt.Println("  watch w = expr   Print expr whenever a variable in it is set")
+	fmt.Println("  m+, m- [expr]    Add to or subtract from memory (default: last result)")
+	fmt.Println("  mr, mc           Recall or clear memory")
+	fmt.Println("  precision [N]    Show or set the decimal places of results (auto to reset)")
+	fmt.Println("  base [B]         Show or set the base of results (hex, dec, oct, bin)")
+	fmt.Println("  Tab              Complete function and variable names")
 	fmt.Println("")
 	fmt.Println("Mathematical Operations:")
 	fmt.Println("  + - * /          Basic arithmetic")
 	fmt.Println("  ^                Power")
+	fmt.Println("  & | ^^ << >>     Bitwise and, or, xor and shifts of whole numbers")
 	fmt.Println("  ( )              Grouping")
+	fmt.Println("  0xFF 0o17 0b1010 Hexadecimal, octal and binary numbers")
 	fmt.Println("  sin, cos, tan    Trigonometric functions")
 	fmt.Println("  sqrt             Square root")
+	fmt.Println("  root(x, n)       nth root")
+	fmt.Println("  n!, nPr, nCr     Factorial, permutations and combinations")
+	fmt.Println("  delta, pctchange Change and percent change from a first value to a second")
 	fmt.Println("  Variables can be used in expressions")
+	fmt.Println("  ans, ans1..ans10 Earlier results, ans1 being the latest")
+	fmt.Println("  M                Memory")
 }
\ No newline at end of file
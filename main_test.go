@@ -0,0 +1,398 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"ccpm-demo/internal/calculator"
+)
+
+func TestRenderPrompt(t *testing.T) {
+	if got := renderPrompt("calc> ", "", true); got != "calc> " {
+		t.Errorf("Expected the configured prompt 'calc> ', got %q", got)
+	}
+
+	// An empty prompt reverts to the default
+	if got := renderPrompt("", "", true); got != defaultPrompt {
+		t.Errorf("Expected the default prompt %q, got %q", defaultPrompt, got)
+	}
+
+	// With a color the prompt is wrapped in a foreground style
+	expected := lipgloss.NewStyle().Foreground(lipgloss.Color("205")).Render("calc> ")
+	got := renderPrompt("calc> ", "205", true)
+	if got != expected {
+		t.Errorf("Expected the styled prompt %q, got %q", expected, got)
+	}
+	if !strings.Contains(got, "calc> ") {
+		t.Errorf("Expected the styled prompt to contain the text, got %q", got)
+	}
+
+	// Disabling color leaves the prompt plain
+	if got := renderPrompt("calc> ", "205", false); got != "calc> " {
+		t.Errorf("Expected an uncolored prompt, got %q", got)
+	}
+}
+
+func TestTakeFlagValue(t *testing.T) {
+	args, value := takeFlagValue([]string{"--prompt", "calc> ", "--eval", "1+1"}, "--prompt")
+	if value != "calc> " || strings.Join(args, " ") != "--eval 1+1" {
+		t.Errorf("Expected 'calc> ' and the remaining args, got %q and %v", value, args)
+	}
+
+	args, value = takeFlagValue([]string{"--prompt-color=205"}, "--prompt-color")
+	if value != "205" || len(args) != 0 {
+		t.Errorf("Expected '205' and no remaining args, got %q and %v", value, args)
+	}
+
+	// The flag prefix alone does not match a longer flag
+	args, value = takeFlagValue([]string{"--prompt-color=205"}, "--prompt")
+	if value != "" || len(args) != 1 {
+		t.Errorf("Expected --prompt-color to be left alone, got %q and %v", value, args)
+	}
+}
+
+func TestCompletions(t *testing.T) {
+	calc := calculator.NewCalculator()
+	calc.SetVariable("rate", 5)
+	calc.SetVariable("total", 10)
+
+	tests := []struct {
+		prefix string
+		want   []string
+	}{
+		{"r", []string{"rate", "root(", "round("}},
+		{"ro", []string{"root(", "round("}},
+		{"rou", []string{"round("}},
+		{"ra", []string{"rate"}},
+		{"t", []string{"total"}},
+		{"a", []string{"addtax("}},
+		{"x", nil},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		got := completions(calc, tt.prefix)
+		if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("completions(%q) = %v, want %v", tt.prefix, got, tt.want)
+		}
+	}
+}
+
+func TestCompleterCyclesOnRepeatedTab(t *testing.T) {
+	calc := calculator.NewCalculator()
+	calc.SetVariable("rate", 5)
+	c := &completer{calc: calc}
+
+	line, pos, ok := c.complete("2*r", 3, '\t')
+	if !ok || line != "2*rate" || pos != 6 {
+		t.Fatalf("Expected the first Tab to complete '2*rate', got %q at %d (%v)", line, pos, ok)
+	}
+
+	line, pos, _ = c.complete(line, pos, '\t')
+	if line != "2*root(" || pos != 7 {
+		t.Errorf("Expected the second Tab to cycle to '2*root(', got %q at %d", line, pos)
+	}
+
+	line, pos, _ = c.complete(line, pos, '\t')
+	if line != "2*round(" || pos != 8 {
+		t.Errorf("Expected the third Tab to cycle to '2*round(', got %q at %d", line, pos)
+	}
+
+	line, pos, _ = c.complete(line, pos, '\t')
+	if line != "2*rate" {
+		t.Errorf("Expected the fourth Tab to cycle back to '2*rate', got %q", line)
+	}
+
+	// Other keys are left to the terminal and end the cycle
+	if _, _, ok := c.complete(line, pos, 'x'); ok {
+		t.Error("Expected keys other than Tab not to be handled")
+	}
+	if _, _, ok := c.complete("2*q", 3, '\t'); ok {
+		t.Error("Expected no completion for an unknown prefix")
+	}
+
+	// Text after the cursor is kept
+	line, pos, _ = c.complete("rou+1", 3, '\t')
+	if line != "round(+1" || pos != 6 {
+		t.Errorf("Expected completion before the cursor only, got %q at %d", line, pos)
+	}
+}
+
+func TestConstantsSurviveClear(t *testing.T) {
+	calc := calculator.NewCalculator()
+	handleVariableSet(calc, "x = 2")
+	handleConstantSet(calc, "g = 9.81")
+
+	lines := variableLines(calc)
+	if strings.Join(lines, "\n") != "g = 9.81 (const)\nx = 2" {
+		t.Errorf("Expected the listing to mark g as a constant, got %v", lines)
+	}
+
+	calc.ClearVariables()
+
+	lines = variableLines(calc)
+	if strings.Join(lines, "\n") != "g = 9.81 (const)" {
+		t.Errorf("Expected clear to keep only the constant, got %v", lines)
+	}
+
+	result, err := calc.Evaluate("g*2")
+	if err != nil || result != 19.62 {
+		t.Errorf("Expected g*2 to evaluate to 19.62, got %v (%v)", result, err)
+	}
+}
+
+func TestWatchReprintsOnDependencyChange(t *testing.T) {
+	calc := calculator.NewCalculator()
+	calc.SetVariable("a", 1)
+	calc.SetVariable("b", 2)
+	calc.SetVariable("c", 5)
+
+	var out strings.Builder
+	watches := newWatchList(&out)
+	if err := watches.Add(calc, "total = a + b"); err != nil {
+		t.Fatalf("Add returned error: %v", err)
+	}
+	if out.String() != "total = 3\n" {
+		t.Errorf("Expected the watch to print its current value, got %q", out.String())
+	}
+
+	out.Reset()
+	calc.SetVariable("a", 10)
+	watches.Changed(calc, "a")
+	if out.String() != "total = 12\n" {
+		t.Errorf("Expected changing a to re-print the watch, got %q", out.String())
+	}
+
+	out.Reset()
+	calc.SetVariable("c", 7)
+	watches.Changed(calc, "c")
+	if out.String() != "" {
+		t.Errorf("Expected changing an unrelated variable to print nothing, got %q", out.String())
+	}
+
+	// Function names are not dependencies
+	deps := expressionVariables("round(a) * 2 + b")
+	if len(deps) != 2 || !deps["a"] || !deps["b"] {
+		t.Errorf("Expected dependencies a and b, got %v", deps)
+	}
+
+	if err := watches.Add(calc, "broken"); err == nil {
+		t.Error("Expected a watch without an expression to be rejected")
+	}
+}
+
+func TestMemoryCommands(t *testing.T) {
+	calc := calculator.NewCalculator()
+
+	commands := map[string]bool{"m+": true, "m+ 5": true, "m- 2": true, "m-": true, "mr": false, "m+1": false}
+	for input, want := range commands {
+		if got := isMemoryUpdate(input); got != want {
+			t.Errorf("isMemoryUpdate(%q) = %v, want %v", input, got, want)
+		}
+	}
+
+	// Without a result there is nothing to add
+	if handleMemoryUpdate(calc, "m+") {
+		t.Error("Expected m+ without a result or value to fail")
+	}
+
+	calc.Evaluate("4*5")
+	if !handleMemoryUpdate(calc, "m+") || calc.MemoryRecall() != 20 {
+		t.Errorf("Expected m+ to add the last result, got %v", calc.MemoryRecall())
+	}
+	if !handleMemoryUpdate(calc, "m- 2*3") || calc.MemoryRecall() != 14 {
+		t.Errorf("Expected m- 2*3 to subtract 6, got %v", calc.MemoryRecall())
+	}
+	if lines := variableLines(calc); len(lines) != 1 || lines[0] != "M = 14" {
+		t.Errorf("Expected the listing to show the memory, got %v", lines)
+	}
+
+	// Watches on M follow the memory
+	var out strings.Builder
+	watches := newWatchList(&out)
+	watches.Add(calc, "half = M / 2")
+	out.Reset()
+	handleMemoryUpdate(calc, "m+ 2")
+	watches.Changed(calc, calculator.MemoryName)
+	if out.String() != "half = 8\n" {
+		t.Errorf("Expected the watch to follow the memory, got %q", out.String())
+	}
+}
+
+func TestHistoryLines(t *testing.T) {
+	calc := calculator.NewCalculator()
+	calc.Evaluate("2+3")
+	calc.Evaluate("ans*2")
+
+	lines := historyLines(calc)
+	if strings.Join(lines, "\n") != "ans2: 2+3 = 5\nans1: ans*2 = 10" {
+		t.Errorf("Expected the history numbered by ans name, got %v", lines)
+	}
+}
+
+func TestPrecision(t *testing.T) {
+	for input, want := range map[string]int{"3": 3, "0": 0, "auto": calculator.DefaultPrecision} {
+		if got, err := parsePrecision(input); err != nil || got != want {
+			t.Errorf("parsePrecision(%q) = %d, %v, want %d", input, got, err, want)
+		}
+	}
+	for _, input := range []string{"-1", "three", ""} {
+		if _, err := parsePrecision(input); err == nil {
+			t.Errorf("Expected parsePrecision(%q) to fail", input)
+		}
+	}
+
+	calc := calculator.NewCalculator()
+	calc.Evaluate("100/3")
+	calc.Evaluate("12*3")
+
+	handlePrecision(calc, "3")
+	if calc.GetPrecision() != 3 {
+		t.Errorf("Expected precision 3 to be set, got %d", calc.GetPrecision())
+	}
+	lines := historyLines(calc)
+	if strings.Join(lines, "\n") != "ans2: 100/3 = 33.333\nans1: 12*3 = 36.000" {
+		t.Errorf("Expected the history with 3 decimal places, got %v", lines)
+	}
+
+	handlePrecision(calc, "0")
+	if lines := historyLines(calc); lines[1] != "ans1: 12*3 = 36" {
+		t.Errorf("Expected precision 0 to show integers cleanly, got %q", lines[1])
+	}
+
+	handlePrecision(calc, "bad")
+	if calc.GetPrecision() != 0 {
+		t.Errorf("Expected an invalid precision to be ignored, got %d", calc.GetPrecision())
+	}
+}
+
+func TestBase(t *testing.T) {
+	calc := calculator.NewCalculator()
+	handleBase(calc, "hex")
+	if calc.GetBase() != calculator.BaseHex {
+		t.Fatalf("Expected base hex to be set, got %s", calc.GetBase())
+	}
+
+	result, err := calc.Evaluate("0xFF + 0b1010")
+	if err != nil {
+		t.Fatalf("Failed to evaluate base literals: %v", err)
+	}
+	if got := calc.Format(result); got != "0x109" {
+		t.Errorf("Expected 0xFF + 0b1010 in hex, got %q", got)
+	}
+	if warning := baseWarning(calc, result); warning != "" {
+		t.Errorf("Expected no warning for a whole number, got %q", warning)
+	}
+
+	if warning := baseWarning(calc, 2.5); !strings.Contains(warning, "not a whole number") {
+		t.Errorf("Expected a warning for a fraction in hex, got %q", warning)
+	}
+
+	handleBase(calc, "base64")
+	if calc.GetBase() != calculator.BaseHex {
+		t.Errorf("Expected an unknown base to be ignored, got %s", calc.GetBase())
+	}
+
+	handleBase(calc, "dec")
+	if warning := baseWarning(calc, 2.5); warning != "" {
+		t.Errorf("Expected no warning in decimal, got %q", warning)
+	}
+}
+
+func TestRunBatch(t *testing.T) {
+	input := strings.Join([]string{
+		"# totals",
+		"set x = 4",
+		"",
+		"x * 2",
+		"1 / 0",
+		"x + 1",
+	}, "\n")
+
+	var out, errOut strings.Builder
+	failed := runBatch(calculator.NewCalculator(), strings.NewReader(input), &out, &errOut, false)
+	if failed != 1 {
+		t.Errorf("Expected 1 failed line, got %d", failed)
+	}
+	if got := out.String(); got != "x = 4\n= 8\n= 5\n" {
+		t.Errorf("Expected one result per evaluated line, got %q", got)
+	}
+	if !strings.HasPrefix(errOut.String(), "Line 5: ") {
+		t.Errorf("Expected the error to name line 5, got %q", errOut.String())
+	}
+
+	out.Reset()
+	errOut.Reset()
+	if failed := runBatch(calculator.NewCalculator(), strings.NewReader("2 + 2\n"), &out, &errOut, false); failed != 0 {
+		t.Errorf("Expected no failures, got %d: %q", failed, errOut.String())
+	}
+}
+
+func TestJSONOutput(t *testing.T) {
+	var out strings.Builder
+	if !evalExpressionJSON(&out, calculator.NewCalculator(), "123+456") {
+		t.Error("Expected 123+456 to succeed")
+	}
+	if got := out.String(); got != `{"expression":"123+456","result":579,"error":null}`+"\n" {
+		t.Errorf("Unexpected JSON for a result: %s", got)
+	}
+
+	out.Reset()
+	if evalExpressionJSON(&out, calculator.NewCalculator(), "1/0") {
+		t.Error("Expected 1/0 to fail")
+	}
+	var failure jsonResult
+	if err := json.Unmarshal([]byte(out.String()), &failure); err != nil {
+		t.Fatalf("Expected valid JSON for a failure, got %q: %v", out.String(), err)
+	}
+	if failure.Result != nil || failure.Error == nil || !strings.Contains(*failure.Error, "division by zero") {
+		t.Errorf("Expected a null result and the error, got %s", out.String())
+	}
+
+	// Precision applies and the base does not, so the result stays a number
+	calc := newCalculator(2, calculator.BaseHex)
+	out.Reset()
+	evalExpressionJSON(&out, calc, "10/3")
+	if !strings.Contains(out.String(), `"result":3.33,`) {
+		t.Errorf("Expected the result rounded to 2 places, got %s", out.String())
+	}
+
+	// Batch mode writes one JSON object per evaluated line
+	out.Reset()
+	var errOut strings.Builder
+	failed := runBatch(calculator.NewCalculator(), strings.NewReader("set x = 2\nx << 3\n1/0\n"), &out, &errOut, true)
+	if failed != 1 {
+		t.Errorf("Expected 1 failed line, got %d", failed)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	want := []string{
+		`{"expression":"set x = 2","result":2,"error":null}`,
+		`{"expression":"x << 3","result":16,"error":null}`,
+	}
+	if len(lines) != 3 || lines[0] != want[0] || lines[1] != want[1] || !strings.Contains(lines[2], `"result":null`) {
+		t.Errorf("Unexpected batch JSON output:\n%s", out.String())
+	}
+
+	// Write errors are not dropped
+	if evalExpressionJSON(failingWriter{}, calculator.NewCalculator(), "1+1") {
+		t.Error("Expected a failed write to be reported")
+	}
+	errOut.Reset()
+	if failed := runBatch(calculator.NewCalculator(), strings.NewReader("1+1\n"), failingWriter{}, &errOut, true); failed != 1 {
+		t.Errorf("Expected the unwritten line to count as failed, got %d", failed)
+	}
+	if !strings.Contains(errOut.String(), "Line 1: error writing output") {
+		t.Errorf("Expected the write error on errOut, got %q", errOut.String())
+	}
+}
+
+// failingWriter fails every write
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write failed")
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"golang.org/x/term"
+
+	"ccpm-demo/internal/calculator"
+)
+
+// lineReader reads REPL input lines. On a terminal it edits lines in raw mode
+// so that Tab can complete names; otherwise it reads plain lines.
+type lineReader struct {
+	prompt   string
+	terminal *term.Terminal
+	reader   *bufio.Reader
+}
+
+// newLineReader creates a line reader for stdin that completes names known to calc
+func newLineReader(calc *calculator.Calculator, prompt string) *lineReader {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return &lineReader{prompt: prompt, reader: bufio.NewReader(os.Stdin)}
+	}
+
+	terminal := term.NewTerminal(struct {
+		io.Reader
+		io.Writer
+	}{os.Stdin, os.Stdout}, prompt)
+	terminal.AutoCompleteCallback = (&completer{calc: calc}).complete
+	return &lineReader{prompt: prompt, terminal: terminal}
+}
+
+// ReadLine shows the prompt and reads the next line, without its line ending
+func (r *lineReader) ReadLine() (string, error) {
+	if r.terminal == nil {
+		fmt.Print(r.prompt)
+		return r.reader.ReadString('\n')
+	}
+
+	// Raw mode only lasts for the read, so command output prints normally
+	fd := int(os.Stdin.Fd())
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return "", err
+	}
+	defer term.Restore(fd, state)
+
+	return r.terminal.ReadLine()
+}
+
+// completer completes the name before the cursor, cycling through the
+// candidates when Tab is pressed again
+type completer struct {
+	calc *calculator.Calculator
+
+	// The completion in progress: the text around the completed name, its
+	// candidates, the one shown and the line showing it
+	head, tail string
+	candidates []string
+	index      int
+	line       string
+}
+
+// complete implements term.Terminal's AutoCompleteCallback
+func (c *completer) complete(line string, pos int, key rune) (string, int, bool) {
+	if key != '\t' {
+		c.candidates = nil
+		return "", 0, false
+	}
+
+	if len(c.candidates) > 0 && line == c.line {
+		c.index = (c.index + 1) % len(c.candidates)
+	} else {
+		start := pos
+		for start > 0 && isNameByte(line[start-1]) {
+			start--
+		}
+		c.candidates = completions(c.calc, line[start:pos])
+		if len(c.candidates) == 0 {
+			return "", 0, false
+		}
+		c.head, c.tail, c.index = line[:start], line[pos:], 0
+	}
+
+	candidate := c.candidates[c.index]
+	c.line = c.head + candidate + c.tail
+	return c.line, len(c.head) + len(candidate), true
+}
+
+// completions returns the function names, with their opening bracket, and the
+// variable names of calc that start with prefix, in sorted order
+func completions(calc *calculator.Calculator, prefix string) []string {
+	if prefix == "" {
+		return nil
+	}
+
+	var candidates []string
+	for _, name := range calculator.FunctionNames() {
+		if strings.HasPrefix(name, prefix) {
+			candidates = append(candidates, name+"(")
+		}
+	}
+	for name := range calc.GetVariables() {
+		if strings.HasPrefix(name, prefix) {
+			candidates = append(candidates, name)
+		}
+	}
+
+	sort.Strings(candidates)
+	return candidates
+}
+
+// isNameByte reports whether b can be part of a function or variable name
+func isNameByte(b byte) bool {
+	return b == '_' || (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z') || (b >= '0' && b <= '9')
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"ccpm-demo/internal/calculator"
+)
+
+// watch is a named expression that is re-printed whenever a variable it
+// depends on changes
+type watch struct {
+	name         string
+	expression   string
+	dependencies map[string]bool
+}
+
+// watchList holds the REPL's watches and prints their values to out
+type watchList struct {
+	out     io.Writer
+	watches []watch
+}
+
+// newWatchList creates an empty watch list printing to out
+func newWatchList(out io.Writer) *watchList {
+	return &watchList{out: out}
+}
+
+// Add registers a "name = expression" watch, replacing any watch of the same
+// name, and prints its current value
+func (wl *watchList) Add(calc *calculator.Calculator, input string) error {
+	parts := strings.SplitN(input, "=", 2)
+	if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+		return fmt.Errorf("usage: watch name = expression")
+	}
+
+	w := watch{
+		name:         strings.TrimSpace(parts[0]),
+		expression:   strings.TrimSpace(parts[1]),
+		dependencies: expressionVariables(strings.TrimSpace(parts[1])),
+	}
+
+	for i := range wl.watches {
+		if wl.watches[i].name == w.name {
+			wl.watches = append(wl.watches[:i], wl.watches[i+1:]...)
+			break
+		}
+	}
+	wl.watches = append(wl.watches, w)
+
+	wl.print(calc, w)
+	return nil
+}
+
+// Changed re-evaluates and prints every watch that depends on variable
+func (wl *watchList) Changed(calc *calculator.Calculator, variable string) {
+	for _, w := range wl.watches {
+		if w.dependencies[variable] {
+			wl.print(calc, w)
+		}
+	}
+}
+
+// print evaluates a watch and prints its value or error
+func (wl *watchList) print(calc *calculator.Calculator, w watch) {
+	result, err := calc.Preview(w.expression)
+	if err != nil {
+		fmt.Fprintf(wl.out, "%s: Error: %v\n", w.name, err)
+		return
+	}
+	fmt.Fprintf(wl.out, "%s = %s\n", w.name, calc.Format(result))
+}
+
+// expressionVariables returns the names used as variables in expression,
+// leaving out numbers and function calls
+func expressionVariables(expression string) map[string]bool {
+	variables := make(map[string]bool)
+	for i := 0; i < len(expression); {
+		if !isNameByte(expression[i]) {
+			i++
+			continue
+		}
+
+		start := i
+		for i < len(expression) && isNameByte(expression[i]) {
+			i++
+		}
+		name := expression[start:i]
+
+		isNumber := name[0] >= '0' && name[0] <= '9'
+		isCall := strings.HasPrefix(strings.TrimLeft(expression[i:], " "), "(")
+		if !isNumber && !isCall {
+			variables[name] = true
+		}
+	}
+	return variables
+}